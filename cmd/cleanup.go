@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"template-golang/internal/cleanup"
+	"template-golang/internal/db"
+	"template-golang/pkg/config"
+	utlog "template-golang/pkg/logger"
+	"template-golang/pkg/queue"
+	"template-golang/pkg/redisx"
+	"template-golang/pkg/storage"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	cleanupDryRun       bool
+	cleanupTmpTTL       time.Duration
+	cleanupOrphanMinAge time.Duration
+	cleanupPendingTTL   time.Duration
+)
+
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Bersihkan data yang tertinggal",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		cfg := config.LoadConfig()
+		utlog.Init(cfg.Env)
+	},
+}
+
+var cleanupFilesCmd = &cobra.Command{
+	Use:   "files",
+	Short: "Hapus file tmp lama dan object storage tanpa referensi database (--dry-run untuk laporan saja)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.GetConfig()
+		if !cmd.Flags().Changed("tmp-ttl") {
+			cleanupTmpTTL = cfg.FileGCTmpTTL
+		}
+		if !cmd.Flags().Changed("min-age") {
+			cleanupOrphanMinAge = cfg.FileGCOrphanMinAge
+		}
+		if !cmd.Flags().Changed("pending-ttl") {
+			cleanupPendingTTL = cfg.FileGCPendingUploadTTL
+		}
+
+		conn, err := db.ConnectDB()
+		if err != nil {
+			return fmt.Errorf("failed to connect db: %w", err)
+		}
+		defer db.CloseDB()
+
+		store, err := storage.New()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		// antrian job dibaca supaya file tmp job upload yang belum jalan tidak dihapus
+		client, err := redisx.New()
+		if err != nil {
+			return fmt.Errorf("failed to initialize redis client: %w", err)
+		}
+
+		report, err := cleanup.Files(context.Background(), conn, store, queue.NewClient(client), cleanup.Options{
+			DryRun:           cleanupDryRun,
+			TmpTTL:           cleanupTmpTTL,
+			OrphanMinAge:     cleanupOrphanMinAge,
+			PendingUploadTTL: cleanupPendingTTL,
+		})
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TYPE\tPATH\tSIZE\tMODIFIED AT")
+		for _, item := range report.TmpFiles {
+			fmt.Fprintf(w, "tmp\t%s\t%d\t%s\n", item.Path, item.Size, item.ModifiedAt.Local().Format(time.DateTime))
+		}
+		for _, item := range report.ExpiredUploads {
+			fmt.Fprintf(w, "upload\t%s\t%d\t%s\n", item.Path, item.Size, item.ModifiedAt.Local().Format(time.DateTime))
+		}
+		for _, item := range report.Orphans {
+			fmt.Fprintf(w, "orphan\t%s\t%d\t%s\n", item.Path, item.Size, item.ModifiedAt.Local().Format(time.DateTime))
+		}
+		w.Flush()
+
+		verb := "deleted"
+		if report.DryRun {
+			verb = "would delete"
+		}
+		fmt.Printf("\n%s %d tmp file(s), %d expired upload(s) and %d orphan(s), %d bytes; scanned %d object(s) in %v\n",
+			verb, len(report.TmpFiles), len(report.ExpiredUploads), len(report.Orphans), report.FreedBytes, report.Scanned, report.Prefixes)
+		for _, e := range report.Errors {
+			fmt.Println("error:", e)
+		}
+		if err != nil {
+			return err
+		}
+		if len(report.Errors) > 0 {
+			return fmt.Errorf("failed to delete %d file(s)", len(report.Errors))
+		}
+		return nil
+	},
+}
+
+func init() {
+	cleanupFilesCmd.Flags().BoolVar(&cleanupDryRun, "dry-run", false, "laporan saja, tidak ada yang dihapus")
+	cleanupFilesCmd.Flags().DurationVar(&cleanupTmpTTL, "tmp-ttl", 0, "umur file tmp yang dihapus, default FILE_GC_TMP_TTL")
+	cleanupFilesCmd.Flags().DurationVar(&cleanupOrphanMinAge, "min-age", 0, "umur minimal object tanpa referensi yang dihapus, default FILE_GC_ORPHAN_MIN_AGE")
+	cleanupFilesCmd.Flags().DurationVar(&cleanupPendingTTL, "pending-ttl", 0, "umur upload presigned pending yang dihapus, default FILE_GC_PENDING_UPLOAD_TTL")
+	cleanupCmd.AddCommand(cleanupFilesCmd)
+	rootCmd.AddCommand(cleanupCmd)
+}
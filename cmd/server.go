@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 
 	_ "template-golang/docs"
 	"template-golang/pkg/config"
@@ -25,8 +29,15 @@ var serverCmd = &cobra.Command{
 			panic(fmt.Errorf("failed to initialize app: %v", err))
 		}
 
-		app.Listen(":" + fmt.Sprintf("%d", cfg.Port))
+		// SIGINT / SIGTERM: tunggu request berjalan selesai, hentikan hub & gateway
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
 
+		if err := app.Run(ctx, ":"+fmt.Sprintf("%d", cfg.Port)); err != nil {
+			utlog.L().Errorf("server stopped: %v", err)
+			os.Exit(1)
+		}
+		utlog.L().Info("👋 Server stopped")
 	},
 }
 
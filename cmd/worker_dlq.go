@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"template-golang/pkg/config"
+	utlog "template-golang/pkg/logger"
+	"template-golang/pkg/queue"
+	"template-golang/pkg/redisx"
+
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dlqLimit int64
+	dlqAll   bool
+	// dlqQueue client queue, dibuat di PersistentPreRunE
+	dlqQueue *queue.Client
+)
+
+var dlqCmd = &cobra.Command{
+	Use:   "dlq",
+	Short: "Kelola job di dead-letter stream",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.LoadConfig()
+		utlog.Init(cfg.Env)
+
+		client, err := redisx.New()
+		if err != nil {
+			return fmt.Errorf("failed to initialize redis client: %w", err)
+		}
+		dlqQueue = queue.NewClient(client)
+		return nil
+	},
+}
+
+var dlqListCmd = &cobra.Command{
+	Use:   "list [job]",
+	Short: "List dead-letter stream, atau job yang gagal untuk satu job",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		defer w.Flush()
+
+		if len(args) == 0 {
+			queues, err := dlqQueue.DeadLetterQueues(ctx)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(w, "JOB\tSTREAM\tCOUNT")
+			for _, q := range queues {
+				fmt.Fprintf(w, "%s\t%s\t%d\n", q.Name, q.Stream, q.Count)
+			}
+			return nil
+		}
+
+		jobs, err := dlqQueue.ListDead(ctx, args[0], dlqLimit)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, "ID\tJOB ID\tATTEMPTS\tFAILED AT\tERROR")
+		for _, job := range jobs {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", job.ID, job.JobID, job.Attempts, job.FailedAt.Local().Format(time.DateTime), job.Error)
+		}
+		return nil
+	},
+}
+
+var dlqShowCmd = &cobra.Command{
+	Use:   "show <job> <id>",
+	Short: "Tampilkan detail job di dead-letter stream",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		job, err := dlqQueue.GetDead(context.Background(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+		out, err := json.MarshalIndent(job, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	},
+}
+
+var dlqReplayCmd = &cobra.Command{
+	Use:   "replay <job> [id...]",
+	Short: "Antrikan ulang job dari dead-letter stream (--all untuk semua)",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		name, ids := args[0], args[1:]
+
+		if dlqAll {
+			jobIDs, err := dlqQueue.ReplayAllDead(ctx, name)
+			fmt.Printf("replayed %d job(s)\n", len(jobIDs))
+			return err
+		}
+		if len(ids) == 0 {
+			return fmt.Errorf("id required, or use --all")
+		}
+
+		for _, id := range ids {
+			jobID, err := dlqQueue.ReplayDead(ctx, name, id)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("replayed %s as job %s\n", id, jobID)
+		}
+		return nil
+	},
+}
+
+var dlqPurgeCmd = &cobra.Command{
+	Use:   "purge <job> [id...]",
+	Short: "Hapus job dari dead-letter stream, tanpa id hapus semua",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		n, err := dlqQueue.PurgeDead(context.Background(), args[0], args[1:]...)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("purged %d job(s)\n", n)
+		return nil
+	},
+}
+
+func init() {
+	dlqListCmd.Flags().Int64VarP(&dlqLimit, "limit", "n", 50, "jumlah job yang ditampilkan")
+	dlqReplayCmd.Flags().BoolVar(&dlqAll, "all", false, "replay semua job")
+
+	dlqCmd.AddCommand(dlqListCmd, dlqShowCmd, dlqReplayCmd, dlqPurgeCmd)
+	workerCmd.AddCommand(dlqCmd)
+}
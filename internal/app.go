@@ -1,19 +1,101 @@
 package internal
 
 import (
+	"context"
+	"sync"
+	"time"
+
+	"template-golang/internal/db/model"
+	alumni_handler "template-golang/internal/features/alumni/handler"
+	brochure_handler "template-golang/internal/features/brochures/handler"
+	event_handler "template-golang/internal/features/events/handler"
+	facility_handler "template-golang/internal/features/facilities/handler"
+	file_handler "template-golang/internal/features/files/handler"
+	job_handler "template-golang/internal/features/jobs/handler"
+	realtime_handler "template-golang/internal/features/realtime/handler"
+	registration_handler "template-golang/internal/features/registrations/handler"
+	upload_handler "template-golang/internal/features/uploads/handler"
 	user_handler "template-golang/internal/features/users/handler"
-	"template-golang/pkg/fileUploader"
+	"template-golang/pkg/events"
+	"template-golang/pkg/gateway"
+	"template-golang/pkg/logger"
 	"template-golang/pkg/middleware"
+	"template-golang/pkg/storage"
 
 	"github.com/goccy/go-json"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/swagger" // swagger handler
+	"gorm.io/gorm"
 )
 
+// shutdownTimeout batas waktu menunggu request yang sedang berjalan saat server berhenti
+const shutdownTimeout = 10 * time.Second
+
+// App HTTP server beserta background service yang hidup selama server berjalan:
+// hub event (SSE) dan gateway WebSocket
+type App struct {
+	*fiber.App
+	hub     *events.Hub
+	gateway *gateway.Gateway
+}
+
+// Run jalankan hub, gateway dan HTTP server di addr sampai ctx selesai. Setelah itu
+// server di-shutdown (maksimal shutdownTimeout) lalu hub & gateway dihentikan.
+func (a *App) Run(ctx context.Context, addr string) error {
+	bgCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		a.hub.Run(bgCtx)
+	}()
+	go func() {
+		defer wg.Done()
+		a.gateway.Run(bgCtx)
+	}()
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- a.Listen(addr)
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.L().Infof("server: shutting down, waiting up to %s for in-flight requests", shutdownTimeout)
+	if err := a.ShutdownWithTimeout(shutdownTimeout); err != nil {
+		return err
+	}
+	return <-errc
+}
+
 func NewUtschoolApp(
+	conn *gorm.DB,
+	store storage.Storage,
+	hub *events.Hub,
+	gw *gateway.Gateway,
 	userHandler *user_handler.Handler,
-) *fiber.App {
+	registrationHandler *registration_handler.Handler,
+	brochureHandler *brochure_handler.Handler,
+	facilityHandler *facility_handler.Handler,
+	alumniHandler *alumni_handler.Handler,
+	jobHandler *job_handler.Handler,
+	eventHandler *event_handler.Handler,
+	realtimeHandler *realtime_handler.Handler,
+	uploadHandler *upload_handler.Handler,
+	fileHandler *file_handler.Handler,
+) (*App, error) {
+	if err := model.RegisterFileURLs(conn, store); err != nil {
+		return nil, err
+	}
 
 	app := fiber.New(fiber.Config{
 		ServerHeader: "Fiber",
@@ -24,9 +106,19 @@ func NewUtschoolApp(
 	})
 	app.Use(recover.New())
 
+	// Field konten multibahasa bisa dikirim sebagai objek JSON di form multipart
+	fiber.SetParserDecoder(fiber.ParserConfig{
+		IgnoreUnknownKeys: true,
+		ZeroEmpty:         true,
+		ParserType: []fiber.ParserType{
+			{Customtype: model.Translations{}, Converter: model.TranslationsConverter},
+		},
+	})
+
 	app.Use(middleware.RequestIDMiddleware())
 	app.Use(middleware.LoggerMiddleware())
 	app.Use(middleware.CorsMiddleware())
+	app.Use(middleware.LocaleMiddleware())
 
 	app.Get("/swagger/*", swagger.New(swagger.Config{
 		DocExpansion: "none",
@@ -35,12 +127,25 @@ func NewUtschoolApp(
 		return c.SendFile("./swagger/swagger.json")
 	})
 
-	if err := fileUploader.InitS3Client(); err != nil {
-		panic(err)
+	// driver local: file dilayani langsung oleh API, file private hanya lewat /files/:id/download
+	if local, ok := store.(*storage.Local); ok {
+		prefix, dir := local.StaticRoute()
+		app.Static(prefix, dir, fiber.Static{
+			Next: func(c *fiber.Ctx) bool { return local.IsPrivatePath(c.Path()) },
+		})
 	}
 
 	api := app.Group("/api/v1")
 	userHandler.RegisterRoutes(api)
+	registrationHandler.RegisterRoutes(api)
+	brochureHandler.RegisterRoutes(api)
+	facilityHandler.RegisterRoutes(api)
+	alumniHandler.RegisterRoutes(api)
+	jobHandler.RegisterRoutes(api)
+	eventHandler.RegisterRoutes(api)
+	realtimeHandler.RegisterRoutes(api)
+	uploadHandler.RegisterRoutes(api)
+	fileHandler.RegisterRoutes(api)
 
 	api.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
@@ -48,5 +153,5 @@ func NewUtschoolApp(
 		})
 	})
 
-	return app
+	return &App{App: app, hub: hub, gateway: gw}, nil
 }
@@ -0,0 +1,345 @@
+// Package cleanup garbage collector file: file tmp yang tertinggal dan object storage
+// yang sudah tidak direferensikan database. Dijalankan terjadwal oleh worker (job
+// cleanup_files) atau manual lewat CLI "cleanup files".
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"template-golang/internal/db/model"
+	"template-golang/pkg/fileUploader"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/queue"
+	"template-golang/pkg/storage"
+
+	"gorm.io/gorm"
+)
+
+// FilesJob nama job GC file (stream cleanup_files_jobs)
+const FilesJob = "cleanup_files"
+
+// FilesPayload payload job FilesJob
+type FilesPayload struct {
+	// DryRun hanya laporan, tidak ada yang dihapus
+	DryRun bool `json:"dry_run"`
+}
+
+// urlColumns kolom URL file per tabel, dari sebelum ada tabel files. Object yang URL-nya
+// masih tersimpan di sini (termasuk baris yang di-soft delete) tidak dianggap yatim.
+var urlColumns = map[string][]string{
+	model.User{}.TableName():         {"avatar_64", "avatar_256", "avatar_512"},
+	model.Brochure{}.TableName():     {"file_url", "thumbnail_url"},
+	model.Facility{}.TableName():     {"image_url"},
+	model.Alumni{}.TableName():       {"photo_url", "company_logo_url"},
+	model.Registration{}.TableName(): {"proof_url"},
+}
+
+// Options batas umur file yang dihapus
+type Options struct {
+	DryRun bool
+	// TmpTTL file di fileUploader.TmpDir yang lebih lama dari ini dihapus
+	TmpTTL time.Duration
+	// OrphanMinAge object tanpa referensi baru dihapus setelah seumur ini, supaya
+	// upload yang baru selesai tapi barisnya belum tercatat tidak ikut terhapus
+	OrphanMinAge time.Duration
+	// PendingUploadTTL upload presigned yang masih pending di staging setelah seumur ini
+	// dianggap batal: baris files dan object staging-nya dihapus
+	PendingUploadTTL time.Duration
+}
+
+// Item satu file / object yang dihapus (atau akan dihapus saat dry run)
+type Item struct {
+	Path       string    `json:"path"`
+	Size       int64     `json:"size"`
+	ModifiedAt time.Time `json:"modified_at"`
+}
+
+// Report hasil GC
+type Report struct {
+	DryRun   bool   `json:"dry_run"`
+	TmpFiles []Item `json:"tmp_files"`
+	Orphans  []Item `json:"orphans"`
+	// ExpiredUploads upload presigned yang tidak pernah di-complete
+	ExpiredUploads []Item `json:"expired_uploads"`
+	// Prefixes folder storage yang diperiksa, Scanned jumlah object di dalamnya
+	Prefixes []string `json:"prefixes"`
+	Scanned  int      `json:"scanned"`
+	// FreedBytes total ukuran TmpFiles, Orphans dan ExpiredUploads
+	FreedBytes int64    `json:"freed_bytes"`
+	Errors     []string `json:"errors,omitempty"`
+}
+
+// Files hapus file tmp lama dan object storage yang tidak direferensikan tabel files
+// maupun kolom URL lama. Hanya folder yang dipakai aplikasi (folder teratas key yang
+// direferensikan database) yang diperiksa, jadi bucket yang dipakai bersama aplikasi
+// lain aman. File tmp milik job upload yang masih di antrian q tidak dihapus.
+// Gagal hapus dicatat di Report.Errors, GC tetap lanjut.
+func Files(ctx context.Context, conn *gorm.DB, store storage.Storage, q *queue.Client, opts Options) (Report, error) {
+	report := Report{DryRun: opts.DryRun, TmpFiles: []Item{}, Orphans: []Item{}, ExpiredUploads: []Item{}}
+	now := time.Now()
+
+	queued, err := queuedTmpFiles(ctx, q)
+	if err != nil {
+		return report, err
+	}
+	if err := cleanTmp(now.Add(-opts.TmpTTL), queued, &report); err != nil {
+		return report, err
+	}
+	if err := expireUploads(ctx, conn, store, now.Add(-opts.PendingUploadTTL), &report); err != nil {
+		return report, err
+	}
+
+	refs, err := referencedKeys(ctx, conn, store)
+	if err != nil {
+		return report, err
+	}
+	report.Prefixes = topFolders(refs)
+
+	for _, prefix := range report.Prefixes {
+		objects, err := store.List(ctx, prefix+"/")
+		if err != nil {
+			return report, err
+		}
+		for _, obj := range objects {
+			report.Scanned++
+			if refs[obj.Key] || now.Sub(obj.ModifiedAt) < opts.OrphanMinAge {
+				continue
+			}
+			if !opts.DryRun {
+				// bisa saja dipakai lagi sejak referensi dibaca (isi sama diupload ulang)
+				inUse, err := KeyInUse(ctx, conn, obj.Key)
+				if err != nil {
+					return report, err
+				}
+				if inUse {
+					continue
+				}
+				if err := store.Delete(ctx, obj.Key); err != nil {
+					report.Errors = append(report.Errors, err.Error())
+					continue
+				}
+				logger.L().Infof("cleanup: deleted orphan %s", obj.Key)
+			}
+			report.Orphans = append(report.Orphans, Item{Path: obj.Key, Size: obj.Size, ModifiedAt: obj.ModifiedAt})
+			report.FreedBytes += obj.Size
+		}
+	}
+	return report, nil
+}
+
+// KeyInUse key dipakai file aktif di tabel files, sebagai file utama maupun varian
+func KeyInUse(ctx context.Context, conn *gorm.DB, key string) (bool, error) {
+	var count int64
+	err := conn.WithContext(ctx).Model(&model.File{}).
+		Where("storage_key = ? OR EXISTS (SELECT 1 FROM jsonb_each_text(variants) v WHERE v.value = ?)", key, key).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// URLInUse URL file masih dipakai: key-nya dipakai file aktif di tabel files (KeyInUse),
+// atau URL-nya masih tersimpan di kolom URL lama (urlColumns), termasuk baris yang di-soft delete
+func URLInUse(ctx context.Context, conn *gorm.DB, store storage.Storage, fileURL string) (bool, error) {
+	if key, ok := store.Key(fileURL); ok {
+		inUse, err := KeyInUse(ctx, conn, key)
+		if err != nil || inUse {
+			return inUse, err
+		}
+	}
+	for table, columns := range urlColumns {
+		for _, column := range columns {
+			var count int64
+			if err := conn.WithContext(ctx).Table(table).Where(column+" = ?", fileURL).Count(&count).Error; err != nil {
+				return false, fmt.Errorf("failed to check %s.%s: %w", table, column, err)
+			}
+			if count > 0 {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// cleanTmp hapus file di TmpDir yang terakhir diubah sebelum cutoff: file staging job yang
+// dibuang (payload rusak, masuk DLQ), penanda .multipart dan PDF bukti pendaftaran. File di
+// queued (beserta penanda .multipart-nya) masih ditunggu job dan dilewati.
+func cleanTmp(cutoff time.Time, queued map[string]bool, report *Report) error {
+	entries, err := os.ReadDir(fileUploader.TmpDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", fileUploader.TmpDir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		path := filepath.Join(fileUploader.TmpDir, entry.Name())
+		if queued[path] || queued[strings.TrimSuffix(path, fileUploader.MultipartSuffix)] {
+			continue
+		}
+		if !report.DryRun {
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				report.Errors = append(report.Errors, err.Error())
+				continue
+			}
+			logger.L().Infof("cleanup: deleted tmp file %s", path)
+		}
+		report.TmpFiles = append(report.TmpFiles, Item{Path: path, Size: info.Size(), ModifiedAt: info.ModTime()})
+		report.FreedBytes += info.Size()
+	}
+	return nil
+}
+
+// expireUploads hapus upload presigned yang dibuat sebelum cutoff tapi tidak pernah di-complete:
+// baris files masih pending dengan key di storage.StagingPrefix. Baris dihapus lebih dulu
+// (hanya kalau masih pending di staging, complete yang berjalan bersamaan menang), lalu object
+// staging-nya kalau sempat diupload client.
+func expireUploads(ctx context.Context, conn *gorm.DB, store storage.Storage, cutoff time.Time, report *Report) error {
+	var files []model.File
+	err := conn.WithContext(ctx).
+		Where("status = ? AND storage_key LIKE ? AND created_at < ?", model.FileStatusPending, storage.StagingPrefix+"%", cutoff).
+		Find(&files).Error
+	if err != nil {
+		return fmt.Errorf("failed to load pending uploads: %w", err)
+	}
+
+	for _, file := range files {
+		item := Item{Path: file.StorageKey, ModifiedAt: file.CreatedAt}
+		obj, err := store.Stat(ctx, file.StorageKey)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			report.Errors = append(report.Errors, err.Error())
+			continue
+		}
+		if err == nil {
+			item.Size, item.ModifiedAt = obj.Size, obj.ModifiedAt
+		}
+
+		if !report.DryRun {
+			res := conn.WithContext(ctx).
+				Where("id = ? AND status = ? AND storage_key = ?", file.ID, model.FileStatusPending, file.StorageKey).
+				Delete(&model.File{})
+			if res.Error != nil {
+				report.Errors = append(report.Errors, res.Error.Error())
+				continue
+			}
+			if res.RowsAffected == 0 {
+				continue
+			}
+			if err := store.Delete(ctx, file.StorageKey); err != nil {
+				report.Errors = append(report.Errors, err.Error())
+				continue
+			}
+			logger.L().Infof("cleanup: deleted expired upload %s (%s)", file.ID, file.StorageKey)
+		}
+		report.ExpiredUploads = append(report.ExpiredUploads, item)
+		report.FreedBytes += item.Size
+	}
+	return nil
+}
+
+// queuedTmpFiles file tmp yang ditunggu job upload di antrian: job yang masih antri, berjalan,
+// menunggu retry atau tertunda (queue.Client.LivePayloads)
+func queuedTmpFiles(ctx context.Context, q *queue.Client) (map[string]bool, error) {
+	queued := map[string]bool{}
+	add := func(path string) {
+		if path != "" {
+			queued[filepath.Clean(path)] = true
+		}
+	}
+
+	payloads, err := q.LivePayloads(ctx, fileUploader.UploadJob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load queued uploads: %w", err)
+	}
+	for _, data := range payloads {
+		var payload fileUploader.QueueUploadFile
+		if err := queue.DefaultCodec.Unmarshal([]byte(data), &payload); err == nil && payload.FilePathTmp != nil {
+			add(*payload.FilePathTmp)
+		}
+	}
+
+	payloads, err = q.LivePayloads(ctx, fileUploader.PDFUploadJob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load queued uploads: %w", err)
+	}
+	for _, data := range payloads {
+		var payload fileUploader.QueueUploadPDF
+		if err := queue.DefaultCodec.Unmarshal([]byte(data), &payload); err == nil {
+			add(payload.FilePath)
+		}
+	}
+	return queued, nil
+}
+
+// referencedKeys semua key yang direferensikan baris files aktif (key & varian) dan urlColumns
+func referencedKeys(ctx context.Context, conn *gorm.DB, store storage.Storage) (map[string]bool, error) {
+	refs := map[string]bool{}
+
+	rows, err := conn.WithContext(ctx).Model(&model.File{}).Select("storage_key", "variants").Rows()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load files: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key string
+		var variants model.FileKeys
+		if err := rows.Scan(&key, &variants); err != nil {
+			return nil, fmt.Errorf("failed to load files: %w", err)
+		}
+		refs[key] = true
+		for _, variant := range variants {
+			refs[variant] = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load files: %w", err)
+	}
+
+	for table, columns := range urlColumns {
+		for _, column := range columns {
+			var urls []string
+			err := conn.WithContext(ctx).Table(table).
+				Where(column+" IS NOT NULL AND "+column+" <> ''").
+				Distinct().Pluck(column, &urls).Error
+			if err != nil {
+				return nil, fmt.Errorf("failed to load %s.%s: %w", table, column, err)
+			}
+			for _, url := range urls {
+				if key, ok := store.Key(url); ok {
+					refs[key] = true
+				}
+			}
+		}
+	}
+	return refs, nil
+}
+
+// topFolders folder teratas key, terurut. Key tanpa folder tidak diperiksa.
+func topFolders(keys map[string]bool) []string {
+	seen := map[string]bool{}
+	for key := range keys {
+		if folder, _, ok := strings.Cut(key, "/"); ok && folder != "" {
+			seen[folder] = true
+		}
+	}
+	folders := make([]string, 0, len(seen))
+	for folder := range seen {
+		folders = append(folders, folder)
+	}
+	sort.Strings(folders)
+	return folders
+}
@@ -0,0 +1,191 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"template-golang/internal/db/dbtest"
+	"template-golang/internal/db/model"
+	"template-golang/pkg/config"
+	"template-golang/pkg/fileUploader"
+	"template-golang/pkg/queue"
+	"template-golang/pkg/redisx"
+	"template-golang/pkg/storage"
+
+	"github.com/alicebob/miniredis/v2"
+	"gorm.io/gorm"
+)
+
+func newTestQueue(t *testing.T) *queue.Client {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	t.Setenv("REDIS_ADDR", mr.Addr())
+	config.LoadConfig()
+
+	client, err := redisx.New()
+	if err != nil {
+		t.Fatalf("redisx.New: %v", err)
+	}
+	return queue.NewClient(client)
+}
+
+// writeTmp tulis file di TmpDir dengan waktu ubah age yang lalu
+func writeTmp(t *testing.T, name string, age time.Duration) string {
+	t.Helper()
+	path := filepath.Join(fileUploader.TmpDir, name)
+	if err := os.WriteFile(path, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modified := time.Now().Add(-age)
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCleanTmpKeepsQueuedFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll(fileUploader.TmpDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	q := newTestQueue(t)
+
+	queuedUpload := writeTmp(t, "queued.jpg", 48*time.Hour)
+	queuedMarker := writeTmp(t, "queued.jpg"+fileUploader.MultipartSuffix, 48*time.Hour)
+	delayedPDF := writeTmp(t, "delayed.pdf", 48*time.Hour)
+	stale := writeTmp(t, "stale.jpg", 48*time.Hour)
+	staleMarker := writeTmp(t, "stale.jpg"+fileUploader.MultipartSuffix, 48*time.Hour)
+	recent := writeTmp(t, "recent.jpg", time.Minute)
+
+	tmp := queuedUpload
+	if _, err := q.Enqueue(ctx, fileUploader.UploadJob, fileUploader.QueueUploadFile{FilePathTmp: &tmp}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if _, err := q.Enqueue(ctx, fileUploader.PDFUploadJob, fileUploader.QueueUploadPDF{FilePath: delayedPDF}, queue.Delay(time.Hour)); err != nil {
+		t.Fatalf("Enqueue delayed: %v", err)
+	}
+
+	queued, err := queuedTmpFiles(ctx, q)
+	if err != nil {
+		t.Fatalf("queuedTmpFiles: %v", err)
+	}
+
+	// dry run hanya melaporkan
+	report := Report{DryRun: true}
+	if err := cleanTmp(time.Now().Add(-24*time.Hour), queued, &report); err != nil {
+		t.Fatalf("cleanTmp dry run: %v", err)
+	}
+	if len(report.TmpFiles) != 2 {
+		t.Fatalf("dry run reported %+v, want stale file and its marker", report.TmpFiles)
+	}
+	if _, err := os.Stat(stale); err != nil {
+		t.Fatalf("dry run deleted %s: %v", stale, err)
+	}
+
+	report = Report{}
+	if err := cleanTmp(time.Now().Add(-24*time.Hour), queued, &report); err != nil {
+		t.Fatalf("cleanTmp: %v", err)
+	}
+	for _, path := range []string{stale, staleMarker} {
+		if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("%s not deleted: %v", path, err)
+		}
+	}
+	for _, path := range []string{queuedUpload, queuedMarker, delayedPDF, recent} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s deleted: %v", path, err)
+		}
+	}
+	if report.FreedBytes != 8 {
+		t.Errorf("FreedBytes = %d, want 8", report.FreedBytes)
+	}
+}
+
+func TestTopFolders(t *testing.T) {
+	got := topFolders(map[string]bool{
+		"avatars/a_64.webp":     true,
+		"avatars/b.webp":        true,
+		"private/bukti/c.pdf":   true,
+		"root.txt":              true,
+		"/leading-slash/d.webp": true,
+	})
+	want := []string{"avatars", "private"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("topFolders = %v, want %v", got, want)
+	}
+}
+
+func TestFilesDeletesOrphansAndExpiredUploads(t *testing.T) {
+	conn := dbtest.New(t)
+	t.Chdir(t.TempDir())
+	ctx := context.Background()
+	q := newTestQueue(t)
+	store := storage.NewMemory("http://localhost/storage")
+
+	put := func(key string) {
+		t.Helper()
+		if _, err := store.Put(ctx, key, strings.NewReader("data"), storage.PutOptions{}); err != nil {
+			t.Fatalf("Put %s: %v", key, err)
+		}
+	}
+	for _, key := range []string{"avatars/a.webp", "avatars/a_64.webp", "avatars/old.webp", "other-app/x.bin", "private/staging/p.jpg", "private/staging/q.jpg"} {
+		put(key)
+	}
+
+	ready := model.File{StorageKey: "avatars/a.webp", Status: model.FileStatusReady, RefCount: 1, Variants: model.FileKeys{"64": "avatars/a_64.webp"}}
+	expired := model.File{BaseModel: model.BaseModel{CreatedAt: time.Now().Add(-2 * time.Hour)}, StorageKey: "private/staging/p.jpg", Status: model.FileStatusPending}
+	fresh := model.File{StorageKey: "private/staging/q.jpg", Status: model.FileStatusPending}
+	for _, file := range []*model.File{&ready, &expired, &fresh} {
+		if err := conn.Create(file).Error; err != nil {
+			t.Fatalf("create file: %v", err)
+		}
+	}
+
+	opts := Options{TmpTTL: 24 * time.Hour, PendingUploadTTL: time.Hour}
+	dry, err := Files(ctx, conn, store, q, Options{DryRun: true, TmpTTL: opts.TmpTTL, PendingUploadTTL: opts.PendingUploadTTL})
+	if err != nil {
+		t.Fatalf("Files dry run: %v", err)
+	}
+	if len(dry.ExpiredUploads) != 1 || len(dry.Orphans) != 1 {
+		t.Fatalf("dry run report = %+v", dry)
+	}
+	if _, err := store.Stat(ctx, "avatars/old.webp"); err != nil {
+		t.Fatalf("dry run deleted orphan: %v", err)
+	}
+
+	report, err := Files(ctx, conn, store, q, opts)
+	if err != nil {
+		t.Fatalf("Files: %v", err)
+	}
+	if len(report.Errors) > 0 {
+		t.Fatalf("Files errors: %v", report.Errors)
+	}
+	if len(report.ExpiredUploads) != 1 || report.ExpiredUploads[0].Path != "private/staging/p.jpg" {
+		t.Errorf("ExpiredUploads = %+v", report.ExpiredUploads)
+	}
+	if len(report.Orphans) != 1 || report.Orphans[0].Path != "avatars/old.webp" {
+		t.Errorf("Orphans = %+v", report.Orphans)
+	}
+
+	for _, key := range []string{"avatars/old.webp", "private/staging/p.jpg"} {
+		if _, err := store.Stat(ctx, key); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("%s not deleted: %v", key, err)
+		}
+	}
+	// file & varian yang direferensikan, upload yang belum kedaluwarsa dan folder di luar aplikasi tetap ada
+	for _, key := range []string{"avatars/a.webp", "avatars/a_64.webp", "private/staging/q.jpg", "other-app/x.bin"} {
+		if _, err := store.Stat(ctx, key); err != nil {
+			t.Errorf("%s deleted: %v", key, err)
+		}
+	}
+	if err := conn.First(&model.File{}, "id = ?", expired.ID).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("expired upload row still active: %v", err)
+	}
+}
@@ -0,0 +1,4 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS avatar_64,
+    DROP COLUMN IF EXISTS avatar_256,
+    DROP COLUMN IF EXISTS avatar_512;
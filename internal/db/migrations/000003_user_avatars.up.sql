@@ -0,0 +1,4 @@
+ALTER TABLE users
+    ADD COLUMN avatar_64 TEXT DEFAULT NULL,
+    ADD COLUMN avatar_256 TEXT DEFAULT NULL,
+    ADD COLUMN avatar_512 TEXT DEFAULT NULL;
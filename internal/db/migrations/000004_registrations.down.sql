@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_registrations_deleted_at;
+DROP INDEX IF EXISTS idx_registrations_status;
+DROP INDEX IF EXISTS idx_registrations_number;
+DROP TABLE IF EXISTS registrations;
+DROP SEQUENCE IF EXISTS registration_number_seq;
+DROP TYPE IF EXISTS registration_status;
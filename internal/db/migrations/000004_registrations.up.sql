@@ -0,0 +1,29 @@
+CREATE TYPE registration_status AS ENUM ('submitted', 'verified', 'accepted', 'rejected');
+
+CREATE SEQUENCE registration_number_seq;
+
+CREATE TABLE registrations (
+    id VARCHAR(255) PRIMARY KEY,
+    registration_number VARCHAR(30) NOT NULL UNIQUE,
+    full_name VARCHAR(255) NOT NULL,
+    email VARCHAR(100) NOT NULL,
+    phone VARCHAR(30) NOT NULL,
+    gender VARCHAR(10) NOT NULL,
+    birth_place VARCHAR(100) NOT NULL,
+    birth_date DATE NOT NULL,
+    address TEXT NOT NULL,
+    school_origin VARCHAR(255) NOT NULL,
+    parent_name VARCHAR(255) NOT NULL,
+    parent_phone VARCHAR(30) NOT NULL,
+    program VARCHAR(100) NOT NULL,
+    status registration_status NOT NULL DEFAULT 'submitted',
+    status_note TEXT DEFAULT NULL,
+    proof_url TEXT DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_registrations_number ON registrations(registration_number);
+CREATE INDEX idx_registrations_status ON registrations(status);
+CREATE INDEX idx_registrations_deleted_at ON registrations(deleted_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_brochures_deleted_at;
+DROP INDEX IF EXISTS idx_brochures_published_order;
+DROP TABLE IF EXISTS brochures;
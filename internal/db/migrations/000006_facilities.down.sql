@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_facilities_deleted_at;
+DROP INDEX IF EXISTS idx_facilities_published_order;
+DROP TABLE IF EXISTS facilities;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_alumni_deleted_at;
+DROP INDEX IF EXISTS idx_alumni_published_order;
+DROP TABLE IF EXISTS alumni;
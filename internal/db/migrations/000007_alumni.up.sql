@@ -0,0 +1,19 @@
+CREATE TABLE alumni (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    graduation_year INTEGER NOT NULL,
+    occupation VARCHAR(255) DEFAULT NULL,
+    company VARCHAR(255) DEFAULT NULL,
+    company_logo_url TEXT DEFAULT NULL,
+    testimonial TEXT NOT NULL,
+    photo_url TEXT DEFAULT NULL,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    is_published BOOLEAN NOT NULL DEFAULT FALSE,
+    published_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_alumni_published_order ON alumni(is_published, sort_order);
+CREATE INDEX idx_alumni_deleted_at ON alumni(deleted_at);
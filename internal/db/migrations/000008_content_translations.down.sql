@@ -0,0 +1,17 @@
+ALTER TABLE alumni
+    ALTER COLUMN testimonial TYPE TEXT USING COALESCE(testimonial->>'id_ID', testimonial->>'en_US', ''),
+    ALTER COLUMN occupation DROP NOT NULL,
+    ALTER COLUMN occupation DROP DEFAULT,
+    ALTER COLUMN occupation TYPE VARCHAR(255) USING COALESCE(occupation->>'id_ID', occupation->>'en_US');
+
+ALTER TABLE facilities
+    ALTER COLUMN description DROP NOT NULL,
+    ALTER COLUMN description DROP DEFAULT,
+    ALTER COLUMN description TYPE TEXT USING COALESCE(description->>'id_ID', description->>'en_US'),
+    ALTER COLUMN name TYPE VARCHAR(255) USING COALESCE(name->>'id_ID', name->>'en_US', '');
+
+ALTER TABLE brochures
+    ALTER COLUMN description DROP NOT NULL,
+    ALTER COLUMN description DROP DEFAULT,
+    ALTER COLUMN description TYPE TEXT USING COALESCE(description->>'id_ID', description->>'en_US'),
+    ALTER COLUMN title TYPE VARCHAR(255) USING COALESCE(title->>'id_ID', title->>'en_US', '');
@@ -0,0 +1,18 @@
+-- Konten publik disimpan per locale: {"id_ID": "...", "en_US": "..."}
+ALTER TABLE brochures
+    ALTER COLUMN title TYPE JSONB USING jsonb_build_object('id_ID', title),
+    ALTER COLUMN description TYPE JSONB USING CASE WHEN description IS NULL THEN '{}'::jsonb ELSE jsonb_build_object('id_ID', description) END,
+    ALTER COLUMN description SET DEFAULT '{}'::jsonb,
+    ALTER COLUMN description SET NOT NULL;
+
+ALTER TABLE facilities
+    ALTER COLUMN name TYPE JSONB USING jsonb_build_object('id_ID', name),
+    ALTER COLUMN description TYPE JSONB USING CASE WHEN description IS NULL THEN '{}'::jsonb ELSE jsonb_build_object('id_ID', description) END,
+    ALTER COLUMN description SET DEFAULT '{}'::jsonb,
+    ALTER COLUMN description SET NOT NULL;
+
+ALTER TABLE alumni
+    ALTER COLUMN occupation TYPE JSONB USING CASE WHEN occupation IS NULL THEN '{}'::jsonb ELSE jsonb_build_object('id_ID', occupation) END,
+    ALTER COLUMN occupation SET DEFAULT '{}'::jsonb,
+    ALTER COLUMN occupation SET NOT NULL,
+    ALTER COLUMN testimonial TYPE JSONB USING jsonb_build_object('id_ID', testimonial);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_attachments_deleted_at;
+DROP INDEX IF EXISTS idx_attachments_file_id;
+DROP INDEX IF EXISTS idx_attachments_attachable;
+DROP TABLE IF EXISTS attachments;
+
+DROP INDEX IF EXISTS idx_files_deleted_at;
+DROP INDEX IF EXISTS idx_files_status;
+DROP INDEX IF EXISTS idx_files_uploaded_by;
+DROP INDEX IF EXISTS idx_files_storage_key;
+DROP TABLE IF EXISTS files;
@@ -0,0 +1,36 @@
+CREATE TABLE files (
+    id VARCHAR(255) PRIMARY KEY,
+    storage_key TEXT NOT NULL,
+    bucket VARCHAR(255) NOT NULL DEFAULT '',
+    original_name VARCHAR(255) DEFAULT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    mime_type VARCHAR(255) NOT NULL DEFAULT '',
+    checksum VARCHAR(64) DEFAULT NULL,
+    uploaded_by VARCHAR(255) DEFAULT NULL REFERENCES users(id) ON DELETE SET NULL,
+    variants JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error TEXT DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_files_storage_key ON files(storage_key);
+CREATE INDEX idx_files_uploaded_by ON files(uploaded_by);
+CREATE INDEX idx_files_status ON files(status);
+CREATE INDEX idx_files_deleted_at ON files(deleted_at);
+
+CREATE TABLE attachments (
+    id VARCHAR(255) PRIMARY KEY,
+    file_id VARCHAR(255) NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    attachable_type VARCHAR(100) NOT NULL,
+    attachable_id VARCHAR(255) NOT NULL,
+    field VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_attachments_attachable ON attachments(attachable_type, attachable_id, field);
+CREATE INDEX idx_attachments_file_id ON attachments(file_id);
+CREATE INDEX idx_attachments_deleted_at ON attachments(deleted_at);
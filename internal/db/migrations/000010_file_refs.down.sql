@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_files_checksum_storage_key;
+DROP INDEX IF EXISTS idx_files_checksum;
+ALTER TABLE files DROP COLUMN IF EXISTS ref_count;
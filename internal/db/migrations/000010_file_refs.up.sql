@@ -0,0 +1,10 @@
+ALTER TABLE files ADD COLUMN ref_count INTEGER NOT NULL DEFAULT 0;
+
+-- file lama: satu referensi per lampiran aktif, minimal satu (upload langsung tanpa lampiran)
+UPDATE files SET ref_count = GREATEST(1, (
+    SELECT COUNT(*) FROM attachments a WHERE a.file_id = files.id AND a.deleted_at IS NULL
+)) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_files_checksum ON files(checksum);
+CREATE UNIQUE INDEX idx_files_checksum_storage_key ON files(checksum, storage_key)
+    WHERE deleted_at IS NULL AND status IN ('pending', 'ready');
@@ -0,0 +1,5 @@
+ALTER TABLE files ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'public';
+
+UPDATE files SET visibility = 'private' WHERE storage_key LIKE 'private/%';
+
+CREATE INDEX idx_files_visibility ON files(visibility);
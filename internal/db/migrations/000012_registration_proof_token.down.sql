@@ -0,0 +1,12 @@
+UPDATE registrations r
+SET proof_url = '/api/v1/files/' || a.file_id || '/download'
+FROM attachments a
+WHERE r.proof_token IS NOT NULL
+  AND a.attachable_type = 'registrations'
+  AND a.attachable_id = r.id
+  AND a.field = 'proof'
+  AND a.deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_registrations_proof_token;
+
+ALTER TABLE registrations DROP COLUMN IF EXISTS proof_token;
@@ -0,0 +1,13 @@
+-- Token acak untuk unduh bukti pendaftaran, menggantikan otorisasi nomor pendaftaran + email
+ALTER TABLE registrations ADD COLUMN proof_token VARCHAR(64) DEFAULT NULL;
+
+-- Bukti yang sudah jadi file private (proof_url ke endpoint files) dapat token baru
+UPDATE registrations
+SET proof_token = replace(gen_random_uuid()::text || gen_random_uuid()::text, '-', '')
+WHERE proof_url LIKE '/api/v1/files/%';
+
+UPDATE registrations
+SET proof_url = '/api/v1/registrations/proof/' || proof_token
+WHERE proof_token IS NOT NULL;
+
+CREATE UNIQUE INDEX idx_registrations_proof_token ON registrations(proof_token);
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// Alumni represents the alumni table (alumni testimonials) in the database
+type Alumni struct {
+	BaseModel
+	Name           string       `json:"name" gorm:"type:varchar(255);not null"`
+	GraduationYear int          `json:"graduation_year" gorm:"not null"`
+	Occupation     Translations `json:"occupation" gorm:"type:jsonb;not null;default:'{}'"`
+	Company        *string      `json:"company" gorm:"type:varchar(255);default:null"`
+	CompanyLogoURL *string      `json:"company_logo_url" gorm:"type:text;default:null"`
+	Testimonial    Translations `json:"testimonial" gorm:"type:jsonb;not null;default:'{}'"`
+	PhotoURL       *string      `json:"photo_url" gorm:"type:text;default:null"`
+	SortOrder      int          `json:"sort_order" gorm:"not null;default:0"`
+	IsPublished    bool         `json:"is_published" gorm:"not null;default:false"`
+	PublishedAt    *time.Time   `json:"published_at" gorm:"type:timestamptz;default:null"`
+	Attachments    []Attachment `json:"attachments,omitempty" gorm:"polymorphic:Attachable"`
+}
+
+// TableName specifies the table name for Alumni model
+func (Alumni) TableName() string {
+	return "alumni"
+}
@@ -0,0 +1,18 @@
+package model
+
+// Attachment represents the attachments table in the database, relasi polymorphic
+// antara model apa saja (AttachableType = nama tabel) dan File. Field membedakan
+// beberapa lampiran di satu model, contoh "file" dan "thumbnail" di brosur.
+type Attachment struct {
+	BaseModel
+	FileID         string `json:"file_id" gorm:"type:varchar(255);not null;index:idx_attachments_file_id"`
+	File           *File  `json:"file,omitempty" gorm:"foreignKey:FileID"`
+	AttachableType string `json:"attachable_type" gorm:"type:varchar(100);not null;index:idx_attachments_attachable"`
+	AttachableID   string `json:"attachable_id" gorm:"type:varchar(255);not null;index:idx_attachments_attachable"`
+	Field          string `json:"field" gorm:"type:varchar(100);not null;index:idx_attachments_attachable"`
+}
+
+// TableName specifies the table name for Attachment model
+func (Attachment) TableName() string {
+	return "attachments"
+}
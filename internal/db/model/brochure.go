@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// Brochure represents the brochures table in the database
+type Brochure struct {
+	BaseModel
+	Title        Translations `json:"title" gorm:"type:jsonb;not null;default:'{}'"`
+	Description  Translations `json:"description" gorm:"type:jsonb;not null;default:'{}'"`
+	FileURL      string       `json:"file_url" gorm:"type:text;not null"`
+	ThumbnailURL *string      `json:"thumbnail_url" gorm:"type:text;default:null"`
+	SortOrder    int          `json:"sort_order" gorm:"not null;default:0"`
+	IsPublished  bool         `json:"is_published" gorm:"not null;default:false"`
+	PublishedAt  *time.Time   `json:"published_at" gorm:"type:timestamptz;default:null"`
+	Attachments  []Attachment `json:"attachments,omitempty" gorm:"polymorphic:Attachable"`
+}
+
+// TableName specifies the table name for Brochure model
+func (Brochure) TableName() string {
+	return "brochures"
+}
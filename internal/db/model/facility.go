@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// Facility represents the facilities table in the database
+type Facility struct {
+	BaseModel
+	Name        Translations `json:"name" gorm:"type:jsonb;not null;default:'{}'"`
+	Description Translations `json:"description" gorm:"type:jsonb;not null;default:'{}'"`
+	ImageURL    string       `json:"image_url" gorm:"type:text;not null"`
+	SortOrder   int          `json:"sort_order" gorm:"not null;default:0"`
+	IsPublished bool         `json:"is_published" gorm:"not null;default:false"`
+	PublishedAt *time.Time   `json:"published_at" gorm:"type:timestamptz;default:null"`
+	Attachments []Attachment `json:"attachments,omitempty" gorm:"polymorphic:Attachable"`
+}
+
+// TableName specifies the table name for Facility model
+func (Facility) TableName() string {
+	return "facilities"
+}
@@ -0,0 +1,161 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"net/url"
+	"reflect"
+
+	"template-golang/pkg/storage"
+
+	"github.com/goccy/go-json"
+	"gorm.io/gorm"
+)
+
+// FileStatus status file di storage
+type FileStatus string
+
+const (
+	// FileStatusPending file belum selesai diupload / diproses worker
+	FileStatusPending FileStatus = "pending"
+	// FileStatusReady file dan variannya sudah ada di storage
+	FileStatusReady FileStatus = "ready"
+	// FileStatusFailed upload gagal, alasannya di Error
+	FileStatusFailed FileStatus = "failed"
+	// FileStatusRejected file ditolak validasi atau terinfeksi malware
+	FileStatusRejected FileStatus = "rejected"
+)
+
+// FileVisibility siapa yang boleh mengunduh file
+type FileVisibility string
+
+const (
+	// FileVisibilityPublic file bisa diakses siapa saja lewat URL publik storage
+	FileVisibilityPublic FileVisibility = "public"
+	// FileVisibilityPrivate file hanya bisa diunduh pengupload dan admin lewat
+	// GET /files/:id/download atau URL bertanda tangan, key-nya diawali storage.PrivatePrefix
+	FileVisibilityPrivate FileVisibility = "private"
+)
+
+// File represents the files table in the database, satu baris per object di storage.
+// Isi yang sama (Checksum) di folder & ekstensi yang sama dipakai bersama lewat RefCount.
+type File struct {
+	BaseModel
+	StorageKey   string         `json:"storage_key" gorm:"type:text;not null;index:idx_files_storage_key"`
+	Bucket       string         `json:"bucket" gorm:"type:varchar(255);not null;default:''"`
+	OriginalName *string        `json:"original_name" gorm:"type:varchar(255);default:null"`
+	Size         int64          `json:"size" gorm:"not null;default:0"`
+	MimeType     string         `json:"mime_type" gorm:"type:varchar(255);not null;default:''"`
+	Checksum     *string        `json:"checksum" gorm:"type:varchar(64);default:null;index:idx_files_checksum"`
+	UploadedBy   *string        `json:"uploaded_by" gorm:"type:varchar(255);default:null;index:idx_files_uploaded_by"`
+	Variants     FileKeys       `json:"-" gorm:"type:jsonb;not null;default:'{}'"`
+	Status       FileStatus     `json:"status" gorm:"type:varchar(20);not null;default:'pending';index:idx_files_status"`
+	Error        *string        `json:"error" gorm:"type:text;default:null"`
+	Visibility   FileVisibility `json:"visibility" gorm:"type:varchar(20);not null;default:'public';index:idx_files_visibility"`
+	// RefCount jumlah pemakai file (lampiran / upload langsung), object dihapus saat 0
+	RefCount int `json:"ref_count" gorm:"not null;default:0"`
+
+	// URL URL publik file, untuk file private path endpoint download. Diisi setelah query.
+	URL string `json:"url" gorm:"-"`
+	// VariantURLs nama varian -> URL publik
+	VariantURLs map[string]string `json:"variants,omitempty" gorm:"-"`
+}
+
+// TableName specifies the table name for File model
+func (File) TableName() string {
+	return "files"
+}
+
+// RegisterFileURLs isi URL File (termasuk hasil Preload) dari store setelah setiap query di conn
+func RegisterFileURLs(conn *gorm.DB, store storage.Storage) error {
+	return conn.Callback().Query().After("gorm:after_query").Register("files:fill_urls", func(tx *gorm.DB) {
+		if tx.Error != nil || tx.Statement.Schema == nil || tx.Statement.Schema.Table != (File{}).TableName() {
+			return
+		}
+		fill := func(v reflect.Value) {
+			if f, ok := reflect.Indirect(v).Addr().Interface().(*File); ok {
+				f.FillURLs(store)
+			}
+		}
+		switch rv := tx.Statement.ReflectValue; rv.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < rv.Len(); i++ {
+				fill(rv.Index(i))
+			}
+		case reflect.Struct:
+			fill(rv)
+		}
+	})
+}
+
+// FillURLs isi URL dan VariantURLs dari StorageKey dan Variants
+func (f *File) FillURLs(store storage.Storage) {
+	private := f.Visibility == FileVisibilityPrivate
+	if private {
+		f.URL = FileDownloadURL(f.ID, "")
+	} else {
+		f.URL = store.URL(f.StorageKey)
+	}
+	f.VariantURLs = nil
+	if len(f.Variants) > 0 {
+		f.VariantURLs = make(map[string]string, len(f.Variants))
+		for name, key := range f.Variants {
+			if private {
+				f.VariantURLs[name] = FileDownloadURL(f.ID, name)
+			} else {
+				f.VariantURLs[name] = store.URL(key)
+			}
+		}
+	}
+}
+
+// FileDownloadURL path endpoint download file (proxy API), variant kosong = file utama
+func FileDownloadURL(id, variant string) string {
+	u := "/api/v1/files/" + id + "/download"
+	if variant != "" {
+		u += "?variant=" + url.QueryEscape(variant)
+	}
+	return u
+}
+
+// FileKeys nama varian -> storage key, disimpan sebagai JSONB
+type FileKeys map[string]string
+
+// Value implements driver.Valuer
+func (k FileKeys) Value() (driver.Value, error) {
+	if k == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(k)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner
+func (k *FileKeys) Scan(value any) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*k = FileKeys{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return errors.New("file keys: unsupported scan type")
+	}
+	result := map[string]string{}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return err
+	}
+	*k = result
+	return nil
+}
+
+// GormDataType tipe kolom untuk gorm
+func (FileKeys) GormDataType() string {
+	return "jsonb"
+}
@@ -0,0 +1,56 @@
+package model
+
+import "time"
+
+// RegistrationStatus represents the registration_status enum type
+type RegistrationStatus string
+
+const (
+	RegistrationSubmitted RegistrationStatus = "submitted"
+	RegistrationVerified  RegistrationStatus = "verified"
+	RegistrationAccepted  RegistrationStatus = "accepted"
+	RegistrationRejected  RegistrationStatus = "rejected"
+)
+
+// registrationTransitions daftar status tujuan yang diizinkan dari tiap status
+var registrationTransitions = map[RegistrationStatus][]RegistrationStatus{
+	RegistrationSubmitted: {RegistrationVerified, RegistrationRejected},
+	RegistrationVerified:  {RegistrationAccepted, RegistrationRejected},
+}
+
+// CanTransitionTo cek apakah status boleh dipindah ke next
+func (s RegistrationStatus) CanTransitionTo(next RegistrationStatus) bool {
+	for _, allowed := range registrationTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Registration represents the registrations table in the database
+type Registration struct {
+	BaseModel
+	RegistrationNumber string             `json:"registration_number" gorm:"type:varchar(30);not null;uniqueIndex:idx_registrations_number"`
+	FullName           string             `json:"full_name" gorm:"type:varchar(255);not null"`
+	Email              string             `json:"email" gorm:"type:varchar(100);not null"`
+	Phone              string             `json:"phone" gorm:"type:varchar(30);not null"`
+	Gender             string             `json:"gender" gorm:"type:varchar(10);not null"`
+	BirthPlace         string             `json:"birth_place" gorm:"type:varchar(100);not null"`
+	BirthDate          time.Time          `json:"birth_date" gorm:"type:date;not null"`
+	Address            string             `json:"address" gorm:"type:text;not null"`
+	SchoolOrigin       string             `json:"school_origin" gorm:"type:varchar(255);not null"`
+	ParentName         string             `json:"parent_name" gorm:"type:varchar(255);not null"`
+	ParentPhone        string             `json:"parent_phone" gorm:"type:varchar(30);not null"`
+	Program            string             `json:"program" gorm:"type:varchar(100);not null"`
+	Status             RegistrationStatus `json:"status" gorm:"type:registration_status;not null;default:'submitted'"`
+	StatusNote         *string            `json:"status_note" gorm:"type:text;default:null"`
+	ProofURL           *string            `json:"proof_url" gorm:"type:text;default:null"`
+	ProofToken         *string            `json:"-" gorm:"type:varchar(64);default:null;uniqueIndex:idx_registrations_proof_token"`
+	Attachments        []Attachment       `json:"attachments,omitempty" gorm:"polymorphic:Attachable"`
+}
+
+// TableName specifies the table name for Registration model
+func (Registration) TableName() string {
+	return "registrations"
+}
@@ -0,0 +1,155 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+
+	"template-golang/pkg/locale"
+
+	"github.com/goccy/go-json"
+)
+
+// Translations teks per locale yang disimpan sebagai JSONB,
+// contoh: {"id_ID": "Perpustakaan", "en_US": "Library"}
+type Translations map[string]string
+
+// NewTranslations buat Translations dengan teks untuk locale default
+func NewTranslations(text string) Translations {
+	return Translations{locale.Default: text}
+}
+
+// Get ambil teks untuk locale, fallback ke locale default lalu locale lain yang terisi
+func (t Translations) Get(l string) string {
+	v, _ := t.Resolve(l)
+	return v
+}
+
+// Resolve sama seperti Get, ditambah locale teks yang benar-benar dipakai
+// (bisa locale fallback). Locale kosong kalau tidak ada teks sama sekali.
+func (t Translations) Resolve(l string) (string, string) {
+	if v := t[l]; v != "" {
+		return v, l
+	}
+	if v := t[locale.Default]; v != "" {
+		return v, locale.Default
+	}
+	keys := make([]string, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if t[k] != "" {
+			return t[k], k
+		}
+	}
+	return "", ""
+}
+
+// GetPtr sama seperti Get, tapi nil kalau tidak ada teks sama sekali
+func (t Translations) GetPtr(l string) *string {
+	v := t.Get(l)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+// Merge timpa locale yang ada di other, string kosong menghapus locale tersebut
+func (t Translations) Merge(other Translations) Translations {
+	merged := Translations{}
+	for k, v := range t {
+		merged[k] = v
+	}
+	for k, v := range other {
+		if v == "" {
+			delete(merged, k)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// HasDefault cek apakah teks untuk locale default terisi
+func (t Translations) HasDefault() bool {
+	return strings.TrimSpace(t[locale.Default]) != ""
+}
+
+// Value implements driver.Valuer
+func (t Translations) Value() (driver.Value, error) {
+	if t == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner
+func (t *Translations) Scan(value any) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*t = Translations{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return errors.New("translations: unsupported scan type")
+	}
+	result := map[string]string{}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return err
+	}
+	*t = result
+	return nil
+}
+
+// UnmarshalJSON terima objek per locale atau string biasa (locale default)
+func (t *Translations) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*t = NewTranslations(s)
+		return nil
+	}
+	result := map[string]string{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	*t = result
+	return nil
+}
+
+// UnmarshalText dipakai form multipart: terima objek JSON per locale,
+// atau teks biasa yang dianggap sebagai locale default
+func (t *Translations) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if strings.HasPrefix(s, "{") {
+		return t.UnmarshalJSON([]byte(s))
+	}
+	*t = NewTranslations(s)
+	return nil
+}
+
+// TranslationsConverter converter form decoder Fiber (fiber.ParserType),
+// form decoder tidak mendukung field bertipe map tanpa converter
+func TranslationsConverter(value string) reflect.Value {
+	var t Translations
+	if err := t.UnmarshalText([]byte(value)); err != nil {
+		return reflect.Value{}
+	}
+	return reflect.ValueOf(t)
+}
+
+// GormDataType tipe kolom untuk gorm
+func (Translations) GormDataType() string {
+	return "jsonb"
+}
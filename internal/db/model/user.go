@@ -11,15 +11,18 @@ const (
 // User represents the users table in the database
 type User struct {
 	BaseModel
-	LearningPointID *string       `json:"learning_point_id" gorm:"type:varchar(25);default:null"`
-	Name           string         `json:"name" gorm:"type:varchar(255);not null"`
-	Email          string         `json:"email" gorm:"type:varchar(100);not null;uniqueIndex:idx_users_email"`
-	Password       string         `json:"password" gorm:"type:varchar(255);not null"`
-	Role           UserRole       `json:"role" gorm:"type:user_role;not null;default:'admin'"`
-
+	LearningPointID *string      `json:"learning_point_id" gorm:"type:varchar(25);default:null"`
+	Name            string       `json:"name" gorm:"type:varchar(255);not null"`
+	Email           string       `json:"email" gorm:"type:varchar(100);not null;uniqueIndex:idx_users_email"`
+	Password        string       `json:"password" gorm:"type:varchar(255);not null"`
+	Role            UserRole     `json:"role" gorm:"type:user_role;not null;default:'admin'"`
+	Avatar64        *string      `json:"avatar_64" gorm:"type:text;default:null"`
+	Avatar256       *string      `json:"avatar_256" gorm:"type:text;default:null"`
+	Avatar512       *string      `json:"avatar_512" gorm:"type:text;default:null"`
+	Attachments     []Attachment `json:"attachments,omitempty" gorm:"polymorphic:Attachable"`
 }
 
 // TableName specifies the table name for User model
 func (User) TableName() string {
 	return "users"
-}
\ No newline at end of file
+}
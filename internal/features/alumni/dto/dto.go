@@ -0,0 +1,135 @@
+package dto
+
+import (
+	"mime/multipart"
+	"time"
+
+	"template-golang/internal/db/model"
+)
+
+// CreateAlumniRequest represents the create alumni testimonial request (multipart form)
+// @Description Create alumni testimonial request payload
+type CreateAlumniRequest struct {
+	// @Description Alumni name
+	// @Example Rina Wijaya
+	Name string `form:"name" json:"name" validate:"required,max=255"`
+	// @Description Graduation year
+	// @Example 2018
+	GraduationYear int `form:"graduation_year" json:"graduation_year" validate:"required,gte=1900,lte=2100"`
+	// @Description Current occupation per locale
+	// @Example {"id_ID":"Insinyur Perangkat Lunak","en_US":"Software Engineer"}
+	Occupation model.Translations `form:"occupation" json:"occupation" validate:"omitempty,locales" swaggertype:"object,string"`
+	// @Description Current company
+	// @Example PT Teknologi Nusantara
+	Company *string `form:"company" json:"company" validate:"omitempty,max=255"`
+	// @Description Testimonial text per locale, id_ID is required
+	// @Example {"id_ID":"Sekolah ini membentuk karakter saya","en_US":"This school shaped my character"}
+	Testimonial model.Translations `form:"testimonial" json:"testimonial" validate:"default_locale,locales" swaggertype:"object,string"`
+	// @Description Display order, ascending
+	// @Example 1
+	SortOrder int `form:"sort_order" json:"sort_order"`
+
+	Photo           *multipart.FileHeader `form:"-" json:"-" swaggerignore:"true"`
+	PhotoName       string                `form:"-" json:"photo" validate:"omitempty,image"`
+	PhotoSize       int64                 `form:"-" json:"photo_size" validate:"size=2"`
+	CompanyLogo     *multipart.FileHeader `form:"-" json:"-" swaggerignore:"true"`
+	CompanyLogoName string                `form:"-" json:"company_logo" validate:"omitempty,image"`
+	CompanyLogoSize int64                 `form:"-" json:"company_logo_size" validate:"size=2"`
+}
+
+// UpdateAlumniRequest represents the update alumni testimonial request (multipart form)
+// @Description Update alumni testimonial request payload
+type UpdateAlumniRequest struct {
+	// @Description Alumni name
+	// @Example Rina Wijaya
+	Name *string `form:"name" json:"name,omitempty" validate:"omitempty,max=255"`
+	// @Description Graduation year
+	// @Example 2018
+	GraduationYear *int `form:"graduation_year" json:"graduation_year,omitempty" validate:"omitempty,gte=1900,lte=2100"`
+	// @Description Current occupation per locale, an empty string removes the locale
+	// @Example {"en_US":"Software Engineer"}
+	Occupation model.Translations `form:"occupation" json:"occupation,omitempty" validate:"omitempty,locales" swaggertype:"object,string"`
+	// @Description Current company
+	// @Example PT Teknologi Nusantara
+	Company *string `form:"company" json:"company,omitempty" validate:"omitempty,max=255"`
+	// @Description Testimonial text per locale, only the given locales are changed
+	// @Example {"en_US":"This school shaped my character"}
+	Testimonial model.Translations `form:"testimonial" json:"testimonial,omitempty" validate:"omitempty,locales" swaggertype:"object,string"`
+	// @Description Display order, ascending
+	// @Example 1
+	SortOrder *int `form:"sort_order" json:"sort_order,omitempty"`
+
+	Photo           *multipart.FileHeader `form:"-" json:"-" swaggerignore:"true"`
+	PhotoName       string                `form:"-" json:"photo" validate:"omitempty,image"`
+	PhotoSize       int64                 `form:"-" json:"photo_size" validate:"size=2"`
+	CompanyLogo     *multipart.FileHeader `form:"-" json:"-" swaggerignore:"true"`
+	CompanyLogoName string                `form:"-" json:"company_logo" validate:"omitempty,image"`
+	CompanyLogoSize int64                 `form:"-" json:"company_logo_size" validate:"size=2"`
+}
+
+// ReorderRequest represents the reorder request data structure
+// @Description Reorder alumni testimonials, sort_order follows the position in the list
+type ReorderRequest struct {
+	// @Description Alumni IDs in the desired order
+	// @Example ["id1","id2"]
+	IDs []string `json:"ids" validate:"required,min=1"`
+}
+
+// AlumniResponse represents an alumni testimonial localized to the requested language
+// @Description Localized alumni testimonial response payload
+type AlumniResponse struct {
+	// @Description Alumni ID
+	// @Example clx1abc2d0000qwerty
+	ID string `json:"id"`
+	// @Description Language of the testimonial actually returned, the fallback (id_ID) when the requested language has no translation
+	// @Example id_ID
+	Lang string `json:"lang"`
+	// @Description Alumni name
+	// @Example Rina Wijaya
+	Name string `json:"name"`
+	// @Description Graduation year
+	// @Example 2018
+	GraduationYear int `json:"graduation_year"`
+	// @Description Current occupation
+	// @Example Software Engineer
+	Occupation *string `json:"occupation"`
+	// @Description Current company
+	// @Example PT Teknologi Nusantara
+	Company *string `json:"company"`
+	// @Description Company logo URL
+	// @Example https://is3.cloudhost.id/uts/alumni_logos/1758074703488556600.webp
+	CompanyLogoURL *string `json:"company_logo_url"`
+	// @Description Testimonial text
+	// @Example Sekolah ini membentuk karakter saya
+	Testimonial string `json:"testimonial"`
+	// @Description Alumni photo URL
+	// @Example https://is3.cloudhost.id/uts/alumni/1758074703488556600.webp
+	PhotoURL *string `json:"photo_url"`
+	// @Description Display order
+	// @Example 1
+	SortOrder int `json:"sort_order"`
+	// @Description Publication timestamp
+	// @Example 2024-03-15T10:00:00Z
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// NewAlumniResponse builds an alumni testimonial response in the given locale
+func NewAlumniResponse(alumni model.Alumni, lang string) AlumniResponse {
+	testimonial, used := alumni.Testimonial.Resolve(lang)
+	if used == "" {
+		used = lang
+	}
+	return AlumniResponse{
+		ID:             alumni.ID,
+		Lang:           used,
+		Name:           alumni.Name,
+		GraduationYear: alumni.GraduationYear,
+		Occupation:     alumni.Occupation.GetPtr(lang),
+		Company:        alumni.Company,
+		CompanyLogoURL: alumni.CompanyLogoURL,
+		Testimonial:    testimonial,
+		PhotoURL:       alumni.PhotoURL,
+		SortOrder:      alumni.SortOrder,
+		PublishedAt:    alumni.PublishedAt,
+	}
+}
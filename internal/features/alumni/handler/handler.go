@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"template-golang/internal/features/alumni/dto"
+	"template-golang/internal/features/alumni/service"
+	"template-golang/pkg/middleware"
+	"template-golang/pkg/response"
+	"template-golang/pkg/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	svc *service.Service
+}
+
+func NewHandler(svc *service.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) RegisterRoutes(r fiber.Router) {
+	admin := middleware.AuthMiddleware(&[]string{"admin", "superadmin"})
+
+	router := r.Group("/alumni")
+	router.Get("/", h.PublicList)
+	router.Get("/all", admin, h.ListAlumni)
+	router.Put("/order", admin, h.Reorder)
+	router.Get("/:id", h.PublicShow)
+	router.Post("/", admin, h.Store)
+	router.Put("/:id", admin, h.UpdateAlumni)
+	router.Patch("/:id/publish", admin, h.Publish)
+	router.Patch("/:id/unpublish", admin, h.Unpublish)
+	router.Delete("/:id", admin, h.DeleteAlumni)
+}
+
+// @Summary List published alumni
+// @Description Public list of published alumni, cached
+// @Tags Alumni
+// @Accept json
+// @Produce json
+// @Param lang query string false "Language (id_ID, en_US), overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred language"
+// @Success 200 {array} dto.AlumniResponse
+// @Router /api/v1/alumni [get]
+func (h *Handler) PublicList(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandlePublicList(ctx.Context())
+	if err != nil {
+		return response.Error(ctx, "FETCH_ALUMNI_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Get published alumni
+// @Description Public details of a published alumni testimonial
+// @Tags Alumni
+// @Accept json
+// @Produce json
+// @Param id path string true "Alumni ID"
+// @Param lang query string false "Language (id_ID, en_US), overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred language"
+// @Success 200 {object} dto.AlumniResponse
+// @Router /api/v1/alumni/{id} [get]
+func (h *Handler) PublicShow(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	data, err := h.svc.HandlePublicShow(ctx.Context(), id)
+	if err != nil {
+		return response.Error(ctx, "FETCH_ALUMNI_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary List all alumni
+// @Description List all alumni including unpublished ones
+// @Tags Alumni
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.Alumni
+// @Router /api/v1/alumni/all [get]
+func (h *Handler) ListAlumni(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleList(ctx.Context())
+	if err != nil {
+		return response.Error(ctx, "FETCH_ALUMNI_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Store new alumni
+// @Description Store a new alumni testimonial with optional photo and company logo
+// @Tags Alumni
+// @Accept mpfd
+// @Produce json
+// @Param name formData string true "Alumni name"
+// @Param graduation_year formData int true "Graduation year"
+// @Param occupation formData string false "Current occupation, JSON object per locale or plain id_ID text"
+// @Param company formData string false "Current company"
+// @Param testimonial formData string true "Testimonial text, JSON object per locale (id_ID required, en_US optional) or plain id_ID text"
+// @Param sort_order formData int false "Display order"
+// @Param photo formData file false "Alumni photo (jpg, jpeg, png, max 2 MB)"
+// @Param company_logo formData file false "Company logo (jpg, jpeg, png, max 2 MB)"
+// @Security BearerAuth
+// @Success 200 {object} model.Alumni
+// @Router /api/v1/alumni/ [post]
+func (h *Handler) Store(ctx *fiber.Ctx) error {
+	var req dto.CreateAlumniRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
+	}
+	if photo, err := ctx.FormFile("photo"); err == nil {
+		req.Photo, req.PhotoName, req.PhotoSize = photo, photo.Filename, photo.Size
+	}
+	if logo, err := ctx.FormFile("company_logo"); err == nil {
+		req.CompanyLogo, req.CompanyLogoName, req.CompanyLogoSize = logo, logo.Filename, logo.Size
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	data, err := h.svc.HandleCreate(ctx.Context(), req)
+	if err != nil {
+		return response.Error(ctx, "CREATE_ALUMNI_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Update alumni
+// @Description Update an alumni testimonial, uploaded files replace the previous ones
+// @Tags Alumni
+// @Accept mpfd
+// @Produce json
+// @Param id path string true "Alumni ID"
+// @Param name formData string false "Alumni name"
+// @Param graduation_year formData int false "Graduation year"
+// @Param occupation formData string false "Current occupation, JSON object with the locales to change (empty string removes a locale)"
+// @Param company formData string false "Current company"
+// @Param testimonial formData string false "Testimonial text, JSON object with the locales to change"
+// @Param sort_order formData int false "Display order"
+// @Param photo formData file false "Alumni photo (jpg, jpeg, png, max 2 MB)"
+// @Param company_logo formData file false "Company logo (jpg, jpeg, png, max 2 MB)"
+// @Security BearerAuth
+// @Success 200 {object} model.Alumni
+// @Router /api/v1/alumni/{id} [put]
+func (h *Handler) UpdateAlumni(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	var req dto.UpdateAlumniRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
+	}
+	if photo, err := ctx.FormFile("photo"); err == nil {
+		req.Photo, req.PhotoName, req.PhotoSize = photo, photo.Filename, photo.Size
+	}
+	if logo, err := ctx.FormFile("company_logo"); err == nil {
+		req.CompanyLogo, req.CompanyLogoName, req.CompanyLogoSize = logo, logo.Filename, logo.Size
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	data, err := h.svc.HandleUpdate(ctx.Context(), id, req)
+	if err != nil {
+		return response.Error(ctx, "UPDATE_ALUMNI_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Publish alumni
+// @Description Make an alumni testimonial visible on the public list
+// @Tags Alumni
+// @Accept json
+// @Produce json
+// @Param id path string true "Alumni ID"
+// @Security BearerAuth
+// @Success 200 {object} model.Alumni
+// @Router /api/v1/alumni/{id}/publish [patch]
+func (h *Handler) Publish(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleSetPublished(ctx.Context(), ctx.Params("id"), true)
+	if err != nil {
+		return response.Error(ctx, "PUBLISH_ALUMNI_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Unpublish alumni
+// @Description Hide an alumni testimonial from the public list
+// @Tags Alumni
+// @Accept json
+// @Produce json
+// @Param id path string true "Alumni ID"
+// @Security BearerAuth
+// @Success 200 {object} model.Alumni
+// @Router /api/v1/alumni/{id}/unpublish [patch]
+func (h *Handler) Unpublish(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleSetPublished(ctx.Context(), ctx.Params("id"), false)
+	if err != nil {
+		return response.Error(ctx, "UNPUBLISH_ALUMNI_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Reorder alumni
+// @Description Set the display order of alumni
+// @Tags Alumni
+// @Accept json
+// @Produce json
+// @Param body body dto.ReorderRequest true "Alumni IDs in order"
+// @Security BearerAuth
+// @Success 200 {array} model.Alumni
+// @Router /api/v1/alumni/order [put]
+func (h *Handler) Reorder(ctx *fiber.Ctx) error {
+	var req dto.ReorderRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	data, err := h.svc.HandleReorder(ctx.Context(), req)
+	if err != nil {
+		return response.Error(ctx, "REORDER_ALUMNI_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Delete alumni
+// @Description Delete an existing alumni testimonial
+// @Tags Alumni
+// @Accept json
+// @Produce json
+// @Param id path string true "Alumni ID"
+// @Security BearerAuth
+// @Success 200 {object} model.Alumni
+// @Router /api/v1/alumni/{id} [delete]
+func (h *Handler) DeleteAlumni(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	data, err := h.svc.HandleDelete(ctx.Context(), id)
+	if err != nil {
+		return response.Error(ctx, "DELETE_ALUMNI_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"template-golang/internal/db/model"
+	"template-golang/internal/features/alumni/dto"
+	"template-golang/internal/features/base"
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/locale"
+	"template-golang/pkg/logger"
+
+	"github.com/goccy/go-json"
+	"gorm.io/gorm"
+)
+
+const (
+	photoFolder   = "alumni"
+	logoFolder    = "alumni_logos"
+	cacheKey      = "alumni:public"
+	cachePattern  = "alumni:*"
+	cacheDuration = 10 * time.Minute
+)
+
+type Service struct {
+	*base.BaseService
+}
+
+func NewService(baseService *base.BaseService) *Service {
+	return &Service{
+		BaseService: baseService,
+	}
+}
+
+// forgetCache hapus cache list publik setelah data berubah
+func (s *Service) forgetCache(ctx context.Context) {
+	if err := s.Redis.DelByPattern(ctx, cachePattern); err != nil {
+		logger.L().Warnf("alumni: failed to clear cache: %v", err)
+	}
+}
+
+// publishedAlumni list testimoni terbit, cache menyimpan semua locale
+func (s *Service) publishedAlumni(ctx context.Context) ([]model.Alumni, error) {
+	if cached, err := s.Redis.Get(ctx, cacheKey); err == nil {
+		var alumni []model.Alumni
+		if err := json.Unmarshal([]byte(cached), &alumni); err == nil {
+			return alumni, nil
+		}
+	}
+
+	var alumni []model.Alumni
+	err := s.DB().Where("is_published = ?", true).Order("sort_order ASC, created_at DESC").Find(&alumni).Error
+	if err != nil {
+		return []model.Alumni{}, err
+	}
+
+	if err := s.Redis.Set(ctx, cacheKey, alumni, cacheDuration); err != nil {
+		logger.L().Warnf("alumni: failed to cache list: %v", err)
+	}
+	return alumni, nil
+}
+
+func (s *Service) HandlePublicList(ctx context.Context) ([]dto.AlumniResponse, error) {
+	alumni, err := s.publishedAlumni(ctx)
+	if err != nil {
+		return []dto.AlumniResponse{}, err
+	}
+
+	lang := locale.FromContext(ctx)
+	response := make([]dto.AlumniResponse, 0, len(alumni))
+	for _, a := range alumni {
+		response = append(response, dto.NewAlumniResponse(a, lang))
+	}
+	return response, nil
+}
+
+func (s *Service) HandlePublicShow(ctx context.Context, id string) (dto.AlumniResponse, error) {
+	var alumni model.Alumni
+	err := s.DB().First(&alumni, "id = ? AND is_published = ?", id, true).Error
+	if err != nil {
+		return dto.AlumniResponse{}, err
+	}
+	return dto.NewAlumniResponse(alumni, locale.FromContext(ctx)), nil
+}
+
+func (s *Service) HandleList(ctx context.Context) ([]model.Alumni, error) {
+	var alumni []model.Alumni
+	err := s.DB().Preload("Attachments.File").Order("sort_order ASC, created_at DESC").Find(&alumni).Error
+	if err != nil {
+		return []model.Alumni{}, err
+	}
+	return alumni, nil
+}
+
+func (s *Service) HandleCreate(ctx context.Context, req dto.CreateAlumniRequest) (model.Alumni, error) {
+	alumniAny, err := s.InTx(ctx, func(tx *gorm.DB) (any, error) {
+		alumni := model.Alumni{
+			Name:           req.Name,
+			GraduationYear: req.GraduationYear,
+			Occupation:     model.Translations{}.Merge(req.Occupation),
+			Company:        req.Company,
+			Testimonial:    req.Testimonial,
+			SortOrder:      req.SortOrder,
+		}
+		attachments := map[string]model.File{}
+
+		if req.Photo != nil {
+			photo, err := s.EnqueueUpload(ctx, tx, photoFolder, req.Photo, true, nil)
+			if err != nil {
+				return model.Alumni{}, err
+			}
+			alumni.PhotoURL = &photo.URL
+			attachments["photo"] = photo
+		}
+		if req.CompanyLogo != nil {
+			logo, err := s.EnqueueUpload(ctx, tx, logoFolder, req.CompanyLogo, true, nil)
+			if err != nil {
+				return model.Alumni{}, err
+			}
+			alumni.CompanyLogoURL = &logo.URL
+			attachments["company_logo"] = logo
+		}
+
+		if err := tx.Create(&alumni).Error; err != nil {
+			return model.Alumni{}, err
+		}
+		attached, err := s.AttachFiles(ctx, tx, alumni.TableName(), alumni.ID, attachments)
+		if err != nil {
+			return model.Alumni{}, err
+		}
+		alumni.Attachments = attached
+		return alumni, nil
+	})
+	if err != nil {
+		return model.Alumni{}, apperror.NewT("CREATE_ALUMNI_FAILED", 400, err, nil).WithStack(req.Name)
+	}
+	s.forgetCache(ctx)
+	return alumniAny.(model.Alumni), nil
+}
+
+func (s *Service) HandleUpdate(ctx context.Context, id string, req dto.UpdateAlumniRequest) (model.Alumni, error) {
+	alumniAny, err := s.InTx(ctx, func(tx *gorm.DB) (any, error) {
+		var alumni model.Alumni
+		if err := tx.First(&alumni, "id = ?", id).Error; err != nil {
+			return model.Alumni{}, err
+		}
+		if req.Name != nil {
+			alumni.Name = *req.Name
+		}
+		if req.GraduationYear != nil {
+			alumni.GraduationYear = *req.GraduationYear
+		}
+		if req.Occupation != nil {
+			alumni.Occupation = alumni.Occupation.Merge(req.Occupation)
+		}
+		if req.Company != nil {
+			alumni.Company = req.Company
+		}
+		if req.Testimonial != nil {
+			alumni.Testimonial = alumni.Testimonial.Merge(req.Testimonial)
+			if !alumni.Testimonial.HasDefault() {
+				return model.Alumni{}, errors.New("testimonial must keep a " + locale.Default + " translation")
+			}
+		}
+		if req.SortOrder != nil {
+			alumni.SortOrder = *req.SortOrder
+		}
+		attachments := map[string]model.File{}
+		if req.Photo != nil {
+			photo, err := s.EnqueueUpload(ctx, tx, photoFolder, req.Photo, true, alumni.PhotoURL)
+			if err != nil {
+				return model.Alumni{}, err
+			}
+			alumni.PhotoURL = &photo.URL
+			attachments["photo"] = photo
+		}
+		if req.CompanyLogo != nil {
+			logo, err := s.EnqueueUpload(ctx, tx, logoFolder, req.CompanyLogo, true, alumni.CompanyLogoURL)
+			if err != nil {
+				return model.Alumni{}, err
+			}
+			alumni.CompanyLogoURL = &logo.URL
+			attachments["company_logo"] = logo
+		}
+		if err := tx.Save(&alumni).Error; err != nil {
+			return model.Alumni{}, err
+		}
+		attached, err := s.AttachFiles(ctx, tx, alumni.TableName(), alumni.ID, attachments)
+		if err != nil {
+			return model.Alumni{}, err
+		}
+		alumni.Attachments = attached
+		return alumni, nil
+	})
+	if err != nil {
+		return model.Alumni{}, apperror.NewT("UPDATE_ALUMNI_FAILED", 400, err, nil).WithStack(id)
+	}
+	s.forgetCache(ctx)
+	return alumniAny.(model.Alumni), nil
+}
+
+func (s *Service) HandleSetPublished(ctx context.Context, id string, published bool) (model.Alumni, error) {
+	alumniAny, err := s.InTx(ctx, func(tx *gorm.DB) (any, error) {
+		var alumni model.Alumni
+		if err := tx.First(&alumni, "id = ?", id).Error; err != nil {
+			return model.Alumni{}, err
+		}
+		alumni.IsPublished = published
+		alumni.PublishedAt = nil
+		if published {
+			now := time.Now()
+			alumni.PublishedAt = &now
+		}
+		if err := tx.Save(&alumni).Error; err != nil {
+			return model.Alumni{}, err
+		}
+		return alumni, nil
+	})
+	if err != nil {
+		return model.Alumni{}, apperror.NewT("CHANGE_ALUMNI_PUBLICATION_FAILED", 400, err, nil).WithStack(id)
+	}
+	s.forgetCache(ctx)
+	return alumniAny.(model.Alumni), nil
+}
+
+func (s *Service) HandleReorder(ctx context.Context, req dto.ReorderRequest) ([]model.Alumni, error) {
+	err := s.InTxVoid(ctx, func(tx *gorm.DB) error {
+		for i, id := range req.IDs {
+			res := tx.Model(&model.Alumni{}).Where("id = ?", id).Update("sort_order", i+1)
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return []model.Alumni{}, apperror.NewT("REORDER_ALUMNI_FAILED", 400, err, nil).WithStack(strings.Join(req.IDs, ","))
+	}
+	s.forgetCache(ctx)
+	return s.HandleList(ctx)
+}
+
+func (s *Service) HandleDelete(ctx context.Context, id string) (model.Alumni, error) {
+	var alumni model.Alumni
+	err := s.DB().First(&alumni, "id = ?", id).Error
+	if err != nil {
+		return model.Alumni{}, err
+	}
+	// file yang tidak dipakai model lain ikut dihapus dari storage
+	err = s.InTxVoid(ctx, func(tx *gorm.DB) error {
+		if err := tx.Delete(&alumni).Error; err != nil {
+			return err
+		}
+		return s.DetachAll(ctx, tx, alumni.TableName(), alumni.ID)
+	})
+	if err != nil {
+		return model.Alumni{}, err
+	}
+	s.forgetCache(ctx)
+	return alumni, nil
+}
@@ -0,0 +1,13 @@
+package alumni
+
+import (
+	"template-golang/internal/features/alumni/handler"
+	"template-golang/internal/features/alumni/service"
+
+	"github.com/google/wire"
+)
+
+var Set = wire.NewSet(
+	service.NewService,
+	handler.NewHandler,
+)
@@ -0,0 +1,276 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"template-golang/internal/db/model"
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/fileUploader"
+	"template-golang/pkg/helper"
+	"template-golang/pkg/storage"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Upload hasil EnqueueUploadFile
+type Upload struct {
+	File model.File
+	// JobID kosong kalau isi file sudah pernah diupload (file lama dipakai ulang)
+	JobID string
+}
+
+// acquireFile catat file yang akan diupload worker di tabel files (status pending, ref_count 1).
+// Kalau isi yang sama sudah ada di folder & ekstensi yang sama, file itu yang dipakai
+// (ref_count + 1) dan created false.
+func (b *BaseService) acquireFile(ctx context.Context, tx *gorm.DB, payload fileUploader.QueueUploadFile) (model.File, bool, error) {
+	key, ok := fileUploader.FileKey(b.Storage, payload.FilePath)
+	if !ok {
+		return model.File{}, false, apperror.NewT("FILE_ACQUIRE_FAILED", 500, nil, nil).WithStack("file url does not belong to storage")
+	}
+
+	if payload.Checksum != "" {
+		if file, found, err := b.AcquireDuplicate(tx, payload.Checksum, key, ""); err != nil || found {
+			return file, false, err
+		}
+	}
+
+	file := model.File{
+		StorageKey: key,
+		Bucket:     storage.Bucket(),
+		Size:       payload.Size,
+		MimeType:   payload.ContentType,
+		Status:     model.FileStatusPending,
+		Visibility: model.FileVisibilityPublic,
+		RefCount:   1,
+	}
+	if storage.IsPrivate(key) {
+		file.Visibility = model.FileVisibilityPrivate
+	}
+	if payload.IsCompressToWebp != nil && *payload.IsCompressToWebp {
+		file.MimeType = "image/webp"
+	}
+	if payload.File != nil {
+		file.OriginalName = helper.StringPtr(filepath.Base(payload.File.Filename))
+	} else if payload.OriginalName != "" {
+		file.OriginalName = helper.StringPtr(payload.OriginalName)
+	}
+	if payload.Checksum != "" {
+		file.Checksum = helper.StringPtr(payload.Checksum)
+	}
+	if userID := ctxUserID(ctx); userID != "" {
+		file.UploadedBy = &userID
+	}
+
+	// upload bersamaan dengan isi yang sama: yang kalah memakai file pemenang
+	res := tx.Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "checksum"}, {Name: "storage_key"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "deleted_at IS NULL AND status IN ('pending', 'ready')"}}},
+		DoNothing:   true,
+	}).Create(&file)
+	if res.Error != nil {
+		return model.File{}, false, apperror.NewT("FILE_ACQUIRE_FAILED", 500, res.Error, nil).WithStack("failed to create file")
+	}
+	if res.RowsAffected == 0 {
+		existing, found, err := b.AcquireDuplicate(tx, payload.Checksum, key, "")
+		if err == nil && !found {
+			err = apperror.NewT("FILE_ACQUIRE_FAILED", 500, nil, nil).WithStack("duplicate file disappeared")
+		}
+		return existing, false, err
+	}
+	file.FillURLs(b.Storage)
+	return file, true, nil
+}
+
+// AcquireDuplicate cari file aktif (pending / ready) dengan checksum yang sama di folder & ekstensi
+// yang sama dengan key (selain file exceptID), lalu tambah ref_count-nya. Folder & ekstensi menentukan
+// cara file diproses (WebP, varian avatar, dll.), jadi isi sama di folder lain tetap disimpan terpisah.
+// File private tidak pernah dipakai bersama supaya isi file user lain tidak bisa ditebak dari checksum.
+func (b *BaseService) AcquireDuplicate(tx *gorm.DB, checksum, key, exceptID string) (model.File, bool, error) {
+	if storage.IsPrivate(key) {
+		return model.File{}, false, nil
+	}
+	var file model.File
+	like, nested := sameKind(key)
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("checksum = ? AND storage_key LIKE ? AND storage_key NOT LIKE ? AND status IN ? AND id <> ?", checksum, like, nested,
+			[]model.FileStatus{model.FileStatusPending, model.FileStatusReady}, exceptID).
+		Order("created_at ASC").
+		First(&file).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.File{}, false, nil
+	}
+	if err != nil {
+		return model.File{}, false, apperror.NewT("FILE_ACQUIRE_FAILED", 500, err, nil).WithStack("failed to find duplicate file")
+	}
+
+	err = tx.Model(&file).UpdateColumn("ref_count", gorm.Expr("ref_count + 1")).Error
+	if err != nil {
+		return model.File{}, false, apperror.NewT("FILE_ACQUIRE_FAILED", 500, err, nil).WithStack("failed to reference file")
+	}
+	file.RefCount++
+	return file, true, nil
+}
+
+// sameKind pola LIKE key di folder yang sama dengan ekstensi yang sama, dan pola NOT LIKE
+// untuk key di subfolder-nya (% di LIKE juga cocok dengan "/")
+func sameKind(key string) (like, nested string) {
+	escape := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	prefix := ""
+	if dir := path.Dir(key); dir != "." {
+		prefix = escape.Replace(dir) + "/"
+	}
+	return prefix + "%" + escape.Replace(path.Ext(key)), prefix + "%/%"
+}
+
+// ReleaseFile kurangi ref_count file. File tanpa referensi di-soft delete dan object
+// beserta variannya dihapus worker (job DeleteFileJob) yang diantrikan setelah tx commit.
+func (b *BaseService) ReleaseFile(ctx context.Context, tx *gorm.DB, fileID string) error {
+	var file model.File
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&file, "id = ?", fileID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return apperror.NewT("FILE_RELEASE_FAILED", 500, err, nil).WithStack("failed to find file")
+	}
+
+	if file.RefCount > 1 {
+		err := tx.Model(&file).UpdateColumn("ref_count", gorm.Expr("ref_count - 1")).Error
+		if err != nil {
+			return apperror.NewT("FILE_RELEASE_FAILED", 500, err, nil).WithStack("failed to release file")
+		}
+		return nil
+	}
+
+	if err := tx.Model(&file).UpdateColumn("ref_count", 0).Error; err != nil {
+		return apperror.NewT("FILE_RELEASE_FAILED", 500, err, nil).WithStack("failed to release file")
+	}
+	if err := tx.Delete(&file).Error; err != nil {
+		return apperror.NewT("FILE_RELEASE_FAILED", 500, err, nil).WithStack("failed to delete file")
+	}
+	// kalau gagal diantrikan, object-nya dihapus GC file (cleanup_files) sebagai object yatim
+	return b.AfterCommit(tx, func(ctx context.Context) error {
+		if _, err := b.Queue.Enqueue(ctx, fileUploader.DeleteFileJob, fileUploader.QueueDeleteFile{FileID: file.ID}); err != nil {
+			return fmt.Errorf("failed to enqueue deletion of file %s: %w", file.ID, err)
+		}
+		return nil
+	})
+}
+
+// Attach pasang file sebagai lampiran field pada model (attachableType = nama tabel),
+// lampiran lama di field yang sama dilepas beserta referensinya ke file
+func (b *BaseService) Attach(ctx context.Context, tx *gorm.DB, attachableType, attachableID, field string, fileID string) error {
+	if err := b.detach(ctx, tx, attachableType, attachableID, field); err != nil {
+		return err
+	}
+
+	attachment := model.Attachment{
+		FileID:         fileID,
+		AttachableType: attachableType,
+		AttachableID:   attachableID,
+		Field:          field,
+	}
+	if err := tx.Create(&attachment).Error; err != nil {
+		return apperror.NewT("ATTACHMENT_FAILED", 500, err, nil).WithStack("failed to attach file")
+	}
+	return nil
+}
+
+// AttachFiles Attach untuk beberapa field sekaligus (field -> file),
+// mengembalikan semua lampiran model beserta file-nya
+func (b *BaseService) AttachFiles(ctx context.Context, tx *gorm.DB, attachableType, attachableID string, files map[string]model.File) ([]model.Attachment, error) {
+	fields := make([]string, 0, len(files))
+	for field := range files {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if err := b.Attach(ctx, tx, attachableType, attachableID, field, files[field].ID); err != nil {
+			return nil, err
+		}
+	}
+
+	var attachments []model.Attachment
+	err := tx.Preload("File").
+		Where("attachable_type = ? AND attachable_id = ?", attachableType, attachableID).
+		Order("field ASC").
+		Find(&attachments).Error
+	if err != nil {
+		return nil, apperror.NewT("ATTACHMENT_FAILED", 500, err, nil).WithStack("failed to load attachments")
+	}
+	return attachments, nil
+}
+
+// DetachAll lepas semua lampiran model, dipanggil saat model dihapus
+func (b *BaseService) DetachAll(ctx context.Context, tx *gorm.DB, attachableType, attachableID string) error {
+	return b.detach(ctx, tx, attachableType, attachableID, "")
+}
+
+// detach hapus lampiran model (field kosong = semua field) lalu ReleaseFile file-nya
+func (b *BaseService) detach(ctx context.Context, tx *gorm.DB, attachableType, attachableID, field string) error {
+	query := tx.Where("attachable_type = ? AND attachable_id = ?", attachableType, attachableID)
+	if field != "" {
+		query = query.Where("field = ?", field)
+	}
+	var attachments []model.Attachment
+	if err := query.Find(&attachments).Error; err != nil {
+		return apperror.NewT("ATTACHMENT_FAILED", 500, err, nil).WithStack("failed to find attachments")
+	}
+
+	for _, attachment := range attachments {
+		if err := tx.Delete(&attachment).Error; err != nil {
+			return apperror.NewT("ATTACHMENT_FAILED", 500, err, nil).WithStack("failed to detach file")
+		}
+		if err := b.ReleaseFile(ctx, tx, attachment.FileID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// untrackedFiles URL file lama yang tidak tercatat di tabel files (upload sebelum ada
+// tabel files). File yang tercatat dihapus lewat ReleaseFile, bukan OldFiles job upload.
+func (b *BaseService) untrackedFiles(tx *gorm.DB, fileURLs []string) ([]string, error) {
+	var untracked []string
+	for _, fileURL := range fileURLs {
+		key, ok := fileUploader.FileKey(b.Storage, fileURL)
+		if !ok {
+			untracked = append(untracked, fileURL)
+			continue
+		}
+		var count int64
+		err := tx.Unscoped().Model(&model.File{}).
+			Where("storage_key = ? OR EXISTS (SELECT 1 FROM jsonb_each_text(variants) v WHERE v.value = ?)", key, key).
+			Count(&count).Error
+		if err != nil {
+			return nil, apperror.NewT("FILE_CHECK_FAILED", 500, err, nil).WithStack("failed to check old file")
+		}
+		if count == 0 {
+			untracked = append(untracked, fileURL)
+		}
+	}
+	return untracked, nil
+}
+
+// FindAttachment file yang terpasang di field model, gorm.ErrRecordNotFound kalau tidak ada
+func (b *BaseService) FindAttachment(ctx context.Context, attachableType, attachableID, field string) (model.File, error) {
+	var attachment model.Attachment
+	err := b.Db.WithContext(ctx).Preload("File").
+		Where("attachable_type = ? AND attachable_id = ? AND field = ?", attachableType, attachableID, field).
+		Order("created_at DESC").
+		First(&attachment).Error
+	if err != nil {
+		return model.File{}, err
+	}
+	if attachment.File == nil {
+		return model.File{}, gorm.ErrRecordNotFound
+	}
+	return *attachment.File, nil
+}
@@ -0,0 +1,141 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"template-golang/internal/db/model"
+	"template-golang/pkg/fileUploader"
+	"template-golang/pkg/queue"
+
+	"gorm.io/gorm"
+)
+
+func TestSameKind(t *testing.T) {
+	tests := []struct {
+		key, like, nested string
+	}{
+		{key: "avatars/a.webp", like: "avatars/%.webp", nested: "avatars/%/%"},
+		{key: "images/facilities/a.jpg", like: "images/facilities/%.jpg", nested: "images/facilities/%/%"},
+		{key: "a.pdf", like: "%.pdf", nested: "%/%"},
+		{key: "my_folder/50%/a.webp", like: `my\_folder/50\%/%.webp`, nested: `my\_folder/50\%/%/%`},
+	}
+	for _, tt := range tests {
+		like, nested := sameKind(tt.key)
+		if like != tt.like || nested != tt.nested {
+			t.Errorf("sameKind(%s) = %s, %s, want %s, %s", tt.key, like, nested, tt.like, tt.nested)
+		}
+	}
+}
+
+// createFile baris files ready dengan satu referensi
+func createFile(t *testing.T, b *BaseService, key, checksum string) model.File {
+	t.Helper()
+	file := model.File{StorageKey: key, Checksum: &checksum, Status: model.FileStatusReady, RefCount: 1}
+	if err := b.DB().Create(&file).Error; err != nil {
+		t.Fatalf("create file: %v", err)
+	}
+	return file
+}
+
+func refCount(t *testing.T, b *BaseService, id string) int {
+	t.Helper()
+	var file model.File
+	if err := b.DB().Unscoped().First(&file, "id = ?", id).Error; err != nil {
+		t.Fatalf("load file %s: %v", id, err)
+	}
+	return file.RefCount
+}
+
+func TestAcquireDuplicate(t *testing.T) {
+	b, _ := newTestService(t)
+	ctx := context.Background()
+	avatar := createFile(t, b, "avatars/abc.webp", "abc")
+	createFile(t, b, "avatars/nested/def.webp", "def")
+
+	acquire := func(checksum, key string) (model.File, bool) {
+		t.Helper()
+		var dup model.File
+		var found bool
+		err := b.InTxVoid(ctx, func(tx *gorm.DB) error {
+			var err error
+			dup, found, err = b.AcquireDuplicate(tx, checksum, key, "")
+			return err
+		})
+		if err != nil {
+			t.Fatalf("AcquireDuplicate(%s, %s): %v", checksum, key, err)
+		}
+		return dup, found
+	}
+
+	if dup, found := acquire("abc", "avatars/new.webp"); !found || dup.ID != avatar.ID || dup.RefCount != 2 {
+		t.Errorf("same folder: found=%v dup=%+v", found, dup)
+	}
+	if refCount(t, b, avatar.ID) != 2 {
+		t.Errorf("ref_count = %d, want 2", refCount(t, b, avatar.ID))
+	}
+
+	misses := map[string][2]string{
+		"other folder":    {"abc", "images/new.webp"},
+		"other extension": {"abc", "avatars/new.png"},
+		"subfolder":       {"def", "avatars/new.webp"},
+		"private":         {"abc", "private/avatars/new.webp"},
+	}
+	for name, args := range misses {
+		if dup, found := acquire(args[0], args[1]); found {
+			t.Errorf("%s: acquired %s", name, dup.StorageKey)
+		}
+	}
+}
+
+func TestReleaseFile(t *testing.T) {
+	b, client := newTestService(t)
+	ctx := context.Background()
+	file := createFile(t, b, "avatars/abc.webp", "abc")
+	if err := b.DB().Model(&file).UpdateColumn("ref_count", 2).Error; err != nil {
+		t.Fatal(err)
+	}
+	deleteJobs := func() int64 {
+		n, _ := client.StreamLen(ctx, queue.Stream(fileUploader.DeleteFileJob))
+		return n
+	}
+
+	// masih dipakai referensi lain
+	if err := b.InTxVoid(ctx, func(tx *gorm.DB) error { return b.ReleaseFile(ctx, tx, file.ID) }); err != nil {
+		t.Fatalf("ReleaseFile: %v", err)
+	}
+	if refCount(t, b, file.ID) != 1 || deleteJobs() != 0 {
+		t.Fatalf("after first release: ref_count=%d delete jobs=%d", refCount(t, b, file.ID), deleteJobs())
+	}
+
+	// transaksi yang di-rollback tidak menghapus file maupun mengantrikan job
+	err := b.InTxVoid(ctx, func(tx *gorm.DB) error {
+		if err := b.ReleaseFile(ctx, tx, file.ID); err != nil {
+			return err
+		}
+		return errors.New("boom")
+	})
+	if err == nil {
+		t.Fatal("InTxVoid error = nil, want boom")
+	}
+	if refCount(t, b, file.ID) != 1 || deleteJobs() != 0 {
+		t.Fatalf("after rollback: ref_count=%d delete jobs=%d", refCount(t, b, file.ID), deleteJobs())
+	}
+
+	// referensi terakhir: baris di-soft delete, job hapus diantrikan setelah commit
+	if err := b.InTxVoid(ctx, func(tx *gorm.DB) error { return b.ReleaseFile(ctx, tx, file.ID) }); err != nil {
+		t.Fatalf("ReleaseFile: %v", err)
+	}
+	if err := b.DB().First(&model.File{}, "id = ?", file.ID).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("released file still active: %v", err)
+	}
+	if refCount(t, b, file.ID) != 0 || deleteJobs() != 1 {
+		t.Errorf("after last release: ref_count=%d delete jobs=%d", refCount(t, b, file.ID), deleteJobs())
+	}
+
+	// file yang sudah dihapus diabaikan
+	if err := b.InTxVoid(ctx, func(tx *gorm.DB) error { return b.ReleaseFile(ctx, tx, file.ID) }); err != nil {
+		t.Errorf("ReleaseFile deleted file: %v", err)
+	}
+}
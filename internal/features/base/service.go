@@ -2,23 +2,37 @@ package base
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"os"
 
+	"template-golang/internal/db/model"
 	"template-golang/pkg/apperror"
+	"template-golang/pkg/fileUploader"
+	"template-golang/pkg/helper"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/queue"
 	"template-golang/pkg/redisx"
+	"template-golang/pkg/storage"
 
 	"gorm.io/gorm"
 )
 
 // BaseService menyediakan akses ke DB & transaksi
 type BaseService struct {
-	Db    *gorm.DB
-	Redis *redisx.Client
+	Db      *gorm.DB
+	Redis   *redisx.Client
+	Storage storage.Storage
+	Queue   *queue.Client
 }
 
-func NewBaseService(db *gorm.DB, redis *redisx.Client) *BaseService {
+func NewBaseService(db *gorm.DB, redis *redisx.Client, store storage.Storage, q *queue.Client) *BaseService {
 	return &BaseService{
-		Db:    db,
-		Redis: redis,
+		Db:      db,
+		Redis:   redis,
+		Storage: store,
+		Queue:   q,
 	}
 }
 
@@ -29,14 +43,16 @@ func (b *BaseService) DB() *gorm.DB {
 
 // InTx runs function inside transaction (with return)
 func (b *BaseService) InTx(ctx context.Context, fn func(*gorm.DB) (any, error)) (any, error) {
-	tx := b.Db.Begin()
+	hooks := &txHooks{}
+	tx := b.Db.WithContext(context.WithValue(ctx, txHooksKey{}, hooks)).Begin()
 	if tx.Error != nil {
-		return nil, apperror.New("base_service", "in_tx", 500, tx.Error.Error(), "failed to begin transaction")
+		return nil, apperror.NewT("TRANSACTION_FAILED", 500, tx.Error.Error(), nil).WithStack("failed to begin transaction")
 	}
 
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
+			hooks.rolledBack(ctx)
 			panic(r)
 		}
 	}()
@@ -44,38 +60,246 @@ func (b *BaseService) InTx(ctx context.Context, fn func(*gorm.DB) (any, error))
 	result, err := fn(tx)
 	if err != nil {
 		tx.Rollback()
-		return nil, apperror.New("base_service", "in_tx", 500, err, "failed to execute transaction")
+		hooks.rolledBack(ctx)
+		return nil, apperror.NewT("TRANSACTION_FAILED", 500, err, nil).WithStack("failed to execute transaction")
 	}
 
 	if err := tx.Commit().Error; err != nil {
-		return nil, apperror.New("base_service", "in_tx", 500, err, "failed to commit transaction")
+		hooks.rolledBack(ctx)
+		return nil, apperror.NewT("TRANSACTION_FAILED", 500, err, nil).WithStack("failed to commit transaction")
 	}
 
+	hooks.committed(ctx)
 	return result, nil
 }
 
 // InTxVoid runs function inside transaction (no return)
 func (b *BaseService) InTxVoid(ctx context.Context, fn func(*gorm.DB) error) error {
-	tx := b.Db.Begin()
+	hooks := &txHooks{}
+	tx := b.Db.WithContext(context.WithValue(ctx, txHooksKey{}, hooks)).Begin()
 	if tx.Error != nil {
-		return apperror.New("base_service", "in_tx_void", 500, tx.Error, "failed to begin transaction")
+		return apperror.NewT("TRANSACTION_FAILED", 500, tx.Error, nil).WithStack("failed to begin transaction")
 	}
 
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
+			hooks.rolledBack(ctx)
 			panic(r)
 		}
 	}()
 
 	if err := fn(tx); err != nil {
 		tx.Rollback()
-		return apperror.New("base_service", "in_tx_void", 500, err, "failed to execute transaction")
+		hooks.rolledBack(ctx)
+		return apperror.NewT("TRANSACTION_FAILED", 500, err, nil).WithStack("failed to execute transaction")
 	}
 
 	if err := tx.Commit().Error; err != nil {
-		return apperror.New("base_service", "in_tx_void", 500, err, "failed to commit transaction")
+		hooks.rolledBack(ctx)
+		return apperror.NewT("TRANSACTION_FAILED", 500, err, nil).WithStack("failed to commit transaction")
 	}
 
+	hooks.committed(ctx)
 	return nil
 }
+
+type txHooksKey struct{}
+
+// txHooks fungsi yang dijalankan InTx setelah transaksinya commit / rollback
+type txHooks struct {
+	commit   []func(ctx context.Context) error
+	rollback []func(ctx context.Context)
+}
+
+// committed jalankan hook AfterCommit, error-nya hanya dicatat karena data sudah tersimpan
+func (h *txHooks) committed(ctx context.Context) {
+	for _, fn := range h.commit {
+		if err := fn(ctx); err != nil {
+			logger.L().Errorf("after commit hook failed: %v", err)
+		}
+	}
+}
+
+func (h *txHooks) rolledBack(ctx context.Context) {
+	for _, fn := range h.rollback {
+		fn(ctx)
+	}
+}
+
+// AfterCommit jalankan fn setelah transaksi InTx milik tx commit, misal Enqueue job yang
+// membaca baris yang ditulis tx. Error fn hanya dicatat di log dan InTx tetap berhasil karena
+// data sudah tersimpan; fn sendiri yang menandai kegagalannya (misal status file failed).
+// Kalau tx bukan transaksi InTx, fn langsung dijalankan dan error-nya dikembalikan.
+func (b *BaseService) AfterCommit(tx *gorm.DB, fn func(ctx context.Context) error) error {
+	if hooks, ok := tx.Statement.Context.Value(txHooksKey{}).(*txHooks); ok {
+		hooks.commit = append(hooks.commit, fn)
+		return nil
+	}
+	return fn(tx.Statement.Context)
+}
+
+// AfterRollback jalankan fn kalau transaksi InTx milik tx di-rollback
+func (b *BaseService) AfterRollback(tx *gorm.DB, fn func(ctx context.Context)) {
+	if hooks, ok := tx.Statement.Context.Value(txHooksKey{}).(*txHooks); ok {
+		hooks.rollback = append(hooks.rollback, fn)
+	}
+}
+
+// EnqueueUpload generate URL file, catat di tabel files lalu antrikan upload-nya ke worker.
+// File di folder yang diawali storage.PrivatePrefix jadi file private.
+// oldFile (jika ada) dan belum tercatat di tabel files dihapus worker setelah upload berhasil,
+// file yang tercatat dihapus saat lampirannya dilepas (Attach).
+func (b *BaseService) EnqueueUpload(ctx context.Context, tx *gorm.DB, folder string, file *multipart.FileHeader, compress bool, oldFile *string) (model.File, error) {
+	var fileURL string
+	var err error
+	if compress {
+		fileURL, err = fileUploader.GenerateFileURLCompressed(b.Storage, folder, file)
+	} else {
+		fileURL, err = fileUploader.GenerateFileURL(b.Storage, folder, file)
+	}
+	if err != nil {
+		return model.File{}, apperror.NewT("FILE_ENQUEUE_FAILED", 500, err, nil).WithStack("failed to generate file url")
+	}
+
+	var oldFiles []string
+	if oldFile != nil && *oldFile != "" {
+		oldFiles = append(oldFiles, *oldFile)
+	}
+
+	upload, err := b.EnqueueUploadFile(ctx, tx, fileUploader.QueueUploadFile{
+		FilePath:         fileURL,
+		IsCompressToWebp: helper.BoolPtr(compress),
+		File:             file,
+		OldFiles:         oldFiles,
+	})
+	if err != nil {
+		return model.File{}, err
+	}
+	return upload.File, nil
+}
+
+// EnqueueUploadFile simpan payload.File ke tmp, catat di tabel files (status pending) lewat tx
+// lalu antrikan job upload setelah tx commit (AfterCommit), id job-nya sudah bisa dipakai
+// sebelum itu. URL file publik diganti "<folder>/<sha256>.<ext>" (lihat Upload.File.URL).
+// Kalau isinya sudah pernah diupload, file lama dipakai ulang tanpa job (JobID kosong).
+// Kalau request membawa Idempotency-Key yang sudah dipakai, file tidak disimpan ulang
+// dan yang dikembalikan id job lama bersama queue.ErrDuplicate.
+func (b *BaseService) EnqueueUploadFile(ctx context.Context, tx *gorm.DB, payload fileUploader.QueueUploadFile) (Upload, error) {
+	existing, err := b.FindIdempotentJob(ctx, fileUploader.UploadJob)
+	if err != nil {
+		return Upload{}, err
+	}
+	if existing != "" {
+		return Upload{JobID: existing}, queue.ErrDuplicate
+	}
+
+	staged, err := fileUploader.StageUpload(payload)
+	if err != nil {
+		return Upload{}, err
+	}
+	return b.enqueueStaged(ctx, tx, staged, ctxIdempotencyKey(ctx))
+}
+
+// EnqueueGeneratedFile catat file yang digenerate server di path (di fileUploader.TmpDir)
+// sebagai file fileURL lalu antrikan upload-nya seperti EnqueueUploadFile. File tmp dihapus
+// worker setelah upload, atau saat tx di-rollback.
+func (b *BaseService) EnqueueGeneratedFile(ctx context.Context, tx *gorm.DB, path, fileURL string) (Upload, error) {
+	staged, err := fileUploader.StageLocalFile(path, fileURL)
+	if err != nil {
+		os.Remove(path)
+		return Upload{}, err
+	}
+	return b.enqueueStaged(ctx, tx, staged, "")
+}
+
+// enqueueStaged catat file hasil staging di tabel files lalu antrikan job upload setelah tx commit
+func (b *BaseService) enqueueStaged(ctx context.Context, tx *gorm.DB, staged fileUploader.QueueUploadFile, idempotencyKey string) (Upload, error) {
+	var err error
+	// key content-addressed: isi yang sama di folder yang sama tidak diupload dua kali.
+	// File private tetap pakai nama unik karena tidak dipakai bersama (AcquireDuplicate).
+	if key, ok := fileUploader.FileKey(b.Storage, staged.FilePath); ok && !storage.IsPrivate(key) {
+		staged.FilePath = fileUploader.ContentURL(b.Storage, staged.FilePath, staged.Checksum)
+	}
+	staged.OldFiles, err = b.untrackedFiles(tx, staged.OldFiles)
+	if err != nil {
+		os.Remove(*staged.FilePathTmp)
+		return Upload{}, err
+	}
+	file, created, err := b.acquireFile(ctx, tx, staged)
+	if err != nil {
+		os.Remove(*staged.FilePathTmp)
+		return Upload{}, err
+	}
+	if !created {
+		os.Remove(*staged.FilePathTmp)
+		return Upload{File: file}, nil
+	}
+	staged.FileID = file.ID
+	tmp := *staged.FilePathTmp
+
+	jobID := queue.NewJobID()
+	opts := []queue.EnqueueOption{queue.JobID(jobID), queue.Owner(ctxUserID(ctx))}
+	if key := idempotencyKey; key != "" {
+		// key diklaim sebelum commit supaya request kembar langsung ditolak
+		existing, err := b.Queue.ClaimIdempotencyKey(ctx, fileUploader.UploadJob, key, jobID)
+		if err != nil {
+			os.Remove(tmp)
+			if errors.Is(err, queue.ErrDuplicate) {
+				return Upload{JobID: existing}, err
+			}
+			return Upload{}, err
+		}
+		b.AfterRollback(tx, func(ctx context.Context) {
+			if err := b.Queue.ReleaseIdempotencyKey(ctx, fileUploader.UploadJob, key); err != nil {
+				logger.L().Warnf("failed to release idempotency key of job %s: %v", jobID, err)
+			}
+		})
+		opts = append(opts, queue.IdempotencyKey(key))
+	}
+
+	// job baru diantrikan setelah commit: worker selalu menemukan baris files-nya,
+	// dan transaksi yang di-rollback tidak meninggalkan job
+	b.AfterRollback(tx, func(context.Context) { os.Remove(tmp) })
+	err = b.AfterCommit(tx, func(ctx context.Context) error {
+		if _, err := b.Queue.Enqueue(ctx, fileUploader.UploadJob, staged, opts...); err != nil {
+			os.Remove(tmp)
+			b.Db.WithContext(ctx).Model(&model.File{}).Where("id = ?", file.ID).
+				Updates(map[string]any{"status": model.FileStatusFailed, "error": err.Error()})
+			return fmt.Errorf("failed to enqueue upload job %s for file %s: %w", jobID, file.ID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return Upload{}, err
+	}
+	return Upload{File: file, JobID: jobID}, nil
+}
+
+// FindIdempotentJob id job name yang sudah di-Enqueue dengan Idempotency-Key request ini,
+// kosong kalau request tanpa key atau key belum pernah dipakai
+func (b *BaseService) FindIdempotentJob(ctx context.Context, name string) (string, error) {
+	key := ctxIdempotencyKey(ctx)
+	if key == "" {
+		return "", nil
+	}
+	return b.Queue.LookupIdempotencyKey(ctx, name, key)
+}
+
+// ctxUserID id user dari AuthMiddleware, kosong untuk request publik
+func ctxUserID(ctx context.Context) string {
+	userID, _ := ctx.Value("user_id").(string)
+	return userID
+}
+
+// ctxIdempotencyKey header Idempotency-Key dari middleware.IdempotencyKey,
+// diberi prefix id user supaya key antar user tidak bentrok. Request tanpa user
+// (publik) tidak punya namespace sendiri, key-nya diabaikan.
+func ctxIdempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value("idempotency_key").(string)
+	userID := ctxUserID(ctx)
+	if key == "" || userID == "" {
+		return ""
+	}
+	return userID + ":" + key
+}
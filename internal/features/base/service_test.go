@@ -0,0 +1,88 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"template-golang/internal/db/dbtest"
+	"template-golang/pkg/config"
+	"template-golang/pkg/queue"
+	"template-golang/pkg/redisx"
+	"template-golang/pkg/storage"
+
+	"github.com/alicebob/miniredis/v2"
+	"gorm.io/gorm"
+)
+
+// newTestService BaseService dengan database test (dbtest), Redis in-memory dan storage memory
+func newTestService(t *testing.T) (*BaseService, *redisx.Client) {
+	t.Helper()
+	conn := dbtest.New(t)
+
+	mr := miniredis.RunT(t)
+	t.Setenv("REDIS_ADDR", mr.Addr())
+	config.LoadConfig()
+	client, err := redisx.New()
+	if err != nil {
+		t.Fatalf("redisx.New: %v", err)
+	}
+	return NewBaseService(conn, client, storage.NewMemory("http://localhost/storage"), queue.NewClient(client)), client
+}
+
+func TestInTxHooks(t *testing.T) {
+	b, _ := newTestService(t)
+	ctx := context.Background()
+
+	var committed, rolledBack int
+	result, err := b.InTx(ctx, func(tx *gorm.DB) (any, error) {
+		b.AfterCommit(tx, func(context.Context) error { committed++; return nil })
+		// error hook setelah commit hanya dicatat, data sudah tersimpan
+		b.AfterCommit(tx, func(context.Context) error { committed++; return errors.New("enqueue failed") })
+		b.AfterRollback(tx, func(context.Context) { rolledBack++ })
+		return "ok", tx.Exec("SELECT 1").Error
+	})
+	if err != nil || result != "ok" {
+		t.Fatalf("InTx = %v, %v", result, err)
+	}
+	if committed != 2 || rolledBack != 0 {
+		t.Errorf("after commit: committed=%d rolledBack=%d, want 2, 0", committed, rolledBack)
+	}
+
+	committed, rolledBack = 0, 0
+	err = b.InTxVoid(ctx, func(tx *gorm.DB) error {
+		b.AfterCommit(tx, func(context.Context) error { committed++; return nil })
+		b.AfterRollback(tx, func(context.Context) { rolledBack++ })
+		return errors.New("boom")
+	})
+	if err == nil {
+		t.Fatal("InTxVoid error = nil, want boom")
+	}
+	if committed != 0 || rolledBack != 1 {
+		t.Errorf("after rollback: committed=%d rolledBack=%d, want 0, 1", committed, rolledBack)
+	}
+}
+
+func TestAfterCommitOutsideInTx(t *testing.T) {
+	b := &BaseService{}
+	tx := &gorm.DB{Statement: &gorm.Statement{Context: context.Background()}}
+	ran := false
+	err := b.AfterCommit(tx, func(context.Context) error {
+		ran = true
+		return errors.New("boom")
+	})
+	if !ran || err == nil {
+		t.Errorf("AfterCommit outside InTx ran=%v err=%v, want ran and error returned", ran, err)
+	}
+}
+
+func TestCtxIdempotencyKey(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "idempotency_key", "k1")
+	if key := ctxIdempotencyKey(ctx); key != "" {
+		t.Errorf("anonymous key = %q, want ignored", key)
+	}
+	ctx = context.WithValue(ctx, "user_id", "u1")
+	if key := ctxIdempotencyKey(ctx); key != "u1:k1" {
+		t.Errorf("key = %q, want u1:k1", key)
+	}
+}
@@ -0,0 +1,105 @@
+package dto
+
+import (
+	"mime/multipart"
+	"time"
+
+	"template-golang/internal/db/model"
+)
+
+// CreateBrochureRequest represents the create brochure request (multipart form)
+// @Description Create brochure request payload
+type CreateBrochureRequest struct {
+	// @Description Brochure title per locale, id_ID is required
+	// @Example {"id_ID":"Brosur PPDB 2025","en_US":"2025 Admission Brochure"}
+	Title model.Translations `form:"title" json:"title" validate:"default_locale,locales" swaggertype:"object,string"`
+	// @Description Brochure description per locale
+	// @Example {"id_ID":"Informasi lengkap penerimaan peserta didik baru","en_US":"Complete admission information"}
+	Description model.Translations `form:"description" json:"description" validate:"omitempty,locales" swaggertype:"object,string"`
+	// @Description Display order, ascending
+	// @Example 1
+	SortOrder int `form:"sort_order" json:"sort_order"`
+
+	File          *multipart.FileHeader `form:"-" json:"-" swaggerignore:"true"`
+	FileName      string                `form:"-" json:"file" validate:"required,pdf"`
+	FileSize      int64                 `form:"-" json:"file_size" validate:"size=20"`
+	Thumbnail     *multipart.FileHeader `form:"-" json:"-" swaggerignore:"true"`
+	ThumbnailName string                `form:"-" json:"thumbnail" validate:"omitempty,image"`
+	ThumbnailSize int64                 `form:"-" json:"thumbnail_size" validate:"size=2"`
+}
+
+// UpdateBrochureRequest represents the update brochure request (multipart form)
+// @Description Update brochure request payload
+type UpdateBrochureRequest struct {
+	// @Description Brochure title per locale, only the given locales are changed
+	// @Example {"en_US":"2025 Admission Brochure"}
+	Title model.Translations `form:"title" json:"title,omitempty" validate:"omitempty,locales" swaggertype:"object,string"`
+	// @Description Brochure description per locale, an empty string removes the locale
+	// @Example {"en_US":"Complete admission information"}
+	Description model.Translations `form:"description" json:"description,omitempty" validate:"omitempty,locales" swaggertype:"object,string"`
+	// @Description Display order, ascending
+	// @Example 1
+	SortOrder *int `form:"sort_order" json:"sort_order,omitempty"`
+
+	File          *multipart.FileHeader `form:"-" json:"-" swaggerignore:"true"`
+	FileName      string                `form:"-" json:"file" validate:"omitempty,pdf"`
+	FileSize      int64                 `form:"-" json:"file_size" validate:"size=20"`
+	Thumbnail     *multipart.FileHeader `form:"-" json:"-" swaggerignore:"true"`
+	ThumbnailName string                `form:"-" json:"thumbnail" validate:"omitempty,image"`
+	ThumbnailSize int64                 `form:"-" json:"thumbnail_size" validate:"size=2"`
+}
+
+// ReorderRequest represents the reorder request data structure
+// @Description Reorder brochures, sort_order follows the position in the list
+type ReorderRequest struct {
+	// @Description Brochure IDs in the desired order
+	// @Example ["id1","id2"]
+	IDs []string `json:"ids" validate:"required,min=1"`
+}
+
+// BrochureResponse represents a brochure localized to the requested language
+// @Description Localized brochure response payload
+type BrochureResponse struct {
+	// @Description Brochure ID
+	// @Example clx1abc2d0000qwerty
+	ID string `json:"id"`
+	// @Description Language of the title actually returned, the fallback (id_ID) when the requested language has no translation
+	// @Example id_ID
+	Lang string `json:"lang"`
+	// @Description Brochure title
+	// @Example Brosur PPDB 2025
+	Title string `json:"title"`
+	// @Description Brochure description
+	// @Example Informasi lengkap penerimaan peserta didik baru
+	Description *string `json:"description"`
+	// @Description Brochure PDF URL
+	// @Example https://is3.cloudhost.id/uts/brochures/1758074703488556600.pdf
+	FileURL string `json:"file_url"`
+	// @Description Thumbnail URL
+	// @Example https://is3.cloudhost.id/uts/brochure_thumbnails/1758074703488556600.webp
+	ThumbnailURL *string `json:"thumbnail_url"`
+	// @Description Display order
+	// @Example 1
+	SortOrder int `json:"sort_order"`
+	// @Description Publication timestamp
+	// @Example 2024-03-15T10:00:00Z
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// NewBrochureResponse builds a brochure response in the given locale
+func NewBrochureResponse(brochure model.Brochure, lang string) BrochureResponse {
+	title, used := brochure.Title.Resolve(lang)
+	if used == "" {
+		used = lang
+	}
+	return BrochureResponse{
+		ID:           brochure.ID,
+		Lang:         used,
+		Title:        title,
+		Description:  brochure.Description.GetPtr(lang),
+		FileURL:      brochure.FileURL,
+		ThumbnailURL: brochure.ThumbnailURL,
+		SortOrder:    brochure.SortOrder,
+		PublishedAt:  brochure.PublishedAt,
+	}
+}
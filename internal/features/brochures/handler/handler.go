@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"template-golang/internal/features/brochures/dto"
+	"template-golang/internal/features/brochures/service"
+	"template-golang/pkg/middleware"
+	"template-golang/pkg/response"
+	"template-golang/pkg/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	svc *service.Service
+}
+
+func NewHandler(svc *service.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) RegisterRoutes(r fiber.Router) {
+	admin := middleware.AuthMiddleware(&[]string{"admin", "superadmin"})
+
+	router := r.Group("/brochures")
+	router.Get("/", h.PublicList)
+	router.Get("/all", admin, h.ListBrochures)
+	router.Put("/order", admin, h.Reorder)
+	router.Get("/:id", h.PublicShow)
+	router.Post("/", admin, h.Store)
+	router.Put("/:id", admin, h.UpdateBrochure)
+	router.Patch("/:id/publish", admin, h.Publish)
+	router.Patch("/:id/unpublish", admin, h.Unpublish)
+	router.Delete("/:id", admin, h.DeleteBrochure)
+}
+
+// @Summary List published brochures
+// @Description Public list of published brochures, cached
+// @Tags Brochures
+// @Accept json
+// @Produce json
+// @Param lang query string false "Language (id_ID, en_US), overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred language"
+// @Success 200 {array} dto.BrochureResponse
+// @Router /api/v1/brochures [get]
+func (h *Handler) PublicList(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandlePublicList(ctx.Context())
+	if err != nil {
+		return response.Error(ctx, "FETCH_BROCHURES_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Get published brochure
+// @Description Public details of a published brochure
+// @Tags Brochures
+// @Accept json
+// @Produce json
+// @Param id path string true "Brochure ID"
+// @Param lang query string false "Language (id_ID, en_US), overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred language"
+// @Success 200 {object} dto.BrochureResponse
+// @Router /api/v1/brochures/{id} [get]
+func (h *Handler) PublicShow(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	data, err := h.svc.HandlePublicShow(ctx.Context(), id)
+	if err != nil {
+		return response.Error(ctx, "FETCH_BROCHURE_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary List all brochures
+// @Description List all brochures including unpublished ones
+// @Tags Brochures
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.Brochure
+// @Router /api/v1/brochures/all [get]
+func (h *Handler) ListBrochures(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleList(ctx.Context())
+	if err != nil {
+		return response.Error(ctx, "FETCH_BROCHURES_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Store new brochure
+// @Description Store a new brochure with a PDF file and optional thumbnail
+// @Tags Brochures
+// @Accept mpfd
+// @Produce json
+// @Param title formData string true "Brochure title, JSON object per locale (id_ID required, en_US optional) or plain id_ID text"
+// @Param description formData string false "Brochure description, JSON object per locale or plain id_ID text"
+// @Param sort_order formData int false "Display order"
+// @Param file formData file true "Brochure PDF (max 20 MB)"
+// @Param thumbnail formData file false "Thumbnail image (jpg, jpeg, png, max 2 MB)"
+// @Security BearerAuth
+// @Success 200 {object} model.Brochure
+// @Router /api/v1/brochures/ [post]
+func (h *Handler) Store(ctx *fiber.Ctx) error {
+	var req dto.CreateBrochureRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
+	}
+	if file, err := ctx.FormFile("file"); err == nil {
+		req.File, req.FileName, req.FileSize = file, file.Filename, file.Size
+	}
+	if thumb, err := ctx.FormFile("thumbnail"); err == nil {
+		req.Thumbnail, req.ThumbnailName, req.ThumbnailSize = thumb, thumb.Filename, thumb.Size
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	data, err := h.svc.HandleCreate(ctx.Context(), req)
+	if err != nil {
+		return response.Error(ctx, "CREATE_BROCHURE_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Update brochure
+// @Description Update a brochure, uploaded files replace the previous ones
+// @Tags Brochures
+// @Accept mpfd
+// @Produce json
+// @Param id path string true "Brochure ID"
+// @Param title formData string false "Brochure title, JSON object with the locales to change"
+// @Param description formData string false "Brochure description, JSON object with the locales to change (empty string removes a locale)"
+// @Param sort_order formData int false "Display order"
+// @Param file formData file false "Brochure PDF (max 20 MB)"
+// @Param thumbnail formData file false "Thumbnail image (jpg, jpeg, png, max 2 MB)"
+// @Security BearerAuth
+// @Success 200 {object} model.Brochure
+// @Router /api/v1/brochures/{id} [put]
+func (h *Handler) UpdateBrochure(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	var req dto.UpdateBrochureRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
+	}
+	if file, err := ctx.FormFile("file"); err == nil {
+		req.File, req.FileName, req.FileSize = file, file.Filename, file.Size
+	}
+	if thumb, err := ctx.FormFile("thumbnail"); err == nil {
+		req.Thumbnail, req.ThumbnailName, req.ThumbnailSize = thumb, thumb.Filename, thumb.Size
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	data, err := h.svc.HandleUpdate(ctx.Context(), id, req)
+	if err != nil {
+		return response.Error(ctx, "UPDATE_BROCHURE_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Publish brochure
+// @Description Make a brochure visible on the public list
+// @Tags Brochures
+// @Accept json
+// @Produce json
+// @Param id path string true "Brochure ID"
+// @Security BearerAuth
+// @Success 200 {object} model.Brochure
+// @Router /api/v1/brochures/{id}/publish [patch]
+func (h *Handler) Publish(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleSetPublished(ctx.Context(), ctx.Params("id"), true)
+	if err != nil {
+		return response.Error(ctx, "PUBLISH_BROCHURE_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Unpublish brochure
+// @Description Hide a brochure from the public list
+// @Tags Brochures
+// @Accept json
+// @Produce json
+// @Param id path string true "Brochure ID"
+// @Security BearerAuth
+// @Success 200 {object} model.Brochure
+// @Router /api/v1/brochures/{id}/unpublish [patch]
+func (h *Handler) Unpublish(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleSetPublished(ctx.Context(), ctx.Params("id"), false)
+	if err != nil {
+		return response.Error(ctx, "UNPUBLISH_BROCHURE_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Reorder brochures
+// @Description Set the display order of brochures
+// @Tags Brochures
+// @Accept json
+// @Produce json
+// @Param body body dto.ReorderRequest true "Brochure IDs in order"
+// @Security BearerAuth
+// @Success 200 {array} model.Brochure
+// @Router /api/v1/brochures/order [put]
+func (h *Handler) Reorder(ctx *fiber.Ctx) error {
+	var req dto.ReorderRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	data, err := h.svc.HandleReorder(ctx.Context(), req)
+	if err != nil {
+		return response.Error(ctx, "REORDER_BROCHURES_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Delete brochure
+// @Description Delete an existing brochure
+// @Tags Brochures
+// @Accept json
+// @Produce json
+// @Param id path string true "Brochure ID"
+// @Security BearerAuth
+// @Success 200 {object} model.Brochure
+// @Router /api/v1/brochures/{id} [delete]
+func (h *Handler) DeleteBrochure(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	data, err := h.svc.HandleDelete(ctx.Context(), id)
+	if err != nil {
+		return response.Error(ctx, "DELETE_BROCHURE_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
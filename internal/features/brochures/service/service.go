@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"template-golang/internal/db/model"
+	"template-golang/internal/features/base"
+	"template-golang/internal/features/brochures/dto"
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/locale"
+	"template-golang/pkg/logger"
+
+	"github.com/goccy/go-json"
+	"gorm.io/gorm"
+)
+
+const (
+	folder        = "brochures"
+	thumbFolder   = "brochure_thumbnails"
+	cacheKey      = "brochures:public"
+	cachePattern  = "brochures:*"
+	cacheDuration = 10 * time.Minute
+)
+
+type Service struct {
+	*base.BaseService
+}
+
+func NewService(baseService *base.BaseService) *Service {
+	return &Service{
+		BaseService: baseService,
+	}
+}
+
+// forgetCache hapus cache list publik setelah data berubah
+func (s *Service) forgetCache(ctx context.Context) {
+	if err := s.Redis.DelByPattern(ctx, cachePattern); err != nil {
+		logger.L().Warnf("brochures: failed to clear cache: %v", err)
+	}
+}
+
+// publishedBrochures list brosur terbit, cache menyimpan semua locale
+func (s *Service) publishedBrochures(ctx context.Context) ([]model.Brochure, error) {
+	if cached, err := s.Redis.Get(ctx, cacheKey); err == nil {
+		var brochures []model.Brochure
+		if err := json.Unmarshal([]byte(cached), &brochures); err == nil {
+			return brochures, nil
+		}
+	}
+
+	var brochures []model.Brochure
+	err := s.DB().Where("is_published = ?", true).Order("sort_order ASC, created_at DESC").Find(&brochures).Error
+	if err != nil {
+		return []model.Brochure{}, err
+	}
+
+	if err := s.Redis.Set(ctx, cacheKey, brochures, cacheDuration); err != nil {
+		logger.L().Warnf("brochures: failed to cache list: %v", err)
+	}
+	return brochures, nil
+}
+
+func (s *Service) HandlePublicList(ctx context.Context) ([]dto.BrochureResponse, error) {
+	brochures, err := s.publishedBrochures(ctx)
+	if err != nil {
+		return []dto.BrochureResponse{}, err
+	}
+
+	lang := locale.FromContext(ctx)
+	response := make([]dto.BrochureResponse, 0, len(brochures))
+	for _, brochure := range brochures {
+		response = append(response, dto.NewBrochureResponse(brochure, lang))
+	}
+	return response, nil
+}
+
+func (s *Service) HandlePublicShow(ctx context.Context, id string) (dto.BrochureResponse, error) {
+	var brochure model.Brochure
+	err := s.DB().First(&brochure, "id = ? AND is_published = ?", id, true).Error
+	if err != nil {
+		return dto.BrochureResponse{}, err
+	}
+	return dto.NewBrochureResponse(brochure, locale.FromContext(ctx)), nil
+}
+
+func (s *Service) HandleList(ctx context.Context) ([]model.Brochure, error) {
+	var brochures []model.Brochure
+	err := s.DB().Preload("Attachments.File").Order("sort_order ASC, created_at DESC").Find(&brochures).Error
+	if err != nil {
+		return []model.Brochure{}, err
+	}
+	return brochures, nil
+}
+
+func (s *Service) HandleShow(ctx context.Context, id string) (model.Brochure, error) {
+	var brochure model.Brochure
+	err := s.DB().First(&brochure, "id = ?", id).Error
+	if err != nil {
+		return model.Brochure{}, err
+	}
+	return brochure, nil
+}
+
+func (s *Service) HandleCreate(ctx context.Context, req dto.CreateBrochureRequest) (model.Brochure, error) {
+	brochureAny, err := s.InTx(ctx, func(tx *gorm.DB) (any, error) {
+		file, err := s.EnqueueUpload(ctx, tx, folder, req.File, false, nil)
+		if err != nil {
+			return model.Brochure{}, err
+		}
+
+		brochure := model.Brochure{
+			Title:       req.Title,
+			Description: model.Translations{}.Merge(req.Description),
+			FileURL:     file.URL,
+			SortOrder:   req.SortOrder,
+		}
+		attachments := map[string]model.File{"file": file}
+
+		if req.Thumbnail != nil {
+			thumb, err := s.EnqueueUpload(ctx, tx, thumbFolder, req.Thumbnail, true, nil)
+			if err != nil {
+				return model.Brochure{}, err
+			}
+			brochure.ThumbnailURL = &thumb.URL
+			attachments["thumbnail"] = thumb
+		}
+
+		if err := tx.Create(&brochure).Error; err != nil {
+			return model.Brochure{}, err
+		}
+		attached, err := s.AttachFiles(ctx, tx, brochure.TableName(), brochure.ID, attachments)
+		if err != nil {
+			return model.Brochure{}, err
+		}
+		brochure.Attachments = attached
+		return brochure, nil
+	})
+	if err != nil {
+		return model.Brochure{}, apperror.NewT("CREATE_BROCHURE_FAILED", 400, err, nil).WithStack(req.Title.Get(locale.Default))
+	}
+	s.forgetCache(ctx)
+	return brochureAny.(model.Brochure), nil
+}
+
+func (s *Service) HandleUpdate(ctx context.Context, id string, req dto.UpdateBrochureRequest) (model.Brochure, error) {
+	brochureAny, err := s.InTx(ctx, func(tx *gorm.DB) (any, error) {
+		var brochure model.Brochure
+		if err := tx.First(&brochure, "id = ?", id).Error; err != nil {
+			return model.Brochure{}, err
+		}
+		if req.Title != nil {
+			brochure.Title = brochure.Title.Merge(req.Title)
+			if !brochure.Title.HasDefault() {
+				return model.Brochure{}, errors.New("title must keep a " + locale.Default + " translation")
+			}
+		}
+		if req.Description != nil {
+			brochure.Description = brochure.Description.Merge(req.Description)
+		}
+		if req.SortOrder != nil {
+			brochure.SortOrder = *req.SortOrder
+		}
+		attachments := map[string]model.File{}
+		if req.File != nil {
+			file, err := s.EnqueueUpload(ctx, tx, folder, req.File, false, &brochure.FileURL)
+			if err != nil {
+				return model.Brochure{}, err
+			}
+			brochure.FileURL = file.URL
+			attachments["file"] = file
+		}
+		if req.Thumbnail != nil {
+			thumb, err := s.EnqueueUpload(ctx, tx, thumbFolder, req.Thumbnail, true, brochure.ThumbnailURL)
+			if err != nil {
+				return model.Brochure{}, err
+			}
+			brochure.ThumbnailURL = &thumb.URL
+			attachments["thumbnail"] = thumb
+		}
+		if err := tx.Save(&brochure).Error; err != nil {
+			return model.Brochure{}, err
+		}
+		attached, err := s.AttachFiles(ctx, tx, brochure.TableName(), brochure.ID, attachments)
+		if err != nil {
+			return model.Brochure{}, err
+		}
+		brochure.Attachments = attached
+		return brochure, nil
+	})
+	if err != nil {
+		return model.Brochure{}, apperror.NewT("UPDATE_BROCHURE_FAILED", 400, err, nil).WithStack(id)
+	}
+	s.forgetCache(ctx)
+	return brochureAny.(model.Brochure), nil
+}
+
+func (s *Service) HandleSetPublished(ctx context.Context, id string, published bool) (model.Brochure, error) {
+	brochureAny, err := s.InTx(ctx, func(tx *gorm.DB) (any, error) {
+		var brochure model.Brochure
+		if err := tx.First(&brochure, "id = ?", id).Error; err != nil {
+			return model.Brochure{}, err
+		}
+		brochure.IsPublished = published
+		brochure.PublishedAt = nil
+		if published {
+			now := time.Now()
+			brochure.PublishedAt = &now
+		}
+		if err := tx.Save(&brochure).Error; err != nil {
+			return model.Brochure{}, err
+		}
+		return brochure, nil
+	})
+	if err != nil {
+		return model.Brochure{}, apperror.NewT("CHANGE_BROCHURE_PUBLICATION_FAILED", 400, err, nil).WithStack(id)
+	}
+	s.forgetCache(ctx)
+	return brochureAny.(model.Brochure), nil
+}
+
+func (s *Service) HandleReorder(ctx context.Context, req dto.ReorderRequest) ([]model.Brochure, error) {
+	err := s.InTxVoid(ctx, func(tx *gorm.DB) error {
+		for i, id := range req.IDs {
+			res := tx.Model(&model.Brochure{}).Where("id = ?", id).Update("sort_order", i+1)
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return []model.Brochure{}, apperror.NewT("REORDER_BROCHURES_FAILED", 400, err, nil).WithStack(strings.Join(req.IDs, ","))
+	}
+	s.forgetCache(ctx)
+	return s.HandleList(ctx)
+}
+
+func (s *Service) HandleDelete(ctx context.Context, id string) (model.Brochure, error) {
+	var brochure model.Brochure
+	err := s.DB().First(&brochure, "id = ?", id).Error
+	if err != nil {
+		return model.Brochure{}, err
+	}
+	// file yang tidak dipakai model lain ikut dihapus dari storage
+	err = s.InTxVoid(ctx, func(tx *gorm.DB) error {
+		if err := tx.Delete(&brochure).Error; err != nil {
+			return err
+		}
+		return s.DetachAll(ctx, tx, brochure.TableName(), brochure.ID)
+	})
+	if err != nil {
+		return model.Brochure{}, err
+	}
+	s.forgetCache(ctx)
+	return brochure, nil
+}
@@ -0,0 +1,13 @@
+package brochures
+
+import (
+	"template-golang/internal/features/brochures/handler"
+	"template-golang/internal/features/brochures/service"
+
+	"github.com/google/wire"
+)
+
+var Set = wire.NewSet(
+	service.NewService,
+	handler.NewHandler,
+)
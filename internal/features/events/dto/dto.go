@@ -0,0 +1,29 @@
+package dto
+
+// BroadcastRequest represents an admin broadcast notice
+// @Description Broadcast notice request payload
+type BroadcastRequest struct {
+	// @Description Notice title
+	// @Example Maintenance
+	Title string `json:"title" validate:"required,max=150"`
+	// @Description Notice message
+	// @Example Sistem akan maintenance pukul 22:00 WIB
+	Message string `json:"message" validate:"required,max=2000"`
+	// @Description Notice level (info, warning, critical), default info
+	// @Example warning
+	Level string `json:"level" validate:"omitempty,oneof=info warning critical"`
+	// @Description Only send to users with these roles, empty for everyone
+	// @Example ["admin"]
+	Roles []string `json:"roles" validate:"omitempty,dive,oneof=admin superadmin"`
+	// @Description Only send to this user
+	UserID string `json:"user_id" validate:"omitempty"`
+}
+
+// Notice data event "notice"
+type Notice struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+	Level   string `json:"level"`
+	// @Description ID of the admin who sent the notice
+	SentBy string `json:"sent_by"`
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"bufio"
+	"fmt"
+	"time"
+
+	"template-golang/internal/features/events/dto"
+	"template-golang/internal/features/events/service"
+	"template-golang/pkg/events"
+	"template-golang/pkg/middleware"
+	"template-golang/pkg/response"
+	"template-golang/pkg/validator"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// heartbeatInterval komentar SSE supaya proxy tidak menutup koneksi idle
+	heartbeatInterval = 15 * time.Second
+	// retryInterval jeda reconnect EventSource (ms)
+	retryInterval = 3000
+)
+
+type Handler struct {
+	svc *service.Service
+}
+
+func NewHandler(svc *service.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) RegisterRoutes(r fiber.Router) {
+	router := r.Group("/events")
+	router.Get("/", middleware.TokenFromQuery("access_token"), middleware.AuthMiddleware(&[]string{}), h.Stream)
+	router.Post("/broadcast", middleware.AuthMiddleware(&[]string{"admin", "superadmin"}), h.Broadcast)
+}
+
+// @Summary Event stream
+// @Description Server-Sent Events stream of notifications for the authenticated user (job.status, registration.created, notice). Each event carries an id, reconnect with the Last-Event-ID header (or last_event_id query) to receive missed events. EventSource clients that cannot send headers may pass the token as access_token query.
+// @Tags Events
+// @Produce text/event-stream
+// @Param Last-Event-ID header string false "Last received event ID"
+// @Param last_event_id query string false "Last received event ID, alternative to the header"
+// @Param access_token query string false "JWT for clients that cannot set the Authorization header"
+// @Security BearerAuth
+// @Success 200 {object} events.Event
+// @Router /api/v1/events [get]
+func (h *Handler) Stream(ctx *fiber.Ctx) error {
+	userID, _ := ctx.Locals("user_id").(string)
+	role, _ := ctx.Locals("role").(string)
+	lastID := ctx.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = ctx.Query("last_event_id")
+	}
+
+	// Subscribe sebelum replay supaya tidak ada event yang terlewat di antaranya,
+	// duplikat dibuang dengan membandingkan id
+	sub := h.svc.Hub.Subscribe(userID, role)
+
+	var replay []events.Event
+	if lastID != "" {
+		var err error
+		replay, err = h.svc.HandleReplay(ctx.Context(), lastID, userID, role)
+		if err != nil {
+			h.svc.Hub.Unsubscribe(sub)
+			return response.Error(ctx, "REPLAY_EVENTS_FAILED", err)
+		}
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/event-stream")
+	ctx.Set(fiber.HeaderCacheControl, "no-cache")
+	ctx.Set(fiber.HeaderConnection, "keep-alive")
+	ctx.Set("X-Accel-Buffering", "no")
+
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer h.svc.Hub.Unsubscribe(sub)
+
+		// event live yang id-nya <= event replay terakhir sudah terkirim lewat replay.
+		// Event live tidak dibandingkan satu sama lain: urutan sampainya tidak selalu urut id.
+		replayed := lastID
+		fmt.Fprintf(w, "retry: %d\n\n", retryInterval)
+		for _, e := range replay {
+			writeEvent(w, e)
+			replayed = e.ID
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case e := <-sub.C:
+				if replayed != "" && !events.After(e.ID, replayed) {
+					continue
+				}
+				writeEvent(w, e)
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": ping\n\n")
+			case <-sub.Lagged():
+				// client tertinggal, tutup supaya reconnect dengan Last-Event-ID
+				return
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// @Summary Broadcast notice
+// @Description Send a notice event to every connected user, or only to the given roles / user
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param request body dto.BroadcastRequest true "Notice"
+// @Security BearerAuth
+// @Success 200 {object} events.Event
+// @Router /api/v1/events/broadcast [post]
+func (h *Handler) Broadcast(ctx *fiber.Ctx) error {
+	var req dto.BroadcastRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	data, err := h.svc.HandleBroadcast(ctx.Context(), req)
+	if err != nil {
+		return response.Error(ctx, "BROADCAST_NOTICE_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+func writeEvent(w *bufio.Writer, e events.Event) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+}
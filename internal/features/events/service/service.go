@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+
+	"template-golang/internal/features/base"
+	"template-golang/internal/features/events/dto"
+	"template-golang/pkg/events"
+)
+
+// replayLimit event maksimal yang dikirim ulang saat reconnect
+const replayLimit = 500
+
+type Service struct {
+	*base.BaseService
+	Hub *events.Hub
+}
+
+// NewHub hub event instance ini, dijalankan & dihentikan oleh internal.App
+func NewHub(baseService *base.BaseService) *events.Hub {
+	return events.NewHub(baseService.Redis)
+}
+
+func NewService(baseService *base.BaseService, hub *events.Hub) *Service {
+	return &Service{
+		BaseService: baseService,
+		Hub:         hub,
+	}
+}
+
+// HandleReplay event setelah lastEventID yang boleh dilihat user
+func (s *Service) HandleReplay(ctx context.Context, lastEventID, userID, role string) ([]events.Event, error) {
+	list, err := events.Since(ctx, s.Redis, lastEventID, replayLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]events.Event, 0, len(list))
+	for _, e := range list {
+		if e.VisibleTo(userID, role) {
+			visible = append(visible, e)
+		}
+	}
+	return visible, nil
+}
+
+func (s *Service) HandleBroadcast(ctx context.Context, req dto.BroadcastRequest) (events.Event, error) {
+	sentBy, _ := ctx.Value("user_id").(string)
+	if req.Level == "" {
+		req.Level = "info"
+	}
+
+	return events.Publish(ctx, s.Redis, events.TypeNotice, dto.Notice{
+		Title:   req.Title,
+		Message: req.Message,
+		Level:   req.Level,
+		SentBy:  sentBy,
+	}, events.Audience{UserID: req.UserID, Roles: req.Roles})
+}
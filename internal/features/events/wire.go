@@ -0,0 +1,14 @@
+package events
+
+import (
+	"template-golang/internal/features/events/handler"
+	"template-golang/internal/features/events/service"
+
+	"github.com/google/wire"
+)
+
+var Set = wire.NewSet(
+	service.NewHub,
+	service.NewService,
+	handler.NewHandler,
+)
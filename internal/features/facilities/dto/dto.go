@@ -0,0 +1,95 @@
+package dto
+
+import (
+	"mime/multipart"
+	"time"
+
+	"template-golang/internal/db/model"
+)
+
+// CreateFacilityRequest represents the create facility request (multipart form)
+// @Description Create facility request payload
+type CreateFacilityRequest struct {
+	// @Description Facility name per locale, id_ID is required
+	// @Example {"id_ID":"Laboratorium Komputer","en_US":"Computer Laboratory"}
+	Name model.Translations `form:"name" json:"name" validate:"default_locale,locales" swaggertype:"object,string"`
+	// @Description Facility description per locale
+	// @Example {"id_ID":"Laboratorium dengan 40 unit komputer","en_US":"Laboratory with 40 computers"}
+	Description model.Translations `form:"description" json:"description" validate:"omitempty,locales" swaggertype:"object,string"`
+	// @Description Display order, ascending
+	// @Example 1
+	SortOrder int `form:"sort_order" json:"sort_order"`
+
+	Image     *multipart.FileHeader `form:"-" json:"-" swaggerignore:"true"`
+	ImageName string                `form:"-" json:"image" validate:"required,image"`
+	ImageSize int64                 `form:"-" json:"image_size" validate:"size=5"`
+}
+
+// UpdateFacilityRequest represents the update facility request (multipart form)
+// @Description Update facility request payload
+type UpdateFacilityRequest struct {
+	// @Description Facility name per locale, only the given locales are changed
+	// @Example {"en_US":"Computer Laboratory"}
+	Name model.Translations `form:"name" json:"name,omitempty" validate:"omitempty,locales" swaggertype:"object,string"`
+	// @Description Facility description per locale, an empty string removes the locale
+	// @Example {"en_US":"Laboratory with 40 computers"}
+	Description model.Translations `form:"description" json:"description,omitempty" validate:"omitempty,locales" swaggertype:"object,string"`
+	// @Description Display order, ascending
+	// @Example 1
+	SortOrder *int `form:"sort_order" json:"sort_order,omitempty"`
+
+	Image     *multipart.FileHeader `form:"-" json:"-" swaggerignore:"true"`
+	ImageName string                `form:"-" json:"image" validate:"omitempty,image"`
+	ImageSize int64                 `form:"-" json:"image_size" validate:"size=5"`
+}
+
+// ReorderRequest represents the reorder request data structure
+// @Description Reorder facilities, sort_order follows the position in the list
+type ReorderRequest struct {
+	// @Description Facility IDs in the desired order
+	// @Example ["id1","id2"]
+	IDs []string `json:"ids" validate:"required,min=1"`
+}
+
+// FacilityResponse represents a facility localized to the requested language
+// @Description Localized facility response payload
+type FacilityResponse struct {
+	// @Description Facility ID
+	// @Example clx1abc2d0000qwerty
+	ID string `json:"id"`
+	// @Description Language of the name actually returned, the fallback (id_ID) when the requested language has no translation
+	// @Example id_ID
+	Lang string `json:"lang"`
+	// @Description Facility name
+	// @Example Laboratorium Komputer
+	Name string `json:"name"`
+	// @Description Facility description
+	// @Example Laboratorium dengan 40 unit komputer
+	Description *string `json:"description"`
+	// @Description Facility image URL
+	// @Example https://is3.cloudhost.id/uts/facilities/1758074703488556600.webp
+	ImageURL string `json:"image_url"`
+	// @Description Display order
+	// @Example 1
+	SortOrder int `json:"sort_order"`
+	// @Description Publication timestamp
+	// @Example 2024-03-15T10:00:00Z
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// NewFacilityResponse builds a facility response in the given locale
+func NewFacilityResponse(facility model.Facility, lang string) FacilityResponse {
+	name, used := facility.Name.Resolve(lang)
+	if used == "" {
+		used = lang
+	}
+	return FacilityResponse{
+		ID:          facility.ID,
+		Lang:        used,
+		Name:        name,
+		Description: facility.Description.GetPtr(lang),
+		ImageURL:    facility.ImageURL,
+		SortOrder:   facility.SortOrder,
+		PublishedAt: facility.PublishedAt,
+	}
+}
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"template-golang/internal/features/facilities/dto"
+	"template-golang/internal/features/facilities/service"
+	"template-golang/pkg/middleware"
+	"template-golang/pkg/response"
+	"template-golang/pkg/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	svc *service.Service
+}
+
+func NewHandler(svc *service.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) RegisterRoutes(r fiber.Router) {
+	admin := middleware.AuthMiddleware(&[]string{"admin", "superadmin"})
+
+	router := r.Group("/facilities")
+	router.Get("/", h.PublicList)
+	router.Get("/all", admin, h.ListFacilities)
+	router.Put("/order", admin, h.Reorder)
+	router.Get("/:id", h.PublicShow)
+	router.Post("/", admin, h.Store)
+	router.Put("/:id", admin, h.UpdateFacility)
+	router.Patch("/:id/publish", admin, h.Publish)
+	router.Patch("/:id/unpublish", admin, h.Unpublish)
+	router.Delete("/:id", admin, h.DeleteFacility)
+}
+
+// @Summary List published facilities
+// @Description Public list of published facilities, cached
+// @Tags Facilities
+// @Accept json
+// @Produce json
+// @Param lang query string false "Language (id_ID, en_US), overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred language"
+// @Success 200 {array} dto.FacilityResponse
+// @Router /api/v1/facilities [get]
+func (h *Handler) PublicList(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandlePublicList(ctx.Context())
+	if err != nil {
+		return response.Error(ctx, "FETCH_FACILITIES_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Get published facility
+// @Description Public details of a published facility
+// @Tags Facilities
+// @Accept json
+// @Produce json
+// @Param id path string true "Facility ID"
+// @Param lang query string false "Language (id_ID, en_US), overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred language"
+// @Success 200 {object} dto.FacilityResponse
+// @Router /api/v1/facilities/{id} [get]
+func (h *Handler) PublicShow(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	data, err := h.svc.HandlePublicShow(ctx.Context(), id)
+	if err != nil {
+		return response.Error(ctx, "FETCH_FACILITY_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary List all facilities
+// @Description List all facilities including unpublished ones
+// @Tags Facilities
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.Facility
+// @Router /api/v1/facilities/all [get]
+func (h *Handler) ListFacilities(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleList(ctx.Context())
+	if err != nil {
+		return response.Error(ctx, "FETCH_FACILITIES_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Store new facility
+// @Description Store a new facility with an image
+// @Tags Facilities
+// @Accept mpfd
+// @Produce json
+// @Param name formData string true "Facility name, JSON object per locale (id_ID required, en_US optional) or plain id_ID text"
+// @Param description formData string false "Facility description, JSON object per locale or plain id_ID text"
+// @Param sort_order formData int false "Display order"
+// @Param image formData file true "Facility image (jpg, jpeg, png, max 5 MB)"
+// @Security BearerAuth
+// @Success 200 {object} model.Facility
+// @Router /api/v1/facilities/ [post]
+func (h *Handler) Store(ctx *fiber.Ctx) error {
+	var req dto.CreateFacilityRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
+	}
+	if image, err := ctx.FormFile("image"); err == nil {
+		req.Image, req.ImageName, req.ImageSize = image, image.Filename, image.Size
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	data, err := h.svc.HandleCreate(ctx.Context(), req)
+	if err != nil {
+		return response.Error(ctx, "CREATE_FACILITY_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Update facility
+// @Description Update a facility, an uploaded image replaces the previous one
+// @Tags Facilities
+// @Accept mpfd
+// @Produce json
+// @Param id path string true "Facility ID"
+// @Param name formData string false "Facility name, JSON object with the locales to change"
+// @Param description formData string false "Facility description, JSON object with the locales to change (empty string removes a locale)"
+// @Param sort_order formData int false "Display order"
+// @Param image formData file false "Facility image (jpg, jpeg, png, max 5 MB)"
+// @Security BearerAuth
+// @Success 200 {object} model.Facility
+// @Router /api/v1/facilities/{id} [put]
+func (h *Handler) UpdateFacility(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	var req dto.UpdateFacilityRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
+	}
+	if image, err := ctx.FormFile("image"); err == nil {
+		req.Image, req.ImageName, req.ImageSize = image, image.Filename, image.Size
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	data, err := h.svc.HandleUpdate(ctx.Context(), id, req)
+	if err != nil {
+		return response.Error(ctx, "UPDATE_FACILITY_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Publish facility
+// @Description Make a facility visible on the public list
+// @Tags Facilities
+// @Accept json
+// @Produce json
+// @Param id path string true "Facility ID"
+// @Security BearerAuth
+// @Success 200 {object} model.Facility
+// @Router /api/v1/facilities/{id}/publish [patch]
+func (h *Handler) Publish(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleSetPublished(ctx.Context(), ctx.Params("id"), true)
+	if err != nil {
+		return response.Error(ctx, "PUBLISH_FACILITY_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Unpublish facility
+// @Description Hide a facility from the public list
+// @Tags Facilities
+// @Accept json
+// @Produce json
+// @Param id path string true "Facility ID"
+// @Security BearerAuth
+// @Success 200 {object} model.Facility
+// @Router /api/v1/facilities/{id}/unpublish [patch]
+func (h *Handler) Unpublish(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleSetPublished(ctx.Context(), ctx.Params("id"), false)
+	if err != nil {
+		return response.Error(ctx, "UNPUBLISH_FACILITY_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Reorder facilities
+// @Description Set the display order of facilities
+// @Tags Facilities
+// @Accept json
+// @Produce json
+// @Param body body dto.ReorderRequest true "Facility IDs in order"
+// @Security BearerAuth
+// @Success 200 {array} model.Facility
+// @Router /api/v1/facilities/order [put]
+func (h *Handler) Reorder(ctx *fiber.Ctx) error {
+	var req dto.ReorderRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	data, err := h.svc.HandleReorder(ctx.Context(), req)
+	if err != nil {
+		return response.Error(ctx, "REORDER_FACILITIES_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Delete facility
+// @Description Delete an existing facility
+// @Tags Facilities
+// @Accept json
+// @Produce json
+// @Param id path string true "Facility ID"
+// @Security BearerAuth
+// @Success 200 {object} model.Facility
+// @Router /api/v1/facilities/{id} [delete]
+func (h *Handler) DeleteFacility(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	data, err := h.svc.HandleDelete(ctx.Context(), id)
+	if err != nil {
+		return response.Error(ctx, "DELETE_FACILITY_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"template-golang/internal/db/model"
+	"template-golang/internal/features/base"
+	"template-golang/internal/features/facilities/dto"
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/locale"
+	"template-golang/pkg/logger"
+
+	"github.com/goccy/go-json"
+	"gorm.io/gorm"
+)
+
+const (
+	folder        = "facilities"
+	cacheKey      = "facilities:public"
+	cachePattern  = "facilities:*"
+	cacheDuration = 10 * time.Minute
+)
+
+type Service struct {
+	*base.BaseService
+}
+
+func NewService(baseService *base.BaseService) *Service {
+	return &Service{
+		BaseService: baseService,
+	}
+}
+
+// forgetCache hapus cache list publik setelah data berubah
+func (s *Service) forgetCache(ctx context.Context) {
+	if err := s.Redis.DelByPattern(ctx, cachePattern); err != nil {
+		logger.L().Warnf("facilities: failed to clear cache: %v", err)
+	}
+}
+
+// publishedFacilities list fasilitas terbit, cache menyimpan semua locale
+func (s *Service) publishedFacilities(ctx context.Context) ([]model.Facility, error) {
+	if cached, err := s.Redis.Get(ctx, cacheKey); err == nil {
+		var facilities []model.Facility
+		if err := json.Unmarshal([]byte(cached), &facilities); err == nil {
+			return facilities, nil
+		}
+	}
+
+	var facilities []model.Facility
+	err := s.DB().Where("is_published = ?", true).Order("sort_order ASC, created_at DESC").Find(&facilities).Error
+	if err != nil {
+		return []model.Facility{}, err
+	}
+
+	if err := s.Redis.Set(ctx, cacheKey, facilities, cacheDuration); err != nil {
+		logger.L().Warnf("facilities: failed to cache list: %v", err)
+	}
+	return facilities, nil
+}
+
+func (s *Service) HandlePublicList(ctx context.Context) ([]dto.FacilityResponse, error) {
+	facilities, err := s.publishedFacilities(ctx)
+	if err != nil {
+		return []dto.FacilityResponse{}, err
+	}
+
+	lang := locale.FromContext(ctx)
+	response := make([]dto.FacilityResponse, 0, len(facilities))
+	for _, facility := range facilities {
+		response = append(response, dto.NewFacilityResponse(facility, lang))
+	}
+	return response, nil
+}
+
+func (s *Service) HandlePublicShow(ctx context.Context, id string) (dto.FacilityResponse, error) {
+	var facility model.Facility
+	err := s.DB().First(&facility, "id = ? AND is_published = ?", id, true).Error
+	if err != nil {
+		return dto.FacilityResponse{}, err
+	}
+	return dto.NewFacilityResponse(facility, locale.FromContext(ctx)), nil
+}
+
+func (s *Service) HandleList(ctx context.Context) ([]model.Facility, error) {
+	var facilities []model.Facility
+	err := s.DB().Preload("Attachments.File").Order("sort_order ASC, created_at DESC").Find(&facilities).Error
+	if err != nil {
+		return []model.Facility{}, err
+	}
+	return facilities, nil
+}
+
+func (s *Service) HandleCreate(ctx context.Context, req dto.CreateFacilityRequest) (model.Facility, error) {
+	facilityAny, err := s.InTx(ctx, func(tx *gorm.DB) (any, error) {
+		image, err := s.EnqueueUpload(ctx, tx, folder, req.Image, true, nil)
+		if err != nil {
+			return model.Facility{}, err
+		}
+
+		facility := model.Facility{
+			Name:        req.Name,
+			Description: model.Translations{}.Merge(req.Description),
+			ImageURL:    image.URL,
+			SortOrder:   req.SortOrder,
+		}
+		if err := tx.Create(&facility).Error; err != nil {
+			return model.Facility{}, err
+		}
+		attached, err := s.AttachFiles(ctx, tx, facility.TableName(), facility.ID, map[string]model.File{"image": image})
+		if err != nil {
+			return model.Facility{}, err
+		}
+		facility.Attachments = attached
+		return facility, nil
+	})
+	if err != nil {
+		return model.Facility{}, apperror.NewT("CREATE_FACILITY_FAILED", 400, err, nil).WithStack(req.Name.Get(locale.Default))
+	}
+	s.forgetCache(ctx)
+	return facilityAny.(model.Facility), nil
+}
+
+func (s *Service) HandleUpdate(ctx context.Context, id string, req dto.UpdateFacilityRequest) (model.Facility, error) {
+	facilityAny, err := s.InTx(ctx, func(tx *gorm.DB) (any, error) {
+		var facility model.Facility
+		if err := tx.First(&facility, "id = ?", id).Error; err != nil {
+			return model.Facility{}, err
+		}
+		if req.Name != nil {
+			facility.Name = facility.Name.Merge(req.Name)
+			if !facility.Name.HasDefault() {
+				return model.Facility{}, errors.New("name must keep a " + locale.Default + " translation")
+			}
+		}
+		if req.Description != nil {
+			facility.Description = facility.Description.Merge(req.Description)
+		}
+		if req.SortOrder != nil {
+			facility.SortOrder = *req.SortOrder
+		}
+		attachments := map[string]model.File{}
+		if req.Image != nil {
+			image, err := s.EnqueueUpload(ctx, tx, folder, req.Image, true, &facility.ImageURL)
+			if err != nil {
+				return model.Facility{}, err
+			}
+			facility.ImageURL = image.URL
+			attachments["image"] = image
+		}
+		if err := tx.Save(&facility).Error; err != nil {
+			return model.Facility{}, err
+		}
+		attached, err := s.AttachFiles(ctx, tx, facility.TableName(), facility.ID, attachments)
+		if err != nil {
+			return model.Facility{}, err
+		}
+		facility.Attachments = attached
+		return facility, nil
+	})
+	if err != nil {
+		return model.Facility{}, apperror.NewT("UPDATE_FACILITY_FAILED", 400, err, nil).WithStack(id)
+	}
+	s.forgetCache(ctx)
+	return facilityAny.(model.Facility), nil
+}
+
+func (s *Service) HandleSetPublished(ctx context.Context, id string, published bool) (model.Facility, error) {
+	facilityAny, err := s.InTx(ctx, func(tx *gorm.DB) (any, error) {
+		var facility model.Facility
+		if err := tx.First(&facility, "id = ?", id).Error; err != nil {
+			return model.Facility{}, err
+		}
+		facility.IsPublished = published
+		facility.PublishedAt = nil
+		if published {
+			now := time.Now()
+			facility.PublishedAt = &now
+		}
+		if err := tx.Save(&facility).Error; err != nil {
+			return model.Facility{}, err
+		}
+		return facility, nil
+	})
+	if err != nil {
+		return model.Facility{}, apperror.NewT("CHANGE_FACILITY_PUBLICATION_FAILED", 400, err, nil).WithStack(id)
+	}
+	s.forgetCache(ctx)
+	return facilityAny.(model.Facility), nil
+}
+
+func (s *Service) HandleReorder(ctx context.Context, req dto.ReorderRequest) ([]model.Facility, error) {
+	err := s.InTxVoid(ctx, func(tx *gorm.DB) error {
+		for i, id := range req.IDs {
+			res := tx.Model(&model.Facility{}).Where("id = ?", id).Update("sort_order", i+1)
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return []model.Facility{}, apperror.NewT("REORDER_FACILITIES_FAILED", 400, err, nil).WithStack(strings.Join(req.IDs, ","))
+	}
+	s.forgetCache(ctx)
+	return s.HandleList(ctx)
+}
+
+func (s *Service) HandleDelete(ctx context.Context, id string) (model.Facility, error) {
+	var facility model.Facility
+	err := s.DB().First(&facility, "id = ?", id).Error
+	if err != nil {
+		return model.Facility{}, err
+	}
+	// file yang tidak dipakai model lain ikut dihapus dari storage
+	err = s.InTxVoid(ctx, func(tx *gorm.DB) error {
+		if err := tx.Delete(&facility).Error; err != nil {
+			return err
+		}
+		return s.DetachAll(ctx, tx, facility.TableName(), facility.ID)
+	})
+	if err != nil {
+		return model.Facility{}, err
+	}
+	s.forgetCache(ctx)
+	return facility, nil
+}
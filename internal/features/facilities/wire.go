@@ -0,0 +1,13 @@
+package facilities
+
+import (
+	"template-golang/internal/features/facilities/handler"
+	"template-golang/internal/features/facilities/service"
+
+	"github.com/google/wire"
+)
+
+var Set = wire.NewSet(
+	service.NewService,
+	handler.NewHandler,
+)
@@ -0,0 +1,10 @@
+package dto
+
+import "time"
+
+// SignedURLResponse URL download sementara
+type SignedURLResponse struct {
+	URL string `json:"url"`
+	// @Description Expiry of the signed URL, empty for public files
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+
+	"template-golang/internal/features/files/service"
+	"template-golang/pkg/middleware"
+	"template-golang/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	svc *service.Service
+}
+
+func NewHandler(svc *service.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) RegisterRoutes(r fiber.Router) {
+	router := r.Group("/files", middleware.AuthMiddleware(&[]string{}))
+	router.Get("/:id", h.Show)
+	router.Get("/:id/download", h.Download)
+	router.Get("/:id/signed-url", h.SignedURL)
+}
+
+// @Summary Get file
+// @Description File metadata (storage key, size, mime type, checksum, uploader, visibility, status) with its URL and variant URLs. Upload endpoints return the file ID. Private files are only visible to the uploader and admins.
+// @Tags Files
+// @Accept json
+// @Produce json
+// @Param id path string true "File ID"
+// @Security BearerAuth
+// @Success 200 {object} model.File
+// @Router /api/v1/files/{id} [get]
+func (h *Handler) Show(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleShow(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return response.Error(ctx, "FETCH_FILE_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Download file
+// @Description Streams the file through the API. Private files are only downloadable by the uploader and admins. Supports a single byte range (Range: bytes=0-1023) and sends the original file name in Content-Disposition.
+// @Tags Files
+// @Produce octet-stream
+// @Param id path string true "File ID"
+// @Param variant query string false "Variant name, e.g. thumbnail or 256"
+// @Param disposition query string false "attachment (default) or inline"
+// @Param Range header string false "Byte range, e.g. bytes=0-1023"
+// @Security BearerAuth
+// @Success 200 {file} file
+// @Success 206 {file} file
+// @Failure 416 {string} string "Range not satisfiable"
+// @Router /api/v1/files/{id}/download [get]
+func (h *Handler) Download(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleDownload(ctx.Context(), ctx.Params("id"), ctx.Query("variant"), ctx.Get(fiber.HeaderRange))
+	if errors.Is(err, service.ErrRangeNotSatisfiable) {
+		ctx.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes */%d", data.Size))
+		return ctx.SendStatus(fiber.StatusRequestedRangeNotSatisfiable)
+	}
+	if err != nil {
+		return response.Error(ctx, "DOWNLOAD_FILE_FAILED", err)
+	}
+
+	ctx.Set(fiber.HeaderContentType, data.ContentType)
+	ctx.Set(fiber.HeaderContentDisposition, data.ContentDisposition(ctx.Query("disposition") == "inline"))
+	ctx.Set(fiber.HeaderAcceptRanges, "bytes")
+	ctx.Set(fiber.HeaderCacheControl, "private, no-cache")
+	if data.ETag != "" {
+		ctx.Set(fiber.HeaderETag, data.ETag)
+	}
+	if data.Partial {
+		ctx.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes %d-%d/%d", data.Start, data.End, data.Size))
+		ctx.Status(fiber.StatusPartialContent)
+	}
+	return ctx.SendStream(data.Body, int(data.End-data.Start+1))
+}
+
+// @Summary Get signed download URL
+// @Description Returns a URL to download the file straight from storage: the public URL for public files, or a presigned URL valid for 15 minutes for private files. Drivers without signed URLs (local, memory) respond 501, use the download endpoint instead.
+// @Tags Files
+// @Accept json
+// @Produce json
+// @Param id path string true "File ID"
+// @Param variant query string false "Variant name, e.g. thumbnail or 256"
+// @Param disposition query string false "attachment (default) or inline"
+// @Security BearerAuth
+// @Success 200 {object} dto.SignedURLResponse
+// @Router /api/v1/files/{id}/signed-url [get]
+func (h *Handler) SignedURL(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleSignedURL(ctx.Context(), ctx.Params("id"), ctx.Query("variant"), ctx.Query("disposition") == "inline")
+	if err != nil {
+		return response.Error(ctx, "SIGN_FILE_URL_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+)
+
+// parseRange rentang byte dari header Range (RFC 9110) untuk file sebesar size. Hanya satu
+// rentang yang didukung: header kosong, bukan "bytes", tidak valid atau berisi beberapa
+// rentang dikirim utuh (partial false). Rentang yang dimulai di luar file ErrRangeNotSatisfiable.
+func parseRange(header string, size int64) (start, end int64, partial bool, err error) {
+	full := func() (int64, int64, bool, error) { return 0, size - 1, false, nil }
+
+	spec, ok := strings.CutPrefix(strings.TrimSpace(header), "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return full()
+	}
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return full()
+	}
+
+	// "bytes=-N": N byte terakhir
+	if first == "" {
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n < 0 {
+			return full()
+		}
+		if n == 0 || size == 0 {
+			return 0, 0, false, ErrRangeNotSatisfiable
+		}
+		return max(size-n, 0), size - 1, true, nil
+	}
+
+	start, err = strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return full()
+	}
+	end = size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return full()
+		}
+	}
+	if start >= size {
+		return 0, 0, false, ErrRangeNotSatisfiable
+	}
+	return start, min(end, size-1), true, nil
+}
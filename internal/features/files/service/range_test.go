@@ -0,0 +1,58 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		size        int64
+		start, end  int64
+		partial     bool
+		unsatisfied bool
+	}{
+		{name: "no header", header: "", size: 100, start: 0, end: 99},
+		{name: "first bytes", header: "bytes=0-9", size: 100, start: 0, end: 9, partial: true},
+		{name: "middle", header: "bytes=10-19", size: 100, start: 10, end: 19, partial: true},
+		{name: "open end", header: "bytes=90-", size: 100, start: 90, end: 99, partial: true},
+		{name: "end past size is clamped", header: "bytes=90-500", size: 100, start: 90, end: 99, partial: true},
+		{name: "suffix", header: "bytes=-10", size: 100, start: 90, end: 99, partial: true},
+		{name: "suffix larger than file", header: "bytes=-500", size: 100, start: 0, end: 99, partial: true},
+		{name: "whitespace", header: " bytes= 5-6 ", size: 100, start: 5, end: 6, partial: true},
+		{name: "single byte", header: "bytes=99-99", size: 100, start: 99, end: 99, partial: true},
+
+		// tidak dikenali: file dikirim utuh
+		{name: "other unit", header: "items=0-9", size: 100, start: 0, end: 99},
+		{name: "multiple ranges", header: "bytes=0-9,20-29", size: 100, start: 0, end: 99},
+		{name: "missing dash", header: "bytes=10", size: 100, start: 0, end: 99},
+		{name: "end before start", header: "bytes=20-10", size: 100, start: 0, end: 99},
+		{name: "not a number", header: "bytes=a-b", size: 100, start: 0, end: 99},
+		{name: "negative start", header: "bytes=-5-10", size: 100, start: 0, end: 99},
+
+		{name: "start past size", header: "bytes=100-", size: 100, unsatisfied: true},
+		{name: "empty suffix", header: "bytes=-0", size: 100, unsatisfied: true},
+		{name: "empty file", header: "bytes=-10", size: 0, unsatisfied: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, partial, err := parseRange(tt.header, tt.size)
+			if tt.unsatisfied {
+				if !errors.Is(err, ErrRangeNotSatisfiable) {
+					t.Fatalf("parseRange(%q, %d) error = %v, want ErrRangeNotSatisfiable", tt.header, tt.size, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseRange(%q, %d): %v", tt.header, tt.size, err)
+			}
+			if start != tt.start || end != tt.end || partial != tt.partial {
+				t.Errorf("parseRange(%q, %d) = %d-%d partial=%v, want %d-%d partial=%v",
+					tt.header, tt.size, start, end, partial, tt.start, tt.end, tt.partial)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strings"
+	"time"
+
+	"template-golang/internal/db/model"
+	"template-golang/internal/features/base"
+	"template-golang/internal/features/files/dto"
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/storage"
+
+	"gorm.io/gorm"
+)
+
+// signedURLExpiry masa berlaku URL download bertanda tangan
+const signedURLExpiry = 15 * time.Minute
+
+// ErrRangeNotSatisfiable header Range di luar ukuran file
+var ErrRangeNotSatisfiable = errors.New("range not satisfiable")
+
+type Service struct {
+	*base.BaseService
+}
+
+func NewService(baseService *base.BaseService) *Service {
+	return &Service{
+		BaseService: baseService,
+	}
+}
+
+// Download isi file (atau sebagian, untuk request Range) yang dikirim proxy
+type Download struct {
+	Body        io.ReadCloser
+	ContentType string
+	Filename    string
+	ETag        string
+	// Size ukuran seluruh file, Start - End rentang byte yang dikirim (inklusif)
+	Size    int64
+	Start   int64
+	End     int64
+	Partial bool
+}
+
+// ContentDisposition header Content-Disposition dengan nama file asli
+func (d Download) ContentDisposition(inline bool) string {
+	disposition := "attachment"
+	if inline {
+		disposition = "inline"
+	}
+	return mime.FormatMediaType(disposition, map[string]string{"filename": d.Filename})
+}
+
+// HandleShow metadata file beserta status dan URL variannya. File yang belum ready hanya
+// terlihat oleh pengupload dan admin, misalnya untuk memantau statusnya.
+func (s *Service) HandleShow(ctx context.Context, id string) (model.File, error) {
+	return s.find(ctx, id)
+}
+
+// HandleDownload buka file atau variannya untuk dikirim lewat API. rangeHeader satu rentang
+// "bytes=..." menghasilkan Download.Partial, rentang di luar file ErrRangeNotSatisfiable
+// (Download.Size tetap diisi).
+func (s *Service) HandleDownload(ctx context.Context, id, variant, rangeHeader string) (Download, error) {
+	file, err := s.findReady(ctx, id)
+	if err != nil {
+		return Download{}, err
+	}
+	key, err := variantKey(file, variant)
+	if err != nil {
+		return Download{}, err
+	}
+	obj, err := s.Storage.Stat(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return Download{}, apperror.NotFound("file is not available yet")
+	}
+	if err != nil {
+		return Download{}, err
+	}
+
+	download := Download{
+		ContentType: obj.ContentType,
+		Filename:    downloadName(file, key),
+		Size:        obj.Size,
+	}
+	if download.ContentType == "" {
+		download.ContentType = "application/octet-stream"
+	}
+	if file.Checksum != nil && key == file.StorageKey {
+		download.ETag = `"` + *file.Checksum + `"`
+	}
+
+	download.Start, download.End, download.Partial, err = parseRange(rangeHeader, obj.Size)
+	if err != nil {
+		return download, err
+	}
+	if download.Partial {
+		download.Body, err = s.Storage.GetRange(ctx, key, download.Start, download.End-download.Start+1)
+	} else {
+		download.Body, _, err = s.Storage.Get(ctx, key)
+	}
+	if err != nil {
+		return Download{}, err
+	}
+	return download, nil
+}
+
+// HandleSignedURL URL download langsung dari storage: URL publik untuk file public,
+// URL bertanda tangan yang berlaku signedURLExpiry untuk file private
+func (s *Service) HandleSignedURL(ctx context.Context, id, variant string, inline bool) (dto.SignedURLResponse, error) {
+	file, err := s.findReady(ctx, id)
+	if err != nil {
+		return dto.SignedURLResponse{}, err
+	}
+	key, err := variantKey(file, variant)
+	if err != nil {
+		return dto.SignedURLResponse{}, err
+	}
+	if file.Visibility != model.FileVisibilityPrivate {
+		return dto.SignedURLResponse{URL: s.Storage.URL(key)}, nil
+	}
+
+	signer, ok := s.Storage.(storage.URLSigner)
+	if !ok {
+		return dto.SignedURLResponse{}, apperror.NewT("SIGNED_URL_UNSUPPORTED", 501, storage.ErrSignedURLUnsupported.Error(), nil)
+	}
+	url, err := signer.SignedURL(ctx, key, storage.SignedURLOptions{
+		Expires:            signedURLExpiry,
+		ContentDisposition: Download{Filename: downloadName(file, key)}.ContentDisposition(inline),
+	})
+	if err != nil {
+		return dto.SignedURLResponse{}, apperror.NewT("SIGN_URL_FAILED", 500, err, nil).WithStack(id)
+	}
+	expiresAt := time.Now().Add(signedURLExpiry).UTC()
+	return dto.SignedURLResponse{URL: url, ExpiresAt: &expiresAt}, nil
+}
+
+// find file yang boleh dibaca user: file public yang sudah ready semua user login, file
+// private dan file yang belum ready (pending, failed, rejected) hanya pengupload dan admin.
+// File lain dianggap tidak ada.
+func (s *Service) find(ctx context.Context, id string) (model.File, error) {
+	var file model.File
+	err := s.DB().WithContext(ctx).First(&file, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.File{}, apperror.NotFound("file not found")
+	}
+	if err != nil {
+		return model.File{}, err
+	}
+
+	restricted := file.Visibility == model.FileVisibilityPrivate || file.Status != model.FileStatusReady
+	if restricted && !isOwnerOrAdmin(ctx, file) {
+		return model.File{}, apperror.NotFound("file not found")
+	}
+	return file, nil
+}
+
+// findReady find untuk membaca isi file: file yang belum ready membalas 409
+func (s *Service) findReady(ctx context.Context, id string) (model.File, error) {
+	file, err := s.find(ctx, id)
+	if err != nil {
+		return model.File{}, err
+	}
+	if file.Status != model.FileStatusReady {
+		return model.File{}, apperror.NewT("FILE_NOT_READY", 409, string(file.Status), nil).WithStack(id)
+	}
+	return file, nil
+}
+
+// isOwnerOrAdmin user request adalah pengupload file, admin atau superadmin
+func isOwnerOrAdmin(ctx context.Context, file model.File) bool {
+	userID, _ := ctx.Value("user_id").(string)
+	role, _ := ctx.Value("role").(string)
+	if role == "admin" || role == "superadmin" {
+		return true
+	}
+	return file.UploadedBy != nil && *file.UploadedBy == userID
+}
+
+// variantKey key file utama (variant kosong) atau varian
+func variantKey(file model.File, variant string) (string, error) {
+	if variant == "" {
+		return file.StorageKey, nil
+	}
+	key, ok := file.Variants[variant]
+	if !ok {
+		return "", apperror.NotFound(fmt.Sprintf("variant %s not found", variant))
+	}
+	return key, nil
+}
+
+// downloadName nama file asli dengan ekstensi key (varian / hasil konversi WebP bisa beda ekstensi)
+func downloadName(file model.File, key string) string {
+	if file.OriginalName == nil || *file.OriginalName == "" {
+		return path.Base(key)
+	}
+	name := *file.OriginalName
+	return strings.TrimSuffix(name, path.Ext(name)) + path.Ext(key)
+}
@@ -0,0 +1,13 @@
+package files
+
+import (
+	"template-golang/internal/features/files/handler"
+	"template-golang/internal/features/files/service"
+
+	"github.com/google/wire"
+)
+
+var Set = wire.NewSet(
+	service.NewService,
+	handler.NewHandler,
+)
@@ -0,0 +1,12 @@
+package dto
+
+// ReplayResponse job yang diantrikan ulang dari dead-letter stream
+type ReplayResponse struct {
+	// @Description IDs of the re-enqueued jobs
+	JobIDs []string `json:"job_ids"`
+}
+
+// PurgeResponse jumlah job yang dihapus dari dead-letter stream
+type PurgeResponse struct {
+	Purged int64 `json:"purged"`
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"template-golang/internal/features/jobs/service"
+	"template-golang/pkg/middleware"
+	"template-golang/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	svc *service.Service
+}
+
+func NewHandler(svc *service.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) RegisterRoutes(r fiber.Router) {
+	superadmin := middleware.AuthMiddleware(&[]string{"superadmin"})
+
+	router := r.Group("/jobs")
+
+	dlq := router.Group("/dlq", superadmin)
+	dlq.Get("/", h.DeadLetterQueues)
+	dlq.Get("/:name", h.ListDead)
+	dlq.Post("/:name/replay", h.ReplayAll)
+	dlq.Delete("/:name", h.Purge)
+	dlq.Get("/:name/:id", h.ShowDead)
+	dlq.Post("/:name/:id/replay", h.Replay)
+	dlq.Delete("/:name/:id", h.PurgeOne)
+
+	router.Get("/:id", middleware.AuthMiddleware(&[]string{}), h.Show)
+}
+
+// @Summary Get job status
+// @Description Status of an enqueued job (queued, running, succeeded, failed) with attempts, progress, result and last error. Users can only see their own jobs, admins see all.
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Security BearerAuth
+// @Success 200 {object} queue.Status
+// @Router /api/v1/jobs/{id} [get]
+func (h *Handler) Show(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleShow(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return response.Error(ctx, "FETCH_JOB_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary List dead-letter queues
+// @Description Dead-letter streams with the number of failed jobs per job name
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} queue.DeadLetterQueue
+// @Router /api/v1/jobs/dlq [get]
+func (h *Handler) DeadLetterQueues(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleDeadLetterQueues(ctx.Context())
+	if err != nil {
+		return response.Error(ctx, "FETCH_DEAD_LETTER_QUEUES_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary List dead jobs
+// @Description Failed jobs in the dead-letter stream of a job name, newest first
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Param name path string true "Job name, e.g. upload"
+// @Param limit query int false "Max jobs returned (default 50)"
+// @Security BearerAuth
+// @Success 200 {array} queue.DeadJob
+// @Router /api/v1/jobs/dlq/{name} [get]
+func (h *Handler) ListDead(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleListDead(ctx.Context(), ctx.Params("name"), int64(ctx.QueryInt("limit", 50)))
+	if err != nil {
+		return response.Error(ctx, "FETCH_DEAD_JOBS_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Get dead job
+// @Description Details of a failed job including its payload and last error
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Param name path string true "Job name"
+// @Param id path string true "Dead-letter message ID"
+// @Security BearerAuth
+// @Success 200 {object} queue.DeadJob
+// @Router /api/v1/jobs/dlq/{name}/{id} [get]
+func (h *Handler) ShowDead(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleShowDead(ctx.Context(), ctx.Params("name"), ctx.Params("id"))
+	if err != nil {
+		return response.Error(ctx, "FETCH_DEAD_JOB_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Replay dead job
+// @Description Re-enqueue a failed job with its original ID and remove it from the dead-letter stream
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Param name path string true "Job name"
+// @Param id path string true "Dead-letter message ID"
+// @Security BearerAuth
+// @Success 200 {object} dto.ReplayResponse
+// @Router /api/v1/jobs/dlq/{name}/{id}/replay [post]
+func (h *Handler) Replay(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleReplay(ctx.Context(), ctx.Params("name"), ctx.Params("id"))
+	if err != nil {
+		return response.Error(ctx, "REPLAY_DEAD_JOB_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Replay all dead jobs
+// @Description Re-enqueue every failed job of a job name
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Param name path string true "Job name"
+// @Security BearerAuth
+// @Success 200 {object} dto.ReplayResponse
+// @Router /api/v1/jobs/dlq/{name}/replay [post]
+func (h *Handler) ReplayAll(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleReplayAll(ctx.Context(), ctx.Params("name"))
+	if err != nil {
+		return response.Error(ctx, "REPLAY_DEAD_JOBS_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Purge dead job
+// @Description Remove a failed job from the dead-letter stream
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Param name path string true "Job name"
+// @Param id path string true "Dead-letter message ID"
+// @Security BearerAuth
+// @Success 200 {object} dto.PurgeResponse
+// @Router /api/v1/jobs/dlq/{name}/{id} [delete]
+func (h *Handler) PurgeOne(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandlePurge(ctx.Context(), ctx.Params("name"), ctx.Params("id"))
+	if err != nil {
+		return response.Error(ctx, "PURGE_DEAD_JOB_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Purge dead-letter queue
+// @Description Remove every failed job of a job name
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Param name path string true "Job name"
+// @Security BearerAuth
+// @Success 200 {object} dto.PurgeResponse
+// @Router /api/v1/jobs/dlq/{name} [delete]
+func (h *Handler) Purge(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandlePurge(ctx.Context(), ctx.Params("name"))
+	if err != nil {
+		return response.Error(ctx, "PURGE_DEAD_LETTER_QUEUE_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
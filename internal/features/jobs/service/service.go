@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"template-golang/internal/features/base"
+	"template-golang/internal/features/jobs/dto"
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/queue"
+)
+
+type Service struct {
+	*base.BaseService
+}
+
+func NewService(baseService *base.BaseService) *Service {
+	return &Service{
+		BaseService: baseService,
+	}
+}
+
+// HandleShow status job, user biasa hanya bisa melihat job miliknya
+func (s *Service) HandleShow(ctx context.Context, id string) (*queue.Status, error) {
+	status, err := s.Queue.GetStatus(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, _ := ctx.Value("user_id").(string)
+	role, _ := ctx.Value("role").(string)
+	if role != "admin" && role != "superadmin" && (status.Owner == "" || status.Owner != userID) {
+		return nil, apperror.NotFound("job not found")
+	}
+	return status, nil
+}
+
+func (s *Service) HandleDeadLetterQueues(ctx context.Context) ([]queue.DeadLetterQueue, error) {
+	return s.Queue.DeadLetterQueues(ctx)
+}
+
+func (s *Service) HandleListDead(ctx context.Context, name string, limit int64) ([]queue.DeadJob, error) {
+	return s.Queue.ListDead(ctx, name, limit)
+}
+
+func (s *Service) HandleShowDead(ctx context.Context, name, id string) (*queue.DeadJob, error) {
+	return s.Queue.GetDead(ctx, name, id)
+}
+
+func (s *Service) HandleReplay(ctx context.Context, name, id string) (dto.ReplayResponse, error) {
+	jobID, err := s.Queue.ReplayDead(ctx, name, id)
+	if err != nil {
+		return dto.ReplayResponse{}, err
+	}
+	return dto.ReplayResponse{JobIDs: []string{jobID}}, nil
+}
+
+func (s *Service) HandleReplayAll(ctx context.Context, name string) (dto.ReplayResponse, error) {
+	jobIDs, err := s.Queue.ReplayAllDead(ctx, name)
+	if err != nil {
+		return dto.ReplayResponse{}, err
+	}
+	return dto.ReplayResponse{JobIDs: jobIDs}, nil
+}
+
+func (s *Service) HandlePurge(ctx context.Context, name string, ids ...string) (dto.PurgeResponse, error) {
+	n, err := s.Queue.PurgeDead(ctx, name, ids...)
+	if err != nil {
+		return dto.PurgeResponse{}, err
+	}
+	return dto.PurgeResponse{Purged: n}, nil
+}
@@ -0,0 +1,13 @@
+package jobs
+
+import (
+	"template-golang/internal/features/jobs/handler"
+	"template-golang/internal/features/jobs/service"
+
+	"github.com/google/wire"
+)
+
+var Set = wire.NewSet(
+	service.NewService,
+	handler.NewHandler,
+)
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"template-golang/internal/db/model"
+	"template-golang/internal/features/realtime/service"
+	"template-golang/pkg/gateway"
+	"template-golang/pkg/middleware"
+	"template-golang/pkg/response"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	svc *service.Service
+}
+
+func NewHandler(svc *service.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) RegisterRoutes(r fiber.Router) {
+	router := r.Group("/ws")
+	router.Get("/", middleware.TokenFromQuery("access_token"), middleware.AuthMiddleware(&[]string{}), h.Upgrade, websocket.New(h.Serve))
+	router.Get("/presence", middleware.AuthMiddleware(&[]string{"admin", "superadmin"}), h.Presence)
+}
+
+// @Summary WebSocket gateway
+// @Description Bidirectional WebSocket. Send JSON frames {"type":"subscribe|unsubscribe|publish|ping","id":"1","channel":"admins","data":{}}; the server replies with ack, error, pong and message frames. Channels: admins, superadmins, presence (online admins, subscribe only), user:<own id> (server to user). Browsers pass the JWT as access_token query.
+// @Tags Realtime
+// @Param access_token query string false "JWT for clients that cannot set the Authorization header"
+// @Security BearerAuth
+// @Success 101 {object} gateway.Frame
+// @Router /api/v1/ws [get]
+func (h *Handler) Upgrade(ctx *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(ctx) {
+		return fiber.ErrUpgradeRequired
+	}
+	return ctx.Next()
+}
+
+func (h *Handler) Serve(c *websocket.Conn) {
+	userID, _ := c.Locals("user_id").(string)
+	role, _ := c.Locals("role").(string)
+	user, _ := c.Locals("user").(model.User)
+
+	h.svc.Gateway.Serve(c, gateway.Identity{UserID: userID, Name: user.Name, Role: role})
+}
+
+// @Summary Online admins
+// @Description Users currently connected to the WebSocket gateway on any instance
+// @Tags Realtime
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} gateway.Presence
+// @Router /api/v1/ws/presence [get]
+func (h *Handler) Presence(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandlePresence(ctx.Context())
+	if err != nil {
+		return response.Error(ctx, "FETCH_PRESENCE_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"template-golang/internal/db/model"
+	"template-golang/internal/features/base"
+	"template-golang/pkg/gateway"
+)
+
+// Channel yang bisa dipakai client WebSocket
+const (
+	// ChannelAdmins obrolan / notifikasi antar admin
+	ChannelAdmins = "admins"
+	// ChannelSuperadmins khusus superadmin
+	ChannelSuperadmins = "superadmins"
+	// userChannelPrefix "user:<id>" pesan dari server ke satu user
+	userChannelPrefix = "user:"
+)
+
+type Service struct {
+	*base.BaseService
+	Gateway *gateway.Gateway
+}
+
+// NewGateway gateway WebSocket dengan aturan channel aplikasi, dijalankan & dihentikan oleh internal.App
+func NewGateway(baseService *base.BaseService) *gateway.Gateway {
+	return gateway.New(baseService.Redis, authorize, isAdmin)
+}
+
+func NewService(baseService *base.BaseService, gw *gateway.Gateway) *Service {
+	return &Service{
+		BaseService: baseService,
+		Gateway:     gw,
+	}
+}
+
+// isAdmin presence hanya mencatat admin dan superadmin
+func isAdmin(id gateway.Identity) bool {
+	return slices.Contains([]string{string(model.RoleAdmin), string(model.RoleSuperAdmin)}, id.Role)
+}
+
+// authorize aturan akses channel WebSocket
+func authorize(id gateway.Identity, channel string, action gateway.Action) bool {
+	switch {
+	case channel == gateway.PresenceChannel:
+		return isAdmin(id) && action == gateway.ActionSubscribe
+	case channel == ChannelAdmins:
+		return isAdmin(id)
+	case channel == ChannelSuperadmins:
+		return id.Role == string(model.RoleSuperAdmin)
+	case strings.HasPrefix(channel, userChannelPrefix):
+		// hanya server yang publish ke channel user
+		return action == gateway.ActionSubscribe && strings.TrimPrefix(channel, userChannelPrefix) == id.UserID
+	}
+	return false
+}
+
+// HandlePresence admin yang sedang online
+func (s *Service) HandlePresence(ctx context.Context) ([]gateway.Presence, error) {
+	return s.Gateway.Online(ctx)
+}
@@ -0,0 +1,14 @@
+package realtime
+
+import (
+	"template-golang/internal/features/realtime/handler"
+	"template-golang/internal/features/realtime/service"
+
+	"github.com/google/wire"
+)
+
+var Set = wire.NewSet(
+	service.NewGateway,
+	service.NewService,
+	handler.NewHandler,
+)
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"template-golang/internal/db/model"
+)
+
+// CreateRegistrationRequest represents the public registration request data structure
+// @Description Student registration request payload
+type CreateRegistrationRequest struct {
+	// @Description Student full name
+	// @Example Budi Santoso
+	FullName string `json:"full_name" validate:"required,max=255"`
+	// @Description Student email address, used to check the registration
+	// @Example budi@example.com
+	Email string `json:"email" validate:"required,email"`
+	// @Description Student phone number
+	// @Example 081234567890
+	Phone string `json:"phone" validate:"required,max=30"`
+	// @Description Student gender (male, female)
+	// @Example male
+	Gender string `json:"gender" validate:"required,oneof=male female"`
+	// @Description Student birth place
+	// @Example Surabaya
+	BirthPlace string `json:"birth_place" validate:"required,max=100"`
+	// @Description Student birth date (YYYY-MM-DD)
+	// @Example 2010-05-17
+	BirthDate string `json:"birth_date" validate:"required,datetime=2006-01-02"`
+	// @Description Student home address
+	// @Example Jl. Merdeka No. 1, Surabaya
+	Address string `json:"address" validate:"required"`
+	// @Description Previous school
+	// @Example SMP Negeri 1 Surabaya
+	SchoolOrigin string `json:"school_origin" validate:"required,max=255"`
+	// @Description Parent or guardian name
+	// @Example Siti Aminah
+	ParentName string `json:"parent_name" validate:"required,max=255"`
+	// @Description Parent or guardian phone number
+	// @Example 081298765432
+	ParentPhone string `json:"parent_phone" validate:"required,max=30"`
+	// @Description Chosen program
+	// @Example IPA
+	Program string `json:"program" validate:"required,max=100"`
+}
+
+// CheckRegistrationRequest represents the public registration check query
+// @Description Registration check query
+type CheckRegistrationRequest struct {
+	// @Description Registration number
+	// @Example REG-2025-000001
+	RegistrationNumber string `query:"registration_number" json:"registration_number" validate:"required"`
+	// @Description Email used when registering
+	// @Example budi@example.com
+	Email string `query:"email" json:"email" validate:"required,email"`
+}
+
+// UpdateRegistrationStatusRequest represents the admin status update request
+// @Description Update registration status request payload
+type UpdateRegistrationStatusRequest struct {
+	// @Description New status (verified, accepted, rejected)
+	// @Example verified
+	Status model.RegistrationStatus `json:"status" validate:"required,oneof=verified accepted rejected"`
+	// @Description Optional note for the registrant
+	// @Example Berkas lengkap
+	Note *string `json:"note,omitempty"`
+}
+
+// RegistrationStatusResponse represents the public registration check response
+// @Description Registration status response payload
+type RegistrationStatusResponse struct {
+	// @Description Registration number
+	// @Example REG-2025-000001
+	RegistrationNumber string `json:"registration_number"`
+	// @Description Student full name
+	// @Example Budi Santoso
+	FullName string `json:"full_name"`
+	// @Description Registration status
+	// @Example submitted
+	Status model.RegistrationStatus `json:"status"`
+	// @Description Status note from admin
+	// @Example Berkas lengkap
+	StatusNote *string `json:"status_note"`
+	// @Description Registration proof PDF download URL
+	// @Example /api/v1/registrations/proof/3f6c1a9e0b7d4c2f8e5a1b3c7d9e0f2a4b6c8d0e1f3a5b7c9d1e3f5a7b9c0d2e
+	ProofURL *string `json:"proof_url"`
+}
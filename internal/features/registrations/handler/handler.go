@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"mime"
+
+	"template-golang/internal/features/registrations/dto"
+	"template-golang/internal/features/registrations/service"
+	"template-golang/pkg/middleware"
+	"template-golang/pkg/response"
+	"template-golang/pkg/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	svc *service.Service
+}
+
+func NewHandler(svc *service.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) RegisterRoutes(r fiber.Router) {
+	router := r.Group("/registrations")
+	router.Post("/", h.Store)
+	router.Get("/check", h.Check)
+	router.Get("/proof/:token", h.DownloadProof)
+	router.Get("/", middleware.AuthMiddleware(&[]string{"admin", "superadmin"}), h.ListRegistrations)
+	router.Get("/:id", middleware.AuthMiddleware(&[]string{"admin", "superadmin"}), h.GetRegistration)
+	router.Patch("/:id/status", middleware.AuthMiddleware(&[]string{"admin", "superadmin"}), h.UpdateStatus)
+}
+
+// @Summary Submit registration
+// @Description Public student registration, a registration proof PDF with QR code is generated and uploaded by the worker
+// @Tags Registrations
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateRegistrationRequest true "Registration data"
+// @Success 200 {object} model.Registration
+// @Router /api/v1/registrations/ [post]
+func (h *Handler) Store(ctx *fiber.Ctx) error {
+	var req dto.CreateRegistrationRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	data, err := h.svc.HandleCreate(ctx.Context(), req)
+	if err != nil {
+		return response.Error(ctx, "SUBMIT_REGISTRATION_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Check registration
+// @Description Check registration status and download the registration proof
+// @Tags Registrations
+// @Accept json
+// @Produce json
+// @Param registration_number query string true "Registration number"
+// @Param email query string true "Email used when registering"
+// @Success 200 {object} dto.RegistrationStatusResponse
+// @Router /api/v1/registrations/check [get]
+func (h *Handler) Check(ctx *fiber.Ctx) error {
+	var req dto.CheckRegistrationRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		return response.Error(ctx, "PARSE_QUERY_FAILED", err)
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	data, err := h.svc.HandleCheck(ctx.Context(), req)
+	if err != nil {
+		return response.Error(ctx, "REGISTRATION_NOT_FOUND", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Download registration proof
+// @Description Download the registration proof PDF using the random token from proof_url. Responds 409 while the worker is still uploading it.
+// @Tags Registrations
+// @Produce application/pdf
+// @Param token path string true "Registration proof token"
+// @Success 200 {file} file
+// @Router /api/v1/registrations/proof/{token} [get]
+func (h *Handler) DownloadProof(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleProof(ctx.Context(), ctx.Params("token"))
+	if err != nil {
+		return response.Error(ctx, "DOWNLOAD_REGISTRATION_PROOF_FAILED", err)
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": data.Filename}))
+	ctx.Set(fiber.HeaderCacheControl, "private, no-store")
+	return ctx.SendStream(data.Body, int(data.Size))
+}
+
+// @Summary List registrations
+// @Description Get list of registrations, optionally filtered by status
+// @Tags Registrations
+// @Accept json
+// @Produce json
+// @Param status query string false "Filter by status (submitted, verified, accepted, rejected)"
+// @Security BearerAuth
+// @Success 200 {array} model.Registration
+// @Router /api/v1/registrations [get]
+func (h *Handler) ListRegistrations(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleList(ctx.Context(), ctx.Query("status"))
+	if err != nil {
+		return response.Error(ctx, "FETCH_REGISTRATIONS_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Get registration details
+// @Description Get details of a specific registration
+// @Tags Registrations
+// @Accept json
+// @Produce json
+// @Param id path string true "Registration ID"
+// @Security BearerAuth
+// @Success 200 {object} model.Registration
+// @Router /api/v1/registrations/{id} [get]
+func (h *Handler) GetRegistration(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	data, err := h.svc.HandleShow(ctx.Context(), id)
+	if err != nil {
+		return response.Error(ctx, "FETCH_REGISTRATION_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Update registration status
+// @Description Move a registration along its lifecycle: submitted -> verified -> accepted, or rejected
+// @Tags Registrations
+// @Accept json
+// @Produce json
+// @Param id path string true "Registration ID"
+// @Param body body dto.UpdateRegistrationStatusRequest true "New status"
+// @Security BearerAuth
+// @Success 200 {object} model.Registration
+// @Router /api/v1/registrations/{id}/status [patch]
+func (h *Handler) UpdateStatus(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	var req dto.UpdateRegistrationStatusRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	data, err := h.svc.HandleUpdateStatus(ctx.Context(), id, req)
+	if err != nil {
+		return response.Error(ctx, "UPDATE_REGISTRATION_STATUS_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"template-golang/internal/db/model"
+	"template-golang/pkg/fileUploader"
+
+	"github.com/go-pdf/fpdf"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+var genderLabels = map[string]string{
+	"male":   "Laki-laki",
+	"female": "Perempuan",
+}
+
+// generateProof membuat PDF bukti pendaftaran di fileUploader.TmpDir dan mengembalikan path-nya
+func generateProof(reg model.Registration) (string, error) {
+	if err := os.MkdirAll(fileUploader.TmpDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create tmp dir: %w", err)
+	}
+
+	qr, err := qrcode.Encode(reg.RegistrationNumber, qrcode.Medium, 256)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate qr code: %w", err)
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("Bukti Pendaftaran "+reg.RegistrationNumber, true)
+	pdf.SetMargins(20, 20, 20)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	// Header + QR code nomor pendaftaran di kanan atas
+	pdf.RegisterImageOptionsReader("qr", fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qr))
+	pdf.ImageOptions("qr", 155, 15, 35, 35, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(130, 10, "BUKTI PENDAFTARAN", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.CellFormat(130, 7, "Nomor Pendaftaran", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(130, 8, reg.RegistrationNumber, "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(130, 6, "Tanggal daftar: "+reg.CreatedAt.Format("02-01-2006 15:04"), "", 1, "L", false, 0, "")
+	pdf.Ln(12)
+
+	rows := [][2]string{
+		{"Nama Lengkap", reg.FullName},
+		{"Jenis Kelamin", genderLabels[reg.Gender]},
+		{"Tempat, Tanggal Lahir", reg.BirthPlace + ", " + reg.BirthDate.Format("02-01-2006")},
+		{"Email", reg.Email},
+		{"No. Telepon", reg.Phone},
+		{"Alamat", reg.Address},
+		{"Asal Sekolah", reg.SchoolOrigin},
+		{"Nama Orang Tua/Wali", reg.ParentName},
+		{"No. Telepon Orang Tua/Wali", reg.ParentPhone},
+		{"Program", reg.Program},
+		{"Status", strings.ToUpper(string(reg.Status))},
+	}
+	for _, row := range rows {
+		y := pdf.GetY()
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(60, 8, tr(row[0]), "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 11)
+		pdf.MultiCell(0, 8, tr(row[1]), "", "L", false)
+		pdf.Line(20, pdf.GetY(), 190, pdf.GetY())
+		if pdf.GetY() < y+8 {
+			pdf.SetY(y + 8)
+		}
+	}
+
+	pdf.Ln(10)
+	pdf.SetFont("Helvetica", "I", 9)
+	pdf.MultiCell(0, 5, tr("Simpan bukti pendaftaran ini. Tunjukkan QR code di atas kepada panitia saat verifikasi berkas."), "", "L", false)
+
+	path := filepath.Join(fileUploader.TmpDir, reg.RegistrationNumber+".pdf")
+	if err := pdf.OutputFileAndClose(path); err != nil {
+		return "", fmt.Errorf("failed to write registration proof: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"template-golang/internal/db/model"
+	"template-golang/internal/features/base"
+	"template-golang/internal/features/registrations/dto"
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/events"
+	"template-golang/pkg/helper"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/storage"
+
+	"github.com/nrednav/cuid2"
+	"gorm.io/gorm"
+)
+
+const (
+	// proofFolder folder storage bukti pendaftaran. Isinya data pribadi, jadi private
+	// dan nama object-nya acak (tidak bisa ditebak dari nomor pendaftaran)
+	proofFolder = storage.PrivatePrefix + "bukti_pendaftaran"
+	// proofField field attachment bukti pendaftaran
+	proofField = "proof"
+)
+
+// Proof isi PDF bukti pendaftaran untuk diunduh pendaftar
+type Proof struct {
+	Body     io.ReadCloser
+	Size     int64
+	Filename string
+}
+
+type Service struct {
+	*base.BaseService
+}
+
+func NewService(baseService *base.BaseService) *Service {
+	return &Service{
+		BaseService: baseService,
+	}
+}
+
+func (s *Service) HandleCreate(ctx context.Context, req dto.CreateRegistrationRequest) (model.Registration, error) {
+	birthDate, err := time.Parse("2006-01-02", req.BirthDate)
+	if err != nil {
+		return model.Registration{}, apperror.NewT("INVALID_BIRTH_DATE", 400, err, nil).WithStack(req.BirthDate)
+	}
+
+	regAny, err := s.InTx(ctx, func(tx *gorm.DB) (any, error) {
+		var seq int64
+		if err := tx.Raw("SELECT nextval('registration_number_seq')").Scan(&seq).Error; err != nil {
+			return model.Registration{}, err
+		}
+
+		reg := model.Registration{
+			RegistrationNumber: fmt.Sprintf("REG-%d-%06d", time.Now().Year(), seq),
+			FullName:           req.FullName,
+			Email:              strings.ToLower(req.Email),
+			Phone:              req.Phone,
+			Gender:             req.Gender,
+			BirthPlace:         req.BirthPlace,
+			BirthDate:          birthDate,
+			Address:            req.Address,
+			SchoolOrigin:       req.SchoolOrigin,
+			ParentName:         req.ParentName,
+			ParentPhone:        req.ParentPhone,
+			Program:            req.Program,
+			Status:             model.RegistrationSubmitted,
+		}
+		if err := tx.Create(&reg).Error; err != nil {
+			return model.Registration{}, err
+		}
+
+		// Generate PDF bukti pendaftaran, dicatat sebagai file private dan diupload
+		// worker setelah commit
+		proofPath, err := generateProof(reg)
+		if err != nil {
+			return model.Registration{}, err
+		}
+
+		upload, err := s.EnqueueGeneratedFile(ctx, tx, proofPath, s.Storage.URL(proofFolder+"/"+cuid2.Generate()+".pdf"))
+		if err != nil {
+			return model.Registration{}, err
+		}
+		if err := s.Attach(ctx, tx, reg.TableName(), reg.ID, proofField, upload.File.ID); err != nil {
+			return model.Registration{}, err
+		}
+
+		// admin mengunduh lewat endpoint files, pendaftar lewat URL bertoken acak
+		// (tanpa email di URL dan tidak bisa ditebak dari nomor pendaftaran)
+		token, err := newProofToken()
+		if err != nil {
+			return model.Registration{}, err
+		}
+		reg.ProofToken = &token
+		reg.ProofURL = helper.StringPtr(proofURL(token))
+		if err := tx.Model(&reg).Updates(map[string]any{"proof_token": reg.ProofToken, "proof_url": reg.ProofURL}).Error; err != nil {
+			return model.Registration{}, err
+		}
+
+		return reg, nil
+	})
+	if err != nil {
+		return model.Registration{}, apperror.NewT("SUBMIT_REGISTRATION_FAILED", 400, err, nil).WithStack(req.Email)
+	}
+
+	reg := regAny.(model.Registration)
+	if _, err := events.Publish(ctx, s.Redis, events.TypeRegistrationCreated, map[string]any{
+		"id":                  reg.ID,
+		"registration_number": reg.RegistrationNumber,
+		"full_name":           reg.FullName,
+		"program":             reg.Program,
+	}, events.ToRoles(string(model.RoleAdmin), string(model.RoleSuperAdmin))); err != nil {
+		logger.L().Warnf("registrations: failed to publish event: %v", err)
+	}
+	return reg, nil
+}
+
+func (s *Service) HandleCheck(ctx context.Context, req dto.CheckRegistrationRequest) (dto.RegistrationStatusResponse, error) {
+	var reg model.Registration
+	err := s.DB().First(&reg, "registration_number = ? AND email = ?", req.RegistrationNumber, strings.ToLower(req.Email)).Error
+	if err != nil {
+		return dto.RegistrationStatusResponse{}, err
+	}
+
+	// proof_url berisi URL bertoken, pendaftaran lama (sebelum bukti jadi file private)
+	// tetap memakai URL publiknya
+	return dto.RegistrationStatusResponse{
+		RegistrationNumber: reg.RegistrationNumber,
+		FullName:           reg.FullName,
+		Status:             reg.Status,
+		StatusNote:         reg.StatusNote,
+		ProofURL:           reg.ProofURL,
+	}, nil
+}
+
+// HandleProof buka PDF bukti pendaftaran lewat token acak per pendaftaran.
+// Error 409 kalau worker belum selesai mengupload.
+func (s *Service) HandleProof(ctx context.Context, token string) (Proof, error) {
+	if token == "" {
+		return Proof{}, gorm.ErrRecordNotFound
+	}
+
+	var reg model.Registration
+	if err := s.DB().First(&reg, "proof_token = ?", token).Error; err != nil {
+		return Proof{}, err
+	}
+
+	file, err := s.FindAttachment(ctx, reg.TableName(), reg.ID, proofField)
+	if err != nil {
+		return Proof{}, err
+	}
+	if file.Status != model.FileStatusReady {
+		return Proof{}, apperror.NewT("REGISTRATION_PROOF_NOT_READY", 409, nil, nil).WithStack(reg.RegistrationNumber)
+	}
+
+	body, obj, err := s.Storage.Get(ctx, file.StorageKey)
+	if err != nil {
+		return Proof{}, apperror.NewT("OPEN_REGISTRATION_PROOF_FAILED", 500, err, nil).WithStack(file.StorageKey)
+	}
+	return Proof{Body: body, Size: obj.Size, Filename: reg.RegistrationNumber + ".pdf"}, nil
+}
+
+// newProofToken token acak 256 bit (hex) untuk URL unduh bukti pendaftaran
+func newProofToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// proofURL path unduh bukti pendaftaran untuk pendaftar
+func proofURL(token string) string {
+	return "/api/v1/registrations/proof/" + token
+}
+
+func (s *Service) HandleList(ctx context.Context, status string) ([]model.Registration, error) {
+	var registrations []model.Registration
+	query := s.DB().Order("created_at DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Find(&registrations).Error; err != nil {
+		return []model.Registration{}, err
+	}
+	return registrations, nil
+}
+
+func (s *Service) HandleShow(ctx context.Context, id string) (model.Registration, error) {
+	var reg model.Registration
+	err := s.DB().First(&reg, "id = ?", id).Error
+	if err != nil {
+		return model.Registration{}, err
+	}
+	return reg, nil
+}
+
+func (s *Service) HandleUpdateStatus(ctx context.Context, id string, req dto.UpdateRegistrationStatusRequest) (model.Registration, error) {
+	regAny, err := s.InTx(ctx, func(tx *gorm.DB) (any, error) {
+		var reg model.Registration
+		if err := tx.First(&reg, "id = ?", id).Error; err != nil {
+			return model.Registration{}, err
+		}
+		if !reg.Status.CanTransitionTo(req.Status) {
+			return model.Registration{}, fmt.Errorf("cannot change status from %s to %s", reg.Status, req.Status)
+		}
+
+		reg.Status = req.Status
+		reg.StatusNote = req.Note
+		if err := tx.Save(&reg).Error; err != nil {
+			return model.Registration{}, err
+		}
+		return reg, nil
+	})
+	if err != nil {
+		return model.Registration{}, apperror.NewT("UPDATE_REGISTRATION_STATUS_FAILED", 400, err, nil).WithStack(id)
+	}
+	return regAny.(model.Registration), nil
+}
@@ -0,0 +1,13 @@
+package registrations
+
+import (
+	"template-golang/internal/features/registrations/handler"
+	"template-golang/internal/features/registrations/service"
+
+	"github.com/google/wire"
+)
+
+var Set = wire.NewSet(
+	service.NewService,
+	handler.NewHandler,
+)
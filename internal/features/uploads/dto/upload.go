@@ -0,0 +1,55 @@
+package dto
+
+import "time"
+
+// PresignRequest represents a direct upload request
+// @Description Presigned upload request payload
+type PresignRequest struct {
+	// @Description Upload purpose: image (jpeg, png, webp up to 10 MB, converted to WebP) or document (pdf up to 50 MB)
+	// @Example image
+	Purpose string `json:"purpose" validate:"required,oneof=image document"`
+	// @Description MIME type of the file, sent as Content-Type when uploading
+	// @Example image/png
+	ContentType string `json:"content_type" validate:"required"`
+	// @Description File size in bytes
+	// @Example 204800
+	Size int64 `json:"size" validate:"required,gt=0"`
+	// @Description put (default, exact size) or post (multipart form with size range policy)
+	// @Example put
+	Method string `json:"method" validate:"omitempty,oneof=put post"`
+	// @Description public (default) or private. Private files (ID scans, registration documents) are only downloadable by the uploader and admins via /files/{id}/download or a signed URL
+	// @Example private
+	Visibility string `json:"visibility" validate:"omitempty,oneof=public private"`
+}
+
+// PresignResponse request yang dikirim client langsung ke storage
+type PresignResponse struct {
+	// @Description Upload ID, used to call complete after the upload finished
+	ID  string `json:"id"`
+	Key string `json:"key"`
+	// @Description PUT or POST
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// @Description Headers that must be sent with the PUT request
+	Headers map[string]string `json:"headers,omitempty"`
+	// @Description Form fields that must precede the "file" field in the POST request
+	Fields    map[string]string `json:"fields,omitempty"`
+	MaxSize   int64             `json:"max_size"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// CompleteResponse hasil verifikasi upload
+type CompleteResponse struct {
+	// @Description Upload ID
+	ID string `json:"id"`
+	// @Description File ID, differs from the upload ID when the same content was uploaded before
+	FileID string `json:"file_id"`
+	Key    string `json:"key"`
+	// @Description Final file URL, available once the processing job succeeded. Private files use the download endpoint.
+	URL string `json:"url"`
+	// @Description Image variant URLs by preset name (thumbnail, card, hero), available once the processing job succeeded
+	Variants map[string]string `json:"variants,omitempty"`
+	// @Description Post-processing job (malware scan, WebP conversion and variants, copy to the final key)
+	JobID  string `json:"job_id,omitempty"`
+	Status string `json:"status"`
+}
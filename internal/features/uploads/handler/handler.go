@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"template-golang/internal/features/uploads/dto"
+	"template-golang/internal/features/uploads/service"
+	"template-golang/pkg/middleware"
+	"template-golang/pkg/response"
+	"template-golang/pkg/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	svc *service.Service
+}
+
+func NewHandler(svc *service.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) RegisterRoutes(r fiber.Router) {
+	router := r.Group("/uploads", middleware.AuthMiddleware(&[]string{}))
+	router.Post("/presign", h.Presign)
+	router.Post("/:id/complete", h.Complete)
+}
+
+// @Summary Presign direct upload
+// @Description Returns a presigned PUT URL (exact size & content type) or POST policy (size range & content type) to upload a file straight to storage without going through the API. Call complete after the upload finished.
+// @Tags Uploads
+// @Accept json
+// @Produce json
+// @Param request body dto.PresignRequest true "Upload"
+// @Security BearerAuth
+// @Success 200 {object} dto.PresignResponse
+// @Router /api/v1/uploads/presign [post]
+func (h *Handler) Presign(ctx *fiber.Ctx) error {
+	var req dto.PresignRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	data, err := h.svc.HandlePresign(ctx.Context(), req)
+	if err != nil {
+		return response.Error(ctx, "PRESIGN_UPLOAD_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Complete direct upload
+// @Description Verifies the uploaded object (size and real content type) and enqueues post-processing: malware scan, WebP conversion for images and copy from the private staging key to the final key. The file is ready once the job succeeded. Rejected files are deleted. Calling it again returns the same result.
+// @Tags Uploads
+// @Accept json
+// @Produce json
+// @Param id path string true "Upload ID"
+// @Security BearerAuth
+// @Success 200 {object} dto.CompleteResponse
+// @Router /api/v1/uploads/{id}/complete [post]
+func (h *Handler) Complete(ctx *fiber.Ctx) error {
+	data, err := h.svc.HandleComplete(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return response.Error(ctx, "COMPLETE_UPLOAD_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
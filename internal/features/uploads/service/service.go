@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"time"
+
+	"template-golang/internal/db/model"
+	"template-golang/internal/features/base"
+	"template-golang/internal/features/uploads/dto"
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/fileUploader"
+	"template-golang/pkg/filecheck"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/queue"
+	"template-golang/pkg/storage"
+
+	"github.com/nrednav/cuid2"
+	"gorm.io/gorm"
+)
+
+const (
+	// presignExpiry masa berlaku URL upload
+	presignExpiry = 15 * time.Minute
+	// uploadTTL lama sesi upload disimpan di Redis "upload:<id>"
+	uploadTTL = 24 * time.Hour
+
+	uploadPrefix = "upload:"
+
+	statusPending   = "pending"
+	statusCompleted = "completed"
+	statusRejected  = "rejected"
+)
+
+// purpose jenis file yang boleh diupload langsung ke storage
+type purpose struct {
+	folder string
+	// contentTypes content type yang diizinkan -> ekstensi file
+	contentTypes map[string]string
+	maxSize      int64
+	// webp dikonversi ke WebP oleh worker setelah complete
+	webp bool
+	// presets varian gambar yang dibuat worker bersamaan dengan konversi
+	presets []string
+}
+
+var purposes = map[string]purpose{
+	"image": {
+		folder:       "images",
+		contentTypes: map[string]string{"image/jpeg": ".jpg", "image/png": ".png", "image/webp": ".webp"},
+		maxSize:      10 * 1024 * 1024,
+		webp:         true,
+		presets:      []string{"thumbnail", "card", "hero"},
+	},
+	"document": {
+		folder:       "documents",
+		contentTypes: map[string]string{"application/pdf": ".pdf"},
+		maxSize:      50 * 1024 * 1024,
+	},
+}
+
+type Service struct {
+	*base.BaseService
+}
+
+func NewService(baseService *base.BaseService) *Service {
+	return &Service{
+		BaseService: baseService,
+	}
+}
+
+// HandlePresign buat sesi upload lalu kembalikan request PUT / POST bertanda tangan
+func (s *Service) HandlePresign(ctx context.Context, req dto.PresignRequest) (dto.PresignResponse, error) {
+	userID, _ := ctx.Value("user_id").(string)
+	p := purposes[req.Purpose]
+
+	ext, ok := p.contentTypes[req.ContentType]
+	if !ok {
+		return dto.PresignResponse{}, apperror.BadRequest(fmt.Sprintf("content type %s is not allowed for %s", req.ContentType, req.Purpose))
+	}
+	if req.Size > p.maxSize {
+		return dto.PresignResponse{}, apperror.BadRequest(fmt.Sprintf("file exceeds maximum size of %d MB", p.maxSize/1024/1024))
+	}
+
+	presigner, ok := s.Storage.(storage.Presigner)
+	if !ok {
+		return dto.PresignResponse{}, apperror.NewT("PRESIGN_UNSUPPORTED", 501, storage.ErrPresignUnsupported.Error(), nil)
+	}
+
+	// client mengupload ke staging private, file baru bisa diakses setelah discan worker
+	id := cuid2.Generate()
+	key := storage.StagingPrefix + id + ext
+	visibility := model.FileVisibilityPublic
+	if req.Visibility == string(model.FileVisibilityPrivate) {
+		visibility = model.FileVisibilityPrivate
+	}
+	opts := storage.PresignOptions{
+		ContentType: req.ContentType,
+		Size:        req.Size,
+		MaxSize:     p.maxSize,
+		Expires:     presignExpiry,
+	}
+
+	var presigned storage.PresignedRequest
+	var err error
+	if req.Method == "post" {
+		presigned, err = presigner.PresignPost(ctx, key, opts)
+	} else {
+		presigned, err = presigner.PresignPut(ctx, key, opts)
+	}
+	if err != nil {
+		return dto.PresignResponse{}, apperror.NewT("PRESIGN_UPLOAD_FAILED", 500, err, nil).WithStack(id)
+	}
+
+	file := model.File{
+		BaseModel:  model.BaseModel{ID: id},
+		StorageKey: key,
+		Bucket:     storage.Bucket(),
+		Size:       req.Size,
+		MimeType:   req.ContentType,
+		Status:     model.FileStatusPending,
+		Visibility: visibility,
+	}
+	if userID != "" {
+		file.UploadedBy = &userID
+	}
+	if err := s.DB().WithContext(ctx).Create(&file).Error; err != nil {
+		return dto.PresignResponse{}, apperror.NewT("CREATE_FILE_FAILED", 500, err, nil).WithStack(id)
+	}
+
+	err = s.Redis.HSet(ctx, uploadPrefix+id, map[string]any{
+		"key":          key,
+		"purpose":      req.Purpose,
+		"content_type": req.ContentType,
+		"size":         req.Size,
+		"method":       presigned.Method,
+		"owner":        userID,
+		"visibility":   string(visibility),
+		"status":       statusPending,
+		"created_at":   time.Now().UTC().Format(time.RFC3339Nano),
+	}, uploadTTL)
+	if err != nil {
+		return dto.PresignResponse{}, err
+	}
+
+	return dto.PresignResponse{
+		ID:        id,
+		Key:       key,
+		Method:    presigned.Method,
+		URL:       presigned.URL,
+		Headers:   presigned.Headers,
+		Fields:    presigned.Fields,
+		MaxSize:   p.maxSize,
+		ExpiresAt: presigned.ExpiresAt,
+	}, nil
+}
+
+// HandleComplete cek object yang diupload client ke staging (ukuran, content type asli dari
+// magic bytes, polyglot), lalu antrikan job yang men-scan malware, mengkonversi gambar ke WebP
+// beserta varian preset-nya dan menyalin file ke key akhir. File yang tidak lolos dihapus.
+// Isi yang sudah pernah diupload (checksum sama) tidak disimpan dua kali, file lama yang dikembalikan.
+func (s *Service) HandleComplete(ctx context.Context, id string) (dto.CompleteResponse, error) {
+	userID, _ := ctx.Value("user_id").(string)
+
+	values, err := s.Redis.HGetAll(ctx, uploadPrefix+id)
+	if err != nil {
+		return dto.CompleteResponse{}, err
+	}
+	if len(values) == 0 || values["owner"] != userID {
+		return dto.CompleteResponse{}, apperror.NotFound("upload not found")
+	}
+
+	p := purposes[values["purpose"]]
+	private := values["visibility"] == string(model.FileVisibilityPrivate)
+	res := dto.CompleteResponse{ID: id, FileID: values["file_id"], Key: values["key"], URL: values["url"], JobID: values["job_id"], Status: values["status"]}
+	switch values["status"] {
+	case statusCompleted:
+		if private {
+			res.URL, res.Variants = privateURLs(res.FileID, p)
+		} else {
+			res.Variants, _ = fileUploader.PresetURLs(res.URL, p.presets)
+		}
+		return res, nil
+	case statusRejected:
+		return dto.CompleteResponse{}, apperror.BadRequest(values["error"])
+	}
+
+	obj, checksum, err := s.verify(ctx, values, p)
+	if err != nil {
+		var appErr *apperror.AppError
+		if !errors.As(err, &appErr) || appErr.StatusCode != 400 {
+			return dto.CompleteResponse{}, err
+		}
+		if err := s.Storage.Delete(ctx, values["key"]); err != nil {
+			logger.L().Warnf("uploads: failed to delete rejected upload %s: %v", values["key"], err)
+		}
+		s.update(ctx, id, map[string]any{"status": statusRejected, "error": appErr.Detail})
+		s.updateFile(ctx, id, map[string]any{"status": model.FileStatusRejected, "error": appErr.Detail})
+		return dto.CompleteResponse{}, err
+	}
+
+	// object staging discan dan diproses worker lalu disalin ke key akhir: content-addressed
+	// untuk file publik, id upload untuk file private karena tidak dipakai bersama
+	ext := path.Ext(res.Key)
+	if p.webp {
+		ext = ".webp"
+	}
+	target := p.folder + "/" + checksum + ext
+	if private {
+		target = storage.PrivatePrefix + p.folder + "/" + id + ext
+	}
+	url := s.Storage.URL(target)
+	jobID := queue.NewJobID()
+	var enqueueErr error
+	fileAny, err := s.InTx(ctx, func(tx *gorm.DB) (any, error) {
+		// isi yang sama sudah pernah diupload: file lama dipakai, file sesi ini dibuang
+		dup, found, err := s.AcquireDuplicate(tx, checksum, target, id)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return dup, tx.Delete(&model.File{}, "id = ?", id).Error
+		}
+
+		// file baru siap (ready) setelah job selesai
+		fields := map[string]any{"storage_key": target, "size": obj.Size, "checksum": checksum}
+		if p.webp {
+			fields["mime_type"] = "image/webp"
+		}
+		if err := tx.Model(&model.File{}).Where("id = ?", id).Updates(fields).Error; err != nil {
+			return nil, err
+		}
+
+		// job diantrikan setelah commit supaya worker selalu membaca key akhir di baris files.
+		// Kalau gagal, sesi tetap pending dan complete bisa dipanggil ulang.
+		return nil, s.AfterCommit(tx, func(ctx context.Context) (err error) {
+			defer func() { enqueueErr = err }()
+			existing, err := s.Queue.Enqueue(ctx, fileUploader.ProcessUploadJob, fileUploader.QueueProcessUpload{
+				Key:              res.Key,
+				FilePath:         url,
+				IsCompressToWebp: p.webp,
+				Presets:          p.presets,
+				FileID:           id,
+			}, queue.JobID(jobID), queue.Owner(userID), queue.IdempotencyKey(uploadPrefix+id))
+			if errors.Is(err, queue.ErrDuplicate) {
+				// sudah diantrikan complete sebelumnya yang gagal menyimpan sesi
+				jobID = existing
+				return nil
+			}
+			return err
+		})
+	})
+	if err != nil {
+		return dto.CompleteResponse{}, err
+	}
+	if enqueueErr != nil {
+		return dto.CompleteResponse{}, apperror.NewT("FILE_ENQUEUE_FAILED", 500, enqueueErr, nil).WithStack(id)
+	}
+
+	res.Status = statusCompleted
+	if dup, ok := fileAny.(model.File); ok {
+		if err := s.Storage.Delete(ctx, values["key"]); err != nil {
+			logger.L().Warnf("uploads: failed to delete duplicate upload %s: %v", values["key"], err)
+		}
+		res.FileID, res.URL = dup.ID, dup.URL
+		if res.Variants, err = fileUploader.PresetURLs(res.URL, p.presets); err != nil {
+			return dto.CompleteResponse{}, err
+		}
+		s.update(ctx, id, map[string]any{"status": res.Status, "url": res.URL, "file_id": res.FileID})
+		return res, nil
+	}
+
+	res.FileID = id
+	res.URL = url
+	res.JobID = jobID
+	if len(p.presets) > 0 {
+		if res.Variants, err = fileUploader.PresetURLs(res.URL, p.presets); err != nil {
+			return dto.CompleteResponse{}, err
+		}
+	}
+	if private {
+		res.URL, res.Variants = privateURLs(res.FileID, p)
+	}
+
+	s.update(ctx, id, map[string]any{"status": res.Status, "url": res.URL, "job_id": res.JobID, "file_id": res.FileID})
+	return res, nil
+}
+
+// verify ukuran dan content type object sesuai sesi upload, isi file dicek filecheck
+// (magic bytes vs ekstensi, polyglot, dimensi gambar) sambil dihitung checksum SHA-256-nya
+func (s *Service) verify(ctx context.Context, values map[string]string, p purpose) (storage.Object, string, error) {
+	obj, err := s.Storage.Stat(ctx, values["key"])
+	if errors.Is(err, storage.ErrNotFound) {
+		return obj, "", apperror.NotFound("file has not been uploaded yet")
+	}
+	if err != nil {
+		return obj, "", err
+	}
+
+	size, _ := strconv.ParseInt(values["size"], 10, 64)
+	switch {
+	case obj.Size == 0 || obj.Size > p.maxSize:
+		return obj, "", apperror.BadRequest(fmt.Sprintf("file size %d bytes is not allowed", obj.Size))
+	case values["method"] == "PUT" && obj.Size != size:
+		return obj, "", apperror.BadRequest(fmt.Sprintf("file size %d bytes does not match declared size %d", obj.Size, size))
+	}
+
+	src, _, err := s.Storage.Get(ctx, values["key"])
+	if err != nil {
+		return obj, "", err
+	}
+	defer src.Close()
+	hash := sha256.New()
+	res, err := filecheck.Inspect(io.TeeReader(src, hash), values["key"])
+	if errors.Is(err, filecheck.ErrRejected) {
+		return obj, "", apperror.BadRequest(err.Error())
+	}
+	if err != nil {
+		return obj, "", err
+	}
+	if res.ContentType != values["content_type"] {
+		return obj, "", apperror.BadRequest(fmt.Sprintf("file content %s does not match %s", res.ContentType, values["content_type"]))
+	}
+	return obj, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// privateURLs URL download file private dan varian preset-nya
+func privateURLs(fileID string, p purpose) (string, map[string]string) {
+	var variants map[string]string
+	if len(p.presets) > 0 {
+		variants = make(map[string]string, len(p.presets))
+		for _, name := range p.presets {
+			variants[name] = model.FileDownloadURL(fileID, name)
+		}
+	}
+	return model.FileDownloadURL(fileID, ""), variants
+}
+
+func (s *Service) update(ctx context.Context, id string, fields map[string]any) {
+	if err := s.Redis.HSet(ctx, uploadPrefix+id, fields, uploadTTL); err != nil {
+		logger.L().Warnf("uploads: failed to update upload %s: %v", id, err)
+	}
+}
+
+// updateFile update baris files milik sesi upload
+func (s *Service) updateFile(ctx context.Context, id string, fields map[string]any) {
+	if err := s.DB().WithContext(ctx).Model(&model.File{}).Where("id = ?", id).Updates(fields).Error; err != nil {
+		logger.L().Warnf("uploads: failed to update file %s: %v", id, err)
+	}
+}
@@ -0,0 +1,13 @@
+package uploads
+
+import (
+	"template-golang/internal/features/uploads/handler"
+	"template-golang/internal/features/uploads/service"
+
+	"github.com/google/wire"
+)
+
+var Set = wire.NewSet(
+	service.NewService,
+	handler.NewHandler,
+)
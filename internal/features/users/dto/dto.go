@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"mime/multipart"
 	"time"
 
 	"template-golang/internal/db/model"
@@ -73,6 +74,41 @@ type UpdateUserRequest struct {
 	Password *string `json:"password,omitempty" validate:"omitempty,min=6"`
 }
 
+// PatchUserRequest represents the patchable user document
+// @Description Patch user document, target of JSON Merge Patch (RFC 7396) and JSON Patch (RFC 6902)
+type PatchUserRequest struct {
+	// @Description User full name
+	// @Example John Doe
+	Name string `json:"name" validate:"required"`
+	// @Description User email address
+	// @Example john.doe@example.com
+	Email string `json:"email" validate:"required,email"`
+	// @Description User learning point ID, null to clear
+	// @Example 123
+	LearningPointId *string `json:"learning_point_id"`
+}
+
+// NewPatchUserRequest builds the patchable document from the current user
+func NewPatchUserRequest(user model.User) PatchUserRequest {
+	return PatchUserRequest{
+		Name:            user.Name,
+		Email:           user.Email,
+		LearningPointId: user.LearningPointID,
+	}
+}
+
+// UploadAvatarRequest represents the avatar upload request (multipart field "avatar")
+// @Description Upload avatar request payload
+type UploadAvatarRequest struct {
+	File *multipart.FileHeader `json:"-" swaggerignore:"true"`
+	// @Description Avatar file name (jpg, jpeg, png)
+	// @Example avatar.png
+	Avatar string `json:"avatar" validate:"required,image"`
+	// @Description Avatar file size in bytes (max 2 MB)
+	// @Example 204800
+	Size int64 `json:"size" validate:"size=2"`
+}
+
 // UserResponse represents the user response data structure
 // @Description User response payload
 type UserResponse struct {
@@ -88,6 +124,15 @@ type UserResponse struct {
 	// @Description User role
 	// @Example admin
 	Role model.UserRole `json:"role"`
+	// @Description Avatar 64px WebP URL
+	// @Example https://is3.cloudhost.id/uts/avatars/1758074703488556600_64.webp
+	Avatar64 *string `json:"avatar_64"`
+	// @Description Avatar 256px WebP URL
+	// @Example https://is3.cloudhost.id/uts/avatars/1758074703488556600_256.webp
+	Avatar256 *string `json:"avatar_256"`
+	// @Description Avatar 512px WebP URL
+	// @Example https://is3.cloudhost.id/uts/avatars/1758074703488556600_512.webp
+	Avatar512 *string `json:"avatar_512"`
 	// @Description User creation timestamp
 	// @Example 2024-03-15T10:00:00Z
 	CreatedAt time.Time `json:"created_at"`
@@ -99,6 +144,18 @@ type UserResponse struct {
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
+// UploadAvatarResponse represents the user after an avatar upload
+// @Description Updated user with the id of the upload job, poll GET /api/v1/jobs/{job_id} for its status
+type UploadAvatarResponse struct {
+	model.User
+	// @Description Upload job ID
+	// @Example tz4a98xxat96iws9zmbrgj3a
+	JobID string `json:"job_id"`
+	// @Description Avatar file ID, variants are listed in the file once the job succeeded
+	// @Example c9v2kd0wq1n4m8x7y6z5a3b1
+	FileID string `json:"file_id"`
+}
+
 // UserListResponse represents a list of users response
 // @Description List of users response payload
 type UserListResponse struct {
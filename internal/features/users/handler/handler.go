@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
 	"template-golang/internal/features/users/dto"
 	"template-golang/internal/features/users/service"
 	"template-golang/pkg/middleware"
+	"template-golang/pkg/patch"
 	"template-golang/pkg/response"
 	"template-golang/pkg/validator"
 )
@@ -22,12 +25,14 @@ func NewHandler(svc *service.Service) *Handler {
 func (h *Handler) RegisterRoutes(r fiber.Router) {
 	router := r.Group("/users")
 	router.Post("/login", h.Login)
-	router.Get("/me", middleware.AuthMiddleware(&[]string{}),h.GetMe)
-	router.Post("/", middleware.AuthMiddleware(&[]string{"superadmin"}),h.Store)
+	router.Get("/me", middleware.AuthMiddleware(&[]string{}), h.GetMe)
+	router.Put("/me/avatar", middleware.AuthMiddleware(&[]string{}), middleware.IdempotencyKey(), h.UploadAvatar)
+	router.Post("/", middleware.AuthMiddleware(&[]string{"superadmin"}), h.Store)
 	router.Get("/", h.ListUsers)
 	router.Get("/:id", h.GetUser)
-	router.Put("/:id", middleware.AuthMiddleware(&[]string{"superadmin"}),h.UpdateUser)
-	router.Delete("/:id", middleware.AuthMiddleware(&[]string{"superadmin"}),h.DeleteUser)
+	router.Put("/:id", middleware.AuthMiddleware(&[]string{"superadmin"}), h.UpdateUser)
+	router.Patch("/:id", middleware.AuthMiddleware(&[]string{"superadmin"}), h.PatchUser)
+	router.Delete("/:id", middleware.AuthMiddleware(&[]string{"superadmin"}), h.DeleteUser)
 }
 
 // @Summary Get current user
@@ -41,11 +46,44 @@ func (h *Handler) RegisterRoutes(r fiber.Router) {
 func (h *Handler) GetMe(ctx *fiber.Ctx) error {
 	data, err := h.svc.HandleMe(ctx.Context())
 	if err != nil {
-		return response.Error(ctx, "Failed to fetch user", err)
+		return response.Error(ctx, "FETCH_USER_FAILED", err)
 	}
 	return response.Success(ctx, data)
 }
 
+// @Summary Upload avatar
+// @Description Upload the authenticated user's avatar, 64/256/512px WebP variants are generated by the worker. Retrying with the same Idempotency-Key returns the first job instead of uploading again.
+// @Tags Users
+// @Accept mpfd
+// @Produce json
+// @Param avatar formData file true "Avatar image (jpg, jpeg, png, max 2 MB)"
+// @Param Idempotency-Key header string false "Client generated key, deduplicates retries for 24 hours"
+// @Security BearerAuth
+// @Success 200 {object} dto.UploadAvatarResponse
+// @Router /api/v1/users/me/avatar [put]
+func (h *Handler) UploadAvatar(ctx *fiber.Ctx) error {
+	file, err := ctx.FormFile("avatar")
+	if err != nil {
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
+	}
+
+	req := dto.UploadAvatarRequest{
+		File:   file,
+		Avatar: file.Filename,
+		Size:   file.Size,
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	data, err := h.svc.HandleUploadAvatar(ctx.Context(), req)
+	if err != nil {
+		return response.Error(ctx, "UPLOAD_AVATAR_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
 // @Summary User login
 // @Description Login for admin and superadmin users
 // @Tags Users
@@ -57,7 +95,7 @@ func (h *Handler) GetMe(ctx *fiber.Ctx) error {
 func (h *Handler) Login(ctx *fiber.Ctx) error {
 	var req dto.LoginRequest
 	if err := ctx.BodyParser(&req); err != nil {
-		return response.Error(ctx, "Failed to parse request body", err)
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
 	}
 
 	if err := validator.ValidateStruct(req); err != nil {
@@ -66,7 +104,7 @@ func (h *Handler) Login(ctx *fiber.Ctx) error {
 
 	data, err := h.svc.HandleLogin(ctx.Context(), req)
 	if err != nil {
-		return response.Error(ctx, "Invalid credentials", err)
+		return response.Error(ctx, "INVALID_CREDENTIALS", err)
 	}
 
 	return response.Success(ctx, data)
@@ -84,7 +122,7 @@ func (h *Handler) Login(ctx *fiber.Ctx) error {
 func (h *Handler) Store(ctx *fiber.Ctx) error {
 	var req dto.CreateUserRequest
 	if err := ctx.BodyParser(&req); err != nil {
-		return response.Error(ctx, "Failed to parse request body", err)
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
 	}
 
 	if err := validator.ValidateStruct(req); err != nil {
@@ -93,7 +131,7 @@ func (h *Handler) Store(ctx *fiber.Ctx) error {
 
 	data, err := h.svc.HandleRegister(ctx.Context(), req)
 	if err != nil {
-		return response.Error(ctx, "Failed to register user", err)
+		return response.Error(ctx, "REGISTER_USER_FAILED", err)
 	}
 
 	return response.Success(ctx, data)
@@ -112,7 +150,7 @@ func (h *Handler) ListUsers(ctx *fiber.Ctx) error {
 
 	data, err := h.svc.HandleList(ctx.Context())
 	if err != nil {
-		return response.Error(ctx, "Failed to fetch users", err)
+		return response.Error(ctx, "FETCH_USERS_FAILED", err)
 	}
 
 	return response.Success(ctx, data)
@@ -131,7 +169,7 @@ func (h *Handler) GetUser(ctx *fiber.Ctx) error {
 
 	data, err := h.svc.HandleShow(ctx.Context(), id)
 	if err != nil {
-		return response.Error(ctx, "Failed to fetch user", err)
+		return response.Error(ctx, "FETCH_USER_FAILED", err)
 	}
 
 	return response.Success(ctx, data)
@@ -152,7 +190,7 @@ func (h *Handler) UpdateUser(ctx *fiber.Ctx) error {
 
 	var req dto.UpdateUserRequest
 	if err := ctx.BodyParser(&req); err != nil {
-		return response.Error(ctx, "Failed to parse request body", err)
+		return response.Error(ctx, "PARSE_REQUEST_BODY_FAILED", err)
 	}
 
 	if err := validator.ValidateStruct(req); err != nil {
@@ -161,7 +199,32 @@ func (h *Handler) UpdateUser(ctx *fiber.Ctx) error {
 
 	data, err := h.svc.HandleUpdate(ctx.Context(), id, req)
 	if err != nil {
-		return response.Error(ctx, "Failed to update user", err)
+		return response.Error(ctx, "UPDATE_USER_FAILED", err)
+	}
+
+	return response.Success(ctx, data)
+}
+
+// @Summary Patch user
+// @Description Partially update a user with JSON Merge Patch (application/merge-patch+json) or JSON Patch (application/json-patch+json)
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param body body dto.PatchUserRequest true "Merge patch document or JSON patch operations"
+// @Security BearerAuth
+// @Success 200 {object} dto.UserResponse
+// @Router /api/v1/users/{id} [patch]
+func (h *Handler) PatchUser(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	data, err := h.svc.HandlePatch(ctx.Context(), id, patch.Parse(ctx))
+	var rejected *patch.Rejected
+	if errors.As(err, &rejected) {
+		return rejected.AppError
+	}
+	if err != nil {
+		return response.Error(ctx, "PATCH_USER_FAILED", err)
 	}
 
 	return response.Success(ctx, data)
@@ -181,7 +244,7 @@ func (h *Handler) DeleteUser(ctx *fiber.Ctx) error {
 
 	user, err := h.svc.HandleDelete(ctx.Context(), id)
 	if err != nil {
-		return response.Error(ctx, "Failed to delete user", err)
+		return response.Error(ctx, "DELETE_USER_FAILED", err)
 	}
 
 	return response.Success(ctx, user)
@@ -2,16 +2,28 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"template-golang/internal/db/model"
 	"template-golang/internal/features/base"
 	"template-golang/internal/features/users/dto"
 	"template-golang/pkg/apperror"
+	"template-golang/pkg/fileUploader"
 	"template-golang/pkg/helper"
 	"template-golang/pkg/pagination"
+	"template-golang/pkg/patch"
+	"template-golang/pkg/queue"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// avatarSizes ukuran varian avatar (px)
+var avatarSizes = []int{64, 256, 512}
+
+// avatarField nama lampiran avatar di tabel attachments
+const avatarField = "avatar"
+
 type Service struct {
 	*base.BaseService
 }
@@ -49,8 +61,6 @@ func (s *Service) HandleRegister(ctx context.Context, req dto.CreateUserRequest)
 			Role:  model.RoleAdmin,
 		}
 
-
-
 		if err := tx.Create(&user).Error; err != nil {
 			return model.User{}, err
 		}
@@ -58,7 +68,7 @@ func (s *Service) HandleRegister(ctx context.Context, req dto.CreateUserRequest)
 		return user, nil
 	})
 	if err != nil {
-		return model.User{}, apperror.New("users", "failed to register user", 400, err, req.Name)
+		return model.User{}, apperror.NewT("REGISTER_USER_FAILED", 400, err, nil).WithStack(req.Name)
 	}
 	return userAny.(model.User), nil
 }
@@ -131,13 +141,11 @@ func (s *Service) HandleCreate(ctx context.Context, req dto.CreateUserRequest) (
 		return user, nil
 	})
 	if err != nil {
-		return model.User{}, apperror.New("users", "failed to create user", 400, err, req.Name)
+		return model.User{}, apperror.NewT("CREATE_USER_FAILED", 400, err, nil).WithStack(req.Name)
 	}
 	return userAny.(model.User), nil
 }
 
-
-
 func (s *Service) HandleGetUserByToken(ctx context.Context) (model.User, error) {
 	userID, err := helper.GetUserIDFromToken(ctx.Value("token").(string))
 	if err != nil {
@@ -169,18 +177,136 @@ func (s *Service) HandleUpdate(ctx context.Context, id string, req dto.UpdateUse
 		return existingUser, nil
 	})
 	if err != nil {
-		return model.User{}, apperror.New("users", "failed to update user", 400, err, id)
+		return model.User{}, apperror.NewT("UPDATE_USER_FAILED", 400, err, nil).WithStack(id)
 	}
 	return userAny.(model.User), nil
 }
 
+// HandlePatch terapkan p ke user yang dibaca dengan FOR UPDATE lalu simpan, dalam satu
+// transaksi. Patch yang ditolak dikembalikan sebagai *patch.Rejected.
+func (s *Service) HandlePatch(ctx context.Context, id string, p patch.Patch) (model.User, error) {
+	var rejected error
+	userAny, err := s.InTx(ctx, func(tx *gorm.DB) (any, error) {
+		var existingUser model.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&existingUser, "id = ?", id).Error; err != nil {
+			return model.User{}, err
+		}
+		req, err := patch.Bind(p, dto.NewPatchUserRequest(existingUser))
+		if err != nil {
+			rejected = err
+			return model.User{}, err
+		}
+		existingUser.Name = req.Name
+		existingUser.Email = req.Email
+		existingUser.LearningPointID = req.LearningPointId
+		if err := tx.Save(&existingUser).Error; err != nil {
+			return model.User{}, err
+		}
+		return existingUser, nil
+	})
+	if rejected != nil {
+		return model.User{}, rejected
+	}
+	if err != nil {
+		return model.User{}, apperror.NewT("PATCH_USER_FAILED", 400, err, nil).WithStack(id)
+	}
+	return userAny.(model.User), nil
+}
+
+func (s *Service) HandleUploadAvatar(ctx context.Context, req dto.UploadAvatarRequest) (dto.UploadAvatarResponse, error) {
+	userID := ctx.Value("user_id").(string)
+
+	// retry dengan Idempotency-Key yang sama: kembalikan job pertama tanpa mengubah avatar lagi
+	jobID, err := s.FindIdempotentJob(ctx, fileUploader.UploadJob)
+	if err != nil {
+		return dto.UploadAvatarResponse{}, apperror.NewT("UPLOAD_AVATAR_FAILED", 400, err, nil).WithStack(userID)
+	}
+	if jobID != "" {
+		return s.avatarResponse(ctx, userID, jobID)
+	}
+
+	var duplicate bool
+	var fileID string
+	userAny, err := s.InTx(ctx, func(tx *gorm.DB) (any, error) {
+		var user model.User
+		if err := tx.First(&user, "id = ?", userID).Error; err != nil {
+			return model.User{}, err
+		}
+
+		fileURL, err := fileUploader.GenerateFileURLCompressed(s.Storage, "avatars", req.File)
+		if err != nil {
+			return model.User{}, err
+		}
+
+		var oldFiles []string
+		for _, old := range []*string{user.Avatar64, user.Avatar256, user.Avatar512} {
+			if old != nil {
+				oldFiles = append(oldFiles, *old)
+			}
+		}
+
+		upload, err := s.EnqueueUploadFile(ctx, tx, fileUploader.QueueUploadFile{
+			FilePath:         fileURL,
+			IsCompressToWebp: helper.BoolPtr(true),
+			File:             req.File,
+			Sizes:            avatarSizes,
+			OldFiles:         oldFiles,
+		})
+		jobID = upload.JobID
+		if err != nil {
+			// request lain dengan key yang sama menang duluan, batalkan perubahan avatar
+			duplicate = errors.Is(err, queue.ErrDuplicate)
+			return model.User{}, err
+		}
+
+		// URL akhir content-addressed, baru diketahui setelah file di-hash
+		user.Avatar64 = helper.StringPtr(fileUploader.VariantURL(upload.File.URL, 64))
+		user.Avatar256 = helper.StringPtr(fileUploader.VariantURL(upload.File.URL, 256))
+		user.Avatar512 = helper.StringPtr(fileUploader.VariantURL(upload.File.URL, 512))
+		if err := tx.Save(&user).Error; err != nil {
+			return model.User{}, err
+		}
+		if err := s.Attach(ctx, tx, user.TableName(), user.ID, avatarField, upload.File.ID); err != nil {
+			return model.User{}, err
+		}
+		fileID = upload.File.ID
+
+		return user, nil
+	})
+	if duplicate {
+		return s.avatarResponse(ctx, userID, jobID)
+	}
+	if err != nil {
+		return dto.UploadAvatarResponse{}, apperror.NewT("UPLOAD_AVATAR_FAILED", 400, err, nil).WithStack(userID)
+	}
+	return dto.UploadAvatarResponse{User: userAny.(model.User), JobID: jobID, FileID: fileID}, nil
+}
+
+func (s *Service) avatarResponse(ctx context.Context, userID, jobID string) (dto.UploadAvatarResponse, error) {
+	var user model.User
+	if err := s.DB().First(&user, "id = ?", userID).Error; err != nil {
+		return dto.UploadAvatarResponse{}, apperror.NewT("UPLOAD_AVATAR_FAILED", 400, err, nil).WithStack(userID)
+	}
+	res := dto.UploadAvatarResponse{User: user, JobID: jobID}
+	if file, err := s.FindAttachment(ctx, user.TableName(), user.ID, avatarField); err == nil {
+		res.FileID = file.ID
+	}
+	return res, nil
+}
+
 func (s *Service) HandleDelete(ctx context.Context, id string) (model.User, error) {
 	var user model.User
 	err := s.DB().First(&user, "id = ?", id).Error
 	if err != nil {
 		return model.User{}, err
 	}
-	err = s.DB().Delete(&user).Error
+	// avatar yang tidak dipakai user lain ikut dihapus dari storage
+	err = s.InTxVoid(ctx, func(tx *gorm.DB) error {
+		if err := tx.Delete(&user).Error; err != nil {
+			return err
+		}
+		return s.DetachAll(ctx, tx, user.TableName(), user.ID)
+	})
 	if err != nil {
 		return model.User{}, err
 	}
@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"template-golang/internal/cleanup"
+	"template-golang/pkg/config"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/queue"
+)
+
+func (h *handlers) registerCleanup() {
+	queue.Register(cleanup.FilesJob, h.handleCleanupFiles)
+	queue.Schedule("cleanup_files_nightly", "0 3 * * *", cleanup.FilesJob, cleanup.FilesPayload{})
+}
+
+// handleCleanupFiles hapus file tmp lama, upload presigned yang kedaluwarsa dan object storage
+// tanpa referensi database, laporannya disimpan sebagai result job (GET /api/v1/jobs/:id)
+func (h *handlers) handleCleanupFiles(ctx context.Context, job queue.Job[cleanup.FilesPayload]) error {
+	cfg := config.GetConfig()
+	report, err := cleanup.Files(ctx, h.db, h.store, h.queue, cleanup.Options{
+		DryRun:           job.Payload.DryRun,
+		TmpTTL:           cfg.FileGCTmpTTL,
+		OrphanMinAge:     cfg.FileGCOrphanMinAge,
+		PendingUploadTTL: cfg.FileGCPendingUploadTTL,
+	})
+	if serr := job.SetResult(ctx, report); serr != nil {
+		logger.L().Warnf("job %s: failed to save cleanup report: %v", job.ID, serr)
+	}
+	if err != nil {
+		return err
+	}
+	logger.L().Infof("job %s: cleanup removed %d tmp file(s), %d orphan(s) and %d expired upload(s), %d bytes (dry run: %v)",
+		job.ID, len(report.TmpFiles), len(report.Orphans), len(report.ExpiredUploads), report.FreedBytes, report.DryRun)
+	if len(report.Errors) > 0 {
+		return fmt.Errorf("failed to delete %d file(s): %s", len(report.Errors), report.Errors[0])
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"template-golang/internal/cleanup"
+	"template-golang/internal/db/model"
+	"template-golang/pkg/fileUploader"
+	"template-golang/pkg/filecheck"
+	"template-golang/pkg/imaging"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/queue"
+	"template-golang/pkg/scanner"
+
+	"gorm.io/gorm"
+)
+
+// fileReady tandai baris files siap setelah upload berhasil: ukuran & content type
+// object akhir (setelah konversi) dan key varian-nya. Error kalau baris belum / tidak
+// ada supaya job di-retry.
+func (h *handlers) fileReady(ctx context.Context, fileID, fileURL string, variants map[string]string) error {
+	if fileID == "" {
+		return nil
+	}
+
+	keys := model.FileKeys{}
+	for name, url := range variants {
+		if key, ok := fileUploader.FileKey(h.store, url); ok {
+			keys[name] = key
+		}
+	}
+	fields := map[string]any{"status": model.FileStatusReady, "variants": keys, "error": nil}
+	if key, ok := fileUploader.FileKey(h.store, fileURL); ok {
+		fields["storage_key"] = key
+	}
+	// file utama tidak disimpan kalau hanya varian yang diupload (Sizes)
+	if obj, err := fileUploader.StatFile(ctx, h.store, fileURL); err == nil {
+		fields["size"] = obj.Size
+		if obj.ContentType != "" {
+			fields["mime_type"] = obj.ContentType
+		}
+	}
+	return h.updateFile(ctx, fileID, fields)
+}
+
+// fileFailed tandai baris files gagal, file yang ditolak validasi / terinfeksi jadi rejected
+func (h *handlers) fileFailed(ctx context.Context, fileID string, cause error) {
+	if fileID == "" {
+		return
+	}
+	status := model.FileStatusFailed
+	if errors.Is(cause, filecheck.ErrRejected) || errors.Is(cause, imaging.ErrTooLarge) || errors.Is(cause, scanner.ErrInfected) {
+		status = model.FileStatusRejected
+	}
+	if err := h.updateFile(ctx, fileID, map[string]any{"status": status, "error": cause.Error()}); err != nil {
+		logger.L().Warnf("failed to mark file %s as %s: %v", fileID, status, err)
+	}
+}
+
+func (h *handlers) registerFiles() {
+	queue.Register(fileUploader.DeleteFileJob, h.handleDeleteFile)
+}
+
+// handleDeleteFile hapus object & varian file yang sudah dilepas referensi terakhirnya
+// (base.ReleaseFile). Key yang masih dipakai file aktif lain (isi sama diupload ulang) dilewati.
+func (h *handlers) handleDeleteFile(ctx context.Context, job queue.Job[fileUploader.QueueDeleteFile]) error {
+	var file model.File
+	err := h.db.WithContext(ctx).Unscoped().First(&file, "id = ?", job.Payload.FileID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return queue.Permanent(fmt.Errorf("file %s not found", job.Payload.FileID))
+	}
+	if err != nil {
+		return err
+	}
+	// transaksi yang melepas file di-rollback, atau file dipakai lagi
+	if !file.DeletedAt.Valid || file.RefCount > 0 {
+		logger.L().Infof("job %s: file %s is still referenced, skipping", job.ID, file.ID)
+		return nil
+	}
+
+	keys := []string{file.StorageKey}
+	for _, key := range file.Variants {
+		keys = append(keys, key)
+	}
+	for _, key := range keys {
+		inUse, err := cleanup.KeyInUse(ctx, h.db, key)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			logger.L().Infof("job %s: %s is used by another file, skipping", job.ID, key)
+			continue
+		}
+		if err := h.store.Delete(ctx, key); err != nil {
+			return err
+		}
+		logger.L().Infof("job %s: %s deleted", job.ID, key)
+	}
+	return nil
+}
+
+// updateFile update baris files, error kalau barisnya tidak ada
+func (h *handlers) updateFile(ctx context.Context, fileID string, fields map[string]any) error {
+	res := h.db.WithContext(ctx).Model(&model.File{}).Where("id = ?", fileID).Updates(fields)
+	if res.Error != nil {
+		return fmt.Errorf("failed to update file %s: %w", fileID, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("file %s not found", fileID)
+	}
+	return nil
+}
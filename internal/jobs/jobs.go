@@ -0,0 +1,36 @@
+// Package jobs handler background job untuk pkg/queue. Worker memanggil Register
+// sekali dengan dependensinya; fitur baru menambahkan handler-nya di Register.
+package jobs
+
+import (
+	"template-golang/pkg/queue"
+	"template-golang/pkg/scanner"
+	"template-golang/pkg/storage"
+
+	"gorm.io/gorm"
+)
+
+// Deps dependensi handler job, dibuat oleh cmd/worker
+type Deps struct {
+	DB      *gorm.DB
+	Storage storage.Storage
+	// Scanner pemindai malware file upload (scanner.New)
+	Scanner scanner.Scanner
+	// Queue antrian job, dibaca GC file supaya file tmp job yang belum jalan tidak dihapus
+	Queue *queue.Client
+}
+
+type handlers struct {
+	db      *gorm.DB
+	store   storage.Storage
+	scanner scanner.Scanner
+	queue   *queue.Client
+}
+
+// Register daftarkan semua handler job & schedule ke pkg/queue, dipanggil sekali sebelum Worker.Run
+func Register(deps Deps) {
+	h := &handlers{db: deps.DB, store: deps.Storage, scanner: deps.Scanner, queue: deps.Queue}
+	h.registerUpload()
+	h.registerFiles()
+	h.registerCleanup()
+}
@@ -0,0 +1,220 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"template-golang/internal/cleanup"
+	"template-golang/pkg/fileUploader"
+	"template-golang/pkg/filecheck"
+	"template-golang/pkg/helper"
+	"template-golang/pkg/imaging"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/queue"
+	"template-golang/pkg/scanner"
+	"template-golang/pkg/storage"
+)
+
+// uploadResult hasil job upload di GET /api/v1/jobs/:id
+type uploadResult struct {
+	// FileID baris tabel files, kosong untuk job lama
+	FileID string `json:"file_id,omitempty"`
+	URL    string `json:"url"`
+	// Variants nama preset -> URL varian gambar
+	Variants map[string]string `json:"variants,omitempty"`
+}
+
+func (h *handlers) registerUpload() {
+	// handleUpload menghapus file tmp & file lama, tidak boleh berjalan dua kali
+	queue.Register(fileUploader.UploadJob, h.handleUpload, queue.Dedupe())
+	queue.Register(fileUploader.PDFUploadJob, h.handlePDFUpload)
+	// object asal dihapus setelah diproses
+	queue.Register(fileUploader.ProcessUploadJob, h.handleProcessUpload, queue.Dedupe())
+}
+
+// handleUpload upload file tmp hasil fileUploader.StageUpload ke S3 beserta varian preset-nya
+func (h *handlers) handleUpload(ctx context.Context, job queue.Job[fileUploader.QueueUploadFile]) (err error) {
+	payload := job.Payload
+	defer func() { h.markFailed(ctx, job.Attempt, job.MaxAttempts, payload.FileID, err) }()
+
+	// Validasi field penting
+	if payload.FilePathTmp == nil {
+		return queue.Permanent(errors.New("FilePathTmp field missing"))
+	}
+	if payload.IsCompressToWebp == nil {
+		return queue.Permanent(errors.New("IsCompressToWebp field missing"))
+	}
+
+	// Pastikan file tmp ada
+	if _, err := os.Stat(*payload.FilePathTmp); err != nil {
+		return queue.Permanent(fmt.Errorf("tmp file not found (%s): %w", *payload.FilePathTmp, err))
+	}
+	if _, err := imaging.Resolve(payload.Presets); err != nil {
+		return queue.Permanent(err)
+	}
+
+	logger.L().Infof("job %s: start processing filePath=%s tmp=%s compress=%v",
+		job.ID, payload.FilePath, *payload.FilePathTmp, *payload.IsCompressToWebp)
+	job.Progress(ctx, 10, "uploading")
+
+	variants, err := fileUploader.UploadFileWithVariants(ctx, h.store, *payload.FilePathTmp, fileUploader.FileUploadOptions{
+		Folder:           fileUploader.ExtractFolderFromFilePath(h.store, payload.FilePath),
+		NameFile:         payload.FilePath,
+		IsCompressToWebp: helper.BoolPtr(*payload.IsCompressToWebp),
+		Sizes:            payload.Sizes,
+		Presets:          payload.Presets,
+		Resumable:        job.Attempt < job.MaxAttempts,
+		OnProgress:       uploadProgress(ctx, job.Progress),
+		Scanner:          h.scanner,
+	})
+	if err != nil {
+		return uploadError(err)
+	}
+
+	// baris files diupdate sebelum file tmp dihapus, kalau gagal job di-retry dari awal
+	if err := h.fileReady(ctx, payload.FileID, payload.FilePath, variants); err != nil {
+		return err
+	}
+	removeTmp(job.ID, *payload.FilePathTmp)
+	job.Progress(ctx, 90, "removing old files")
+
+	// Hapus file lama setelah file baru berhasil diupload. Job diantrikan setelah transaksi
+	// yang mengganti URL-nya commit, file lama yang masih direferensikan baris lain dilewati.
+	oldFiles := payload.OldFiles
+	if payload.OldFile != nil {
+		oldFiles = append(oldFiles, *payload.OldFile)
+	}
+	for _, oldFile := range oldFiles {
+		inUse, err := cleanup.URLInUse(ctx, h.db, h.store, oldFile)
+		if err != nil {
+			logger.L().Errorf("job %s: failed to check old file %s: %v", job.ID, oldFile, err)
+			continue
+		}
+		if inUse {
+			logger.L().Infof("job %s: old file %s is still referenced, skipping", job.ID, oldFile)
+			continue
+		}
+		if err := fileUploader.DeleteFile(ctx, h.store, oldFile); err != nil {
+			logger.L().Errorf("job %s: failed to delete old file %s: %v", job.ID, oldFile, err)
+		} else {
+			logger.L().Infof("job %s: old file %s deleted", job.ID, oldFile)
+		}
+	}
+
+	if err := job.SetResult(ctx, uploadResult{FileID: payload.FileID, URL: payload.FilePath, Variants: variants}); err != nil {
+		logger.L().Warnf("job %s: failed to store result: %v", job.ID, err)
+	}
+	return nil
+}
+
+// handlePDFUpload upload PDF yang digenerate server (misal bukti pendaftaran)
+func (h *handlers) handlePDFUpload(ctx context.Context, job queue.Job[fileUploader.QueueUploadPDF]) error {
+	payload := job.Payload
+
+	if _, err := os.Stat(payload.FilePath); err != nil {
+		return queue.Permanent(fmt.Errorf("tmp file not found (%s): %w", payload.FilePath, err))
+	}
+
+	err := fileUploader.UploadFileFromPath(ctx, h.store, payload.FilePath, fileUploader.FileUploadOptions{
+		Folder:           payload.Folder,
+		NameFile:         payload.Name,
+		AllowedMimeTypes: []string{"application/pdf"},
+		Resumable:        job.Attempt < job.MaxAttempts,
+		Scanner:          h.scanner,
+	})
+	if err != nil {
+		return uploadError(err)
+	}
+
+	removeTmp(job.ID, payload.FilePath)
+	return nil
+}
+
+// handleProcessUpload scan dan salin file yang diupload client ke staging (presigned upload)
+// ke key akhir, gambar dikonversi ke WebP beserta variannya, lalu hapus object staging
+func (h *handlers) handleProcessUpload(ctx context.Context, job queue.Job[fileUploader.QueueProcessUpload]) (err error) {
+	payload := job.Payload
+	defer func() { h.markFailed(ctx, job.Attempt, job.MaxAttempts, payload.FileID, err) }()
+	if payload.Key == "" || payload.FilePath == "" {
+		return queue.Permanent(errors.New("key or file_path field missing"))
+	}
+
+	target, ok := fileUploader.FileKey(h.store, payload.FilePath)
+	if !ok {
+		return queue.Permanent(fmt.Errorf("file_path %s does not belong to storage", payload.FilePath))
+	}
+	if _, err := imaging.Resolve(payload.Presets); err != nil {
+		return queue.Permanent(err)
+	}
+
+	job.Progress(ctx, 10, "processing")
+	variants, err := fileUploader.ProcessStoredFile(ctx, h.store, payload.Key, fileUploader.FileUploadOptions{
+		Folder:           fileUploader.ExtractFolderFromFilePath(h.store, payload.FilePath),
+		NameFile:         payload.FilePath,
+		IsCompressToWebp: helper.BoolPtr(payload.IsCompressToWebp),
+		Sizes:            payload.Sizes,
+		Presets:          payload.Presets,
+		Scanner:          h.scanner,
+	})
+	if err != nil {
+		if errors.Is(err, scanner.ErrInfected) {
+			// object staging langsung dihapus (salinannya ada di karantina worker)
+			if derr := h.store.Delete(ctx, payload.Key); derr != nil {
+				logger.L().Errorf("job %s: failed to delete infected %s: %v", job.ID, payload.Key, derr)
+			}
+		}
+		return uploadError(err)
+	}
+
+	// object asal baru dihapus setelah baris files diupdate, kalau gagal job di-retry dari awal
+	if err := h.fileReady(ctx, payload.FileID, payload.FilePath, variants); err != nil {
+		return err
+	}
+	if target != payload.Key {
+		job.Progress(ctx, 90, "removing original")
+		if err := h.store.Delete(ctx, payload.Key); err != nil {
+			logger.L().Errorf("job %s: failed to delete original %s: %v", job.ID, payload.Key, err)
+		}
+	}
+
+	if err := job.SetResult(ctx, uploadResult{FileID: payload.FileID, URL: payload.FilePath, Variants: variants}); err != nil {
+		logger.L().Warnf("job %s: failed to store result: %v", job.ID, err)
+	}
+	return nil
+}
+
+// uploadError file yang hilang, ditolak validasi (filecheck, dimensi gambar) atau
+// terinfeksi malware tidak akan berhasil kalau di-retry. Pesan error jadi alasan job gagal.
+func uploadError(err error) error {
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, filecheck.ErrRejected) || errors.Is(err, imaging.ErrTooLarge) ||
+		errors.Is(err, scanner.ErrInfected) {
+		return queue.Permanent(err)
+	}
+	return err
+}
+
+// markFailed tandai baris files gagal kalau job tidak akan di-retry lagi
+func (h *handlers) markFailed(ctx context.Context, attempt, maxAttempts int64, fileID string, err error) {
+	if err != nil && (queue.IsPermanent(err) || attempt >= maxAttempts) {
+		h.fileFailed(ctx, fileID, err)
+	}
+}
+
+// uploadProgress progress upload file besar dipetakan ke 10-80%
+func uploadProgress(ctx context.Context, progress func(ctx context.Context, percent int, message string)) func(uploaded, total int64) {
+	return func(uploaded, total int64) {
+		if total > 0 {
+			progress(ctx, 10+int(uploaded*70/total), "uploading")
+		}
+	}
+}
+
+func removeTmp(jobID, path string) {
+	if err := os.Remove(path); err != nil {
+		logger.L().Warnf("job %s: failed to remove tmp file %s: %v", jobID, path, err)
+	} else {
+		logger.L().Infof("job %s: tmp file %s removed", jobID, path)
+	}
+}
@@ -0,0 +1,58 @@
+package seeders
+
+import (
+	"time"
+
+	"template-golang/internal/db/model"
+	"template-golang/pkg/helper"
+	"template-golang/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+func SeedAlumni(db *gorm.DB) error {
+	now := time.Now()
+	alumni := []model.Alumni{
+		{
+			Name:           "Rina Wijaya",
+			GraduationYear: 2018,
+			Occupation:     model.Translations{LANG_ID: "Insinyur Perangkat Lunak", LANG_EN: "Software Engineer"},
+			Company:        helper.StringPtr("PT Teknologi Nusantara"),
+			Testimonial: model.Translations{
+				LANG_ID: "Guru-guru di sini membantu saya menemukan minat di bidang teknologi.",
+				LANG_EN: "The teachers here helped me discover my interest in technology.",
+			},
+		},
+		{
+			Name:           "Andi Pratama",
+			GraduationYear: 2016,
+			Occupation:     model.Translations{LANG_ID: "Dokter", LANG_EN: "Doctor"},
+			Company:        helper.StringPtr("RSUD Dr. Soetomo"),
+			Testimonial: model.Translations{
+				LANG_ID: "Disiplin dan kebiasaan belajar yang saya dapat di sekolah sangat berguna saat kuliah.",
+				LANG_EN: "The discipline and study habits I built at school were very useful in university.",
+			},
+		},
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for i := range alumni {
+			alumni[i].PhotoURL = helper.StringPtr(EXAMPLE_ALUMNI)
+			alumni[i].CompanyLogoURL = helper.StringPtr(EXAMPLE_LOGO)
+			alumni[i].SortOrder = i + 1
+			alumni[i].IsPublished = true
+			alumni[i].PublishedAt = &now
+			if err := tx.Create(&alumni[i]).Error; err != nil {
+				logger.L().Errorf("failed to create alumni: %v", err)
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.L().Errorf("failed to seed alumni: %v", err)
+		return err
+	}
+
+	return nil
+}
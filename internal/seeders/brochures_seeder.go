@@ -0,0 +1,63 @@
+package seeders
+
+import (
+	"time"
+
+	"template-golang/internal/db/model"
+	"template-golang/pkg/helper"
+	"template-golang/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+func SeedBrochures(db *gorm.DB) error {
+	now := time.Now()
+	brochures := []model.Brochure{
+		{
+			Title: model.Translations{
+				LANG_ID: "Brosur Penerimaan Peserta Didik Baru",
+				LANG_EN: "New Student Admission Brochure",
+			},
+			Description: model.Translations{
+				LANG_ID: "Informasi jadwal, syarat dan biaya pendaftaran",
+				LANG_EN: "Schedule, requirements and registration fees",
+			},
+			FileURL:      EXAMPLE_PDF,
+			ThumbnailURL: helper.StringPtr(EXAMPLE_THUMBNAIL),
+			SortOrder:    1,
+			IsPublished:  true,
+			PublishedAt:  &now,
+		},
+		{
+			Title: model.Translations{
+				LANG_ID: "Brosur Program Unggulan",
+				LANG_EN: "Featured Programs Brochure",
+			},
+			Description: model.Translations{
+				LANG_ID: "Program akademik dan ekstrakurikuler unggulan sekolah",
+				LANG_EN: "The school's featured academic and extracurricular programs",
+			},
+			FileURL:      EXAMPLE_PDF,
+			ThumbnailURL: helper.StringPtr(EXAMPLE_THUMBNAIL),
+			SortOrder:    2,
+			IsPublished:  true,
+			PublishedAt:  &now,
+		},
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for i := range brochures {
+			if err := tx.Create(&brochures[i]).Error; err != nil {
+				logger.L().Errorf("failed to create brochure: %v", err)
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.L().Errorf("failed to seed brochures: %v", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package seeders
+
+import (
+	"time"
+
+	"template-golang/internal/db/model"
+	"template-golang/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+func SeedFacilities(db *gorm.DB) error {
+	now := time.Now()
+	facilities := []model.Facility{
+		{
+			Name:        model.Translations{LANG_ID: "Laboratorium Komputer", LANG_EN: "Computer Laboratory"},
+			Description: model.Translations{LANG_ID: "Laboratorium dengan perangkat komputer terbaru", LANG_EN: "Laboratory with the latest computers"},
+		},
+		{
+			Name:        model.Translations{LANG_ID: "Perpustakaan", LANG_EN: "Library"},
+			Description: model.Translations{LANG_ID: "Koleksi buku dan ruang baca yang nyaman", LANG_EN: "Book collection and a comfortable reading room"},
+		},
+		{
+			Name:        model.Translations{LANG_ID: "Lapangan Olahraga", LANG_EN: "Sports Field"},
+			Description: model.Translations{LANG_ID: "Lapangan serbaguna untuk futsal, basket dan voli", LANG_EN: "Multipurpose field for futsal, basketball and volleyball"},
+		},
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for i := range facilities {
+			facilities[i].ImageURL = EXAMPLE_FACILITIES
+			facilities[i].SortOrder = i + 1
+			facilities[i].IsPublished = true
+			facilities[i].PublishedAt = &now
+			if err := tx.Create(&facilities[i]).Error; err != nil {
+				logger.L().Errorf("failed to create facility: %v", err)
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.L().Errorf("failed to seed facilities: %v", err)
+		return err
+	}
+
+	return nil
+}
@@ -25,6 +25,21 @@ func Seed(db *gorm.DB) error {
 		return err
 	}
 
+	if err := SeedBrochures(db); err != nil {
+		logger.L().Errorf("failed to seed brochures: %v", err)
+		return err
+	}
+
+	if err := SeedFacilities(db); err != nil {
+		logger.L().Errorf("failed to seed facilities: %v", err)
+		return err
+	}
+
+	if err := SeedAlumni(db); err != nil {
+		logger.L().Errorf("failed to seed alumni: %v", err)
+		return err
+	}
+
 	logger.L().Info("seeding database completed")
 	return nil
 }
@@ -4,22 +4,43 @@
 package internal
 
 import (
-	"github.com/gofiber/fiber/v2"
 	"github.com/google/wire"
 
 	"template-golang/internal/db"
+	"template-golang/internal/features/alumni"
 	"template-golang/internal/features/base"
+	"template-golang/internal/features/brochures"
+	"template-golang/internal/features/events"
+	"template-golang/internal/features/facilities"
+	"template-golang/internal/features/files"
+	"template-golang/internal/features/jobs"
+	"template-golang/internal/features/realtime"
+	"template-golang/internal/features/registrations"
+	"template-golang/internal/features/uploads"
 	"template-golang/internal/features/users"
 
+	"template-golang/pkg/queue"
 	"template-golang/pkg/redisx"
+	"template-golang/pkg/storage"
 )
 
-func InitApp() (*fiber.App, error) {
+func InitApp() (*App, error) {
 	wire.Build(
 		db.ConnectDB,
 		redisx.New,
+		storage.New,
+		queue.NewClient,
 		base.Set,
 		users.Set,
+		registrations.Set,
+		brochures.Set,
+		facilities.Set,
+		alumni.Set,
+		jobs.Set,
+		events.Set,
+		realtime.Set,
+		uploads.Set,
+		files.Set,
 		NewUtschoolApp,
 	)
 	return nil, nil
@@ -7,28 +7,75 @@
 package internal
 
 import (
-	"github.com/gofiber/fiber/v2"
 	"template-golang/internal/db"
+	handler5 "template-golang/internal/features/alumni/handler"
+	service7 "template-golang/internal/features/alumni/service"
 	"template-golang/internal/features/base"
+	handler3 "template-golang/internal/features/brochures/handler"
+	service5 "template-golang/internal/features/brochures/service"
+	handler7 "template-golang/internal/features/events/handler"
+	"template-golang/internal/features/events/service"
+	handler4 "template-golang/internal/features/facilities/handler"
+	service6 "template-golang/internal/features/facilities/service"
+	handler10 "template-golang/internal/features/files/handler"
+	service10 "template-golang/internal/features/files/service"
+	handler6 "template-golang/internal/features/jobs/handler"
+	service8 "template-golang/internal/features/jobs/service"
+	handler8 "template-golang/internal/features/realtime/handler"
+	service2 "template-golang/internal/features/realtime/service"
+	handler2 "template-golang/internal/features/registrations/handler"
+	service4 "template-golang/internal/features/registrations/service"
+	handler9 "template-golang/internal/features/uploads/handler"
+	service9 "template-golang/internal/features/uploads/service"
 	"template-golang/internal/features/users/handler"
-	"template-golang/internal/features/users/service"
+	service3 "template-golang/internal/features/users/service"
+	"template-golang/pkg/queue"
 	"template-golang/pkg/redisx"
+	"template-golang/pkg/storage"
 )
 
 // Injectors from wire.go:
 
-func InitApp() (*fiber.App, error) {
+func InitApp() (*App, error) {
 	gormDB, err := db.ConnectDB()
 	if err != nil {
 		return nil, err
 	}
+	storageStorage, err := storage.New()
+	if err != nil {
+		return nil, err
+	}
 	client, err := redisx.New()
 	if err != nil {
 		return nil, err
 	}
-	baseService := base.NewBaseService(gormDB, client)
-	serviceService := service.NewService(baseService)
+	queueClient := queue.NewClient(client)
+	baseService := base.NewBaseService(gormDB, client, storageStorage, queueClient)
+	hub := service.NewHub(baseService)
+	gateway := service2.NewGateway(baseService)
+	serviceService := service3.NewService(baseService)
 	handlerHandler := handler.NewHandler(serviceService)
-	app := NewUtschoolApp(handlerHandler)
+	service11 := service4.NewService(baseService)
+	handler11 := handler2.NewHandler(service11)
+	service12 := service5.NewService(baseService)
+	handler12 := handler3.NewHandler(service12)
+	service13 := service6.NewService(baseService)
+	handler13 := handler4.NewHandler(service13)
+	service14 := service7.NewService(baseService)
+	handler14 := handler5.NewHandler(service14)
+	service15 := service8.NewService(baseService)
+	handler15 := handler6.NewHandler(service15)
+	service16 := service.NewService(baseService, hub)
+	handler16 := handler7.NewHandler(service16)
+	service17 := service2.NewService(baseService, gateway)
+	handler17 := handler8.NewHandler(service17)
+	service18 := service9.NewService(baseService)
+	handler18 := handler9.NewHandler(service18)
+	service19 := service10.NewService(baseService)
+	handler19 := handler10.NewHandler(service19)
+	app, err := NewUtschoolApp(gormDB, storageStorage, hub, gateway, handlerHandler, handler11, handler12, handler13, handler14, handler15, handler16, handler17, handler18, handler19)
+	if err != nil {
+		return nil, err
+	}
 	return app, nil
 }
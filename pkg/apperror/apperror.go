@@ -1,7 +1,12 @@
 // pkg/apperror/apperror.go
 package apperror
 
-import "net/http"
+import (
+	"net/http"
+
+	"template-golang/pkg/i18n"
+	"template-golang/pkg/locale"
+)
 
 type AppError struct {
 	Code       string `json:"code"`
@@ -9,17 +14,85 @@ type AppError struct {
 	StatusCode int    `json:"-"`
 	Detail     any    `json:"detail,omitempty"`
 	Stack      string `json:"stack,omitempty"`
+
+	// Key & Params dipakai untuk render ulang Message sesuai bahasa request
+	Key    string      `json:"-"`
+	Params i18n.Params `json:"-"`
+}
+
+// Localizer detail error yang bisa dirender ulang per locale (misal error validasi)
+type Localizer interface {
+	Localize(locale string) any
 }
 
 func (e *AppError) Error() string {
 	return e.Message
 }
 
+// Localize kembalikan salinan error dengan pesan & detail dalam locale l
+func (e *AppError) Localize(l string) *AppError {
+	localized := *e
+	if e.Key != "" {
+		localized.Message = i18n.T(l, e.Key, e.Params)
+	}
+	switch d := e.Detail.(type) {
+	case Localizer:
+		localized.Detail = d.Localize(l)
+	case *AppError:
+		localized.Detail = d.Localize(l)
+	}
+	return &localized
+}
+
+// WithStack isi Stack (keterangan internal, contoh id data yang diproses) lalu kembalikan e
+func (e *AppError) WithStack(stack string) *AppError {
+	e.Stack = stack
+	return e
+}
+
 func New(code, message string, status int, detail any, stack string) *AppError {
 	return &AppError{Code: code, Message: message, StatusCode: status, Detail: detail, Stack: stack}
 }
 
+// NewT buat AppError dengan pesan dari katalog i18n (key "error.<code>"),
+// Message diisi dalam locale default dan dirender ulang oleh ErrorHandler
+func NewT(code string, status int, detail any, params i18n.Params) *AppError {
+	key := i18n.ErrorKey(code)
+	return &AppError{
+		Code:       code,
+		Message:    i18n.T(locale.Default, key, params),
+		StatusCode: status,
+		Detail:     detail,
+		Key:        key,
+		Params:     params,
+	}
+}
+
+func BadRequest(detail any) *AppError {
+	return NewT("BAD_REQUEST", http.StatusBadRequest, detail, nil)
+}
+
+func Unauthorized(detail any) *AppError {
+	return NewT("UNAUTHORIZED", http.StatusUnauthorized, detail, nil)
+}
+
+func Forbidden(detail any) *AppError {
+	return NewT("FORBIDDEN", http.StatusForbidden, detail, nil)
+}
+
+func NotFound(detail any) *AppError {
+	return NewT("NOT_FOUND", http.StatusNotFound, detail, nil)
+}
+
+func Validation(detail any) *AppError {
+	return NewT("VALIDATION_ERROR", http.StatusUnprocessableEntity, detail, nil)
+}
+
+func Internal(detail any) *AppError {
+	return NewT("INTERNAL_ERROR", http.StatusInternalServerError, detail, nil)
+}
+
 var (
-	ErrBadRequest = New("BAD_REQUEST", "Request tidak valid", http.StatusBadRequest, nil, "")
-	ErrInternal   = New("INTERNAL_ERROR", "Kesalahan server", http.StatusInternalServerError, nil, "")
+	ErrBadRequest = BadRequest(nil)
+	ErrInternal   = Internal(nil)
 )
@@ -3,28 +3,65 @@ package config
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Port     int    `env:"SERVER_PORT" envDefault:"10010"`
-	Env      string `env:"SERVER_ENV" envDefault:"dev"`
-	DBHost   string `env:"DB_HOST" envDefault:"localhost"`
-	DBPort   int    `env:"DB_PORT" envDefault:"10001"`
-	DBUser   string `env:"DB_USER"`
-	DBPass   string `env:"DB_PASSWORD"`
-	DBName   string `env:"DB_NAME"`
-	DBURL    string
+	Port      int    `env:"SERVER_PORT" envDefault:"10010"`
+	Env       string `env:"SERVER_ENV" envDefault:"dev"`
+	DBHost    string `env:"DB_HOST" envDefault:"localhost"`
+	DBPort    int    `env:"DB_PORT" envDefault:"10001"`
+	DBUser    string `env:"DB_USER"`
+	DBPass    string `env:"DB_PASSWORD"`
+	DBName    string `env:"DB_NAME"`
+	DBURL     string
 	RedisAddr string `env:"REDIS_ADDR"`
 	RedisPass string `env:"REDIS_PASS"`
-	S3Bucket string `env:"S3_BUCKET"`
-	S3Region string `env:"S3_REGION"`
-	S3Access string `env:"S3_ACCESS_KEY"`
-	S3Secret string `env:"S3_SECRET_KEY"`
-	S3End    string `env:"S3_ENDPOINT" envDefault:"is3.cloudhost.id"`
-	JwtSecret string `env:"JWT_SECRET" envDefault:"utschool"`
+	S3Bucket  string `env:"S3_BUCKET"`
+	S3Region  string `env:"S3_REGION"`
+	S3Access  string `env:"S3_ACCESS_KEY"`
+	S3Secret  string `env:"S3_SECRET_KEY"`
+	S3End     string `env:"S3_ENDPOINT" envDefault:"is3.cloudhost.id"`
+	// Ukuran part multipart upload (MB, minimal 5) dan jumlah part yang diupload bersamaan
+	S3PartSizeMB        int `env:"S3_PART_SIZE_MB" envDefault:"8"`
+	S3UploadConcurrency int `env:"S3_UPLOAD_CONCURRENCY" envDefault:"4"`
+	// Driver penyimpanan file: s3, local, memory
+	StorageDriver string `env:"STORAGE_DRIVER" envDefault:"s3"`
+	// Folder file untuk driver local
+	StorageLocalDir string `env:"STORAGE_LOCAL_DIR" envDefault:"storage"`
+	// Base URL file untuk driver local / memory, default http://localhost:<SERVER_PORT>/storage
+	StoragePublicURL string `env:"STORAGE_PUBLIC_URL"`
+	// Kualitas encode gambar (1-100) kalau preset tidak mengatur
+	ImageQuality int `env:"IMAGE_QUALITY" envDefault:"80"`
+	// Tambahan / pengganti preset varian gambar, "name=WxH:mode:format:quality,..."
+	ImagePresets string `env:"IMAGE_PRESETS"`
+	// Batas dimensi gambar yang boleh di-decode (per sisi, px) dan total pixel
+	ImageMaxDimension int   `env:"IMAGE_MAX_DIMENSION" envDefault:"10000"`
+	ImageMaxPixels    int64 `env:"IMAGE_MAX_PIXELS" envDefault:"40000000"`
+	// Scanner malware sebelum file diupload worker: noop, clamd
+	ScannerDriver  string        `env:"SCANNER_DRIVER"`
+	ScannerTimeout time.Duration `env:"SCANNER_TIMEOUT" envDefault:"1m"`
+	// Alamat clamd, tcp://host:port atau unix:///path/clamd.sock
+	ClamdAddr string `env:"CLAMD_ADDR" envDefault:"tcp://localhost:3310"`
+	// Folder lokal worker untuk file terinfeksi
+	QuarantineDir string `env:"QUARANTINE_DIR" envDefault:"quarantine"`
+	// GC file (job cleanup_files / CLI cleanup files): umur file tmp yang dihapus, umur minimal
+	// object storage tanpa referensi database sebelum dihapus dan umur upload presigned yang
+	// tidak pernah di-complete (harus lebih lama dari masa berlaku URL upload, 15 menit)
+	FileGCTmpTTL           time.Duration `env:"FILE_GC_TMP_TTL" envDefault:"24h"`
+	FileGCOrphanMinAge     time.Duration `env:"FILE_GC_ORPHAN_MIN_AGE" envDefault:"24h"`
+	FileGCPendingUploadTTL time.Duration `env:"FILE_GC_PENDING_UPLOAD_TTL" envDefault:"1h"`
+	JwtSecret              string        `env:"JWT_SECRET" envDefault:"utschool"`
+	// Job pending yang idle lebih lama dari ini diambil alih consumer lain (harus > durasi job terlama)
+	WorkerReclaimIdle     time.Duration `env:"WORKER_RECLAIM_IDLE" envDefault:"1m"`
+	WorkerReclaimInterval time.Duration `env:"WORKER_RECLAIM_INTERVAL" envDefault:"30s"`
+	// Jumlah goroutine consumer per stream
+	WorkerConcurrency int `env:"WORKER_CONCURRENCY" envDefault:"4"`
+	// Batas waktu menunggu job yang sedang berjalan saat SIGTERM / SIGINT
+	WorkerShutdownTimeout time.Duration `env:"WORKER_SHUTDOWN_TIMEOUT" envDefault:"30s"`
 }
 
 var cfg *Config
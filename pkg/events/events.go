@@ -0,0 +1,151 @@
+// Package events notifikasi real-time: event disimpan di buffer Redis Stream
+// (untuk reconnect dengan Last-Event-ID) lalu dipublish ke channel Redis
+// sehingga setiap instance API bisa meneruskannya ke client yang terhubung.
+package events
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/redisx"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	// Channel channel Redis pub/sub untuk semua event
+	Channel = "events"
+	// BufferStream Redis Stream berisi event terakhir
+	BufferStream = "events:buffer"
+	// BufferSize jumlah (kira-kira) event terakhir yang disimpan untuk replay
+	BufferSize = 1000
+)
+
+// Tipe event bawaan
+const (
+	TypeJobStatus           = "job.status"
+	TypeRegistrationCreated = "registration.created"
+	TypeNotice              = "notice"
+)
+
+// Audience penerima event. Kosong = semua user yang terhubung.
+type Audience struct {
+	UserID string   `json:"user_id,omitempty"`
+	Roles  []string `json:"roles,omitempty"`
+}
+
+// ToUser event untuk satu user
+func ToUser(userID string) Audience {
+	return Audience{UserID: userID}
+}
+
+// ToRoles event untuk user dengan salah satu role
+func ToRoles(roles ...string) Audience {
+	return Audience{Roles: roles}
+}
+
+// Event satu notifikasi, ID = id pesan di BufferStream
+type Event struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data" swaggertype:"object"`
+	Audience  Audience        `json:"audience"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// VisibleTo apakah event boleh dikirim ke user dengan role tersebut
+func (e Event) VisibleTo(userID, role string) bool {
+	if e.Audience.UserID == "" && len(e.Audience.Roles) == 0 {
+		return true
+	}
+	if e.Audience.UserID != "" && e.Audience.UserID == userID {
+		return true
+	}
+	return slices.Contains(e.Audience.Roles, role)
+}
+
+// Publish simpan event di buffer lalu kirim ke semua instance lewat Channel
+func Publish(ctx context.Context, c *redisx.Client, typ string, data any, audience Audience) (Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, apperror.New("events", "Publish", 500, err, "failed to marshal event data")
+	}
+
+	e := Event{Type: typ, Data: raw, Audience: audience, CreatedAt: time.Now().UTC()}
+	body, err := json.Marshal(e)
+	if err != nil {
+		return Event{}, apperror.New("events", "Publish", 500, err, "failed to marshal event")
+	}
+
+	id, err := c.AddToStreamCapped(ctx, BufferStream, BufferSize, map[string]any{"event": string(body)})
+	if err != nil {
+		return Event{}, err
+	}
+	e.ID = id
+
+	body, err = json.Marshal(e)
+	if err != nil {
+		return Event{}, apperror.New("events", "Publish", 500, err, "failed to marshal event")
+	}
+	if err := c.Publish(ctx, Channel, string(body)); err != nil {
+		return Event{}, err
+	}
+	return e, nil
+}
+
+// Since event di buffer setelah lastID (maksimal limit), untuk replay saat reconnect
+func Since(ctx context.Context, c *redisx.Client, lastID string, limit int64) ([]Event, error) {
+	if _, _, ok := parseID(lastID); !ok {
+		return nil, apperror.BadRequest("invalid Last-Event-ID")
+	}
+
+	msgs, err := c.StreamRange(ctx, BufferStream, "("+lastID, "+", limit)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]Event, 0, len(msgs))
+	for _, msg := range msgs {
+		values, _ := msg.Payload.(map[string]any)
+		body, _ := values["event"].(string)
+
+		var e Event
+		if err := json.Unmarshal([]byte(body), &e); err != nil {
+			continue
+		}
+		e.ID = msg.ID
+		list = append(list, e)
+	}
+	return list, nil
+}
+
+// After apakah id event a lebih baru dari b
+func After(a, b string) bool {
+	ams, aseq, aok := parseID(a)
+	bms, bseq, bok := parseID(b)
+	if !aok || !bok {
+		return true
+	}
+	return ams > bms || (ams == bms && aseq > bseq)
+}
+
+// parseID pecah id Redis Stream "<ms>-<seq>"
+func parseID(id string) (int64, int64, bool) {
+	msPart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+	ms, err := strconv.ParseInt(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err := strconv.ParseInt(seqPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ms, seq, true
+}
@@ -0,0 +1,32 @@
+package events
+
+import "testing"
+
+func TestAfter(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "1700000000001-0", b: "1700000000000-0", want: true},
+		{a: "1700000000000-0", b: "1700000000001-0", want: false},
+		{a: "1700000000000-1", b: "1700000000000-0", want: true},
+		{a: "1700000000000-0", b: "1700000000000-1", want: false},
+		{a: "1700000000000-0", b: "1700000000000-0", want: false},
+		// dibandingkan sebagai angka, bukan string
+		{a: "1700000000000-10", b: "1700000000000-9", want: true},
+		{a: "999-0", b: "1000-0", want: false},
+
+		// id tidak valid dianggap lebih baru supaya event tidak hilang
+		{a: "", b: "1700000000000-0", want: true},
+		{a: "1700000000000-0", b: "", want: true},
+		{a: "1700000000000", b: "1700000000000-0", want: true},
+		{a: "abc-0", b: "1700000000000-0", want: true},
+		{a: "1700000000000-x", b: "1700000000000-0", want: true},
+	}
+
+	for _, tt := range tests {
+		if got := After(tt.a, tt.b); got != tt.want {
+			t.Errorf("After(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
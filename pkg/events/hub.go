@@ -0,0 +1,124 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"template-golang/pkg/logger"
+	"template-golang/pkg/redisx"
+
+	"github.com/goccy/go-json"
+)
+
+// subscriptionBuffer event yang bisa antre per client sebelum dianggap tertinggal
+const subscriptionBuffer = 64
+
+// Subscription satu client yang terhubung ke Hub
+type Subscription struct {
+	UserID string
+	Role   string
+	// C event untuk client ini
+	C chan Event
+
+	lagged  chan struct{}
+	lagOnce sync.Once
+}
+
+// Lagged ditutup saat client terlalu lambat dan event mulai terbuang,
+// client sebaiknya reconnect dengan Last-Event-ID
+func (s *Subscription) Lagged() <-chan struct{} {
+	return s.lagged
+}
+
+// Hub meneruskan event dari Channel Redis ke client di instance ini
+type Hub struct {
+	client *redisx.Client
+
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+func NewHub(client *redisx.Client) *Hub {
+	return &Hub{
+		client: client,
+		subs:   map[*Subscription]struct{}{},
+	}
+}
+
+// Run subscribe ke Channel dan dispatch event sampai ctx selesai.
+// Kalau subscribe gagal dicoba lagi setiap beberapa detik.
+func (h *Hub) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		msgs, err := h.client.Subscribe(ctx, Channel)
+		if err != nil {
+			logger.L().Errorf("events: failed to subscribe: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+			continue
+		}
+
+	receive:
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-msgs:
+				if !ok {
+					break receive
+				}
+				var e Event
+				if err := json.Unmarshal([]byte(msg.Payload), &e); err != nil {
+					logger.L().Warnf("events: invalid event: %v", err)
+					continue
+				}
+				h.dispatch(e)
+			}
+		}
+	}
+}
+
+// Subscribe daftarkan client, panggil Unsubscribe saat koneksi selesai
+func (h *Hub) Subscribe(userID, role string) *Subscription {
+	s := &Subscription{
+		UserID: userID,
+		Role:   role,
+		C:      make(chan Event, subscriptionBuffer),
+		lagged: make(chan struct{}),
+	}
+	h.mu.Lock()
+	h.subs[s] = struct{}{}
+	h.mu.Unlock()
+	return s
+}
+
+func (h *Hub) Unsubscribe(s *Subscription) {
+	h.mu.Lock()
+	delete(h.subs, s)
+	h.mu.Unlock()
+}
+
+// Count jumlah client yang terhubung ke instance ini
+func (h *Hub) Count() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subs)
+}
+
+func (h *Hub) dispatch(e Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for s := range h.subs {
+		if !e.VisibleTo(s.UserID, s.Role) {
+			continue
+		}
+		select {
+		case s.C <- e:
+		default:
+			s.lagOnce.Do(func() { close(s.lagged) })
+		}
+	}
+}
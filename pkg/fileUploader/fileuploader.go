@@ -3,72 +3,47 @@ package fileUploader
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"image"
-	"image/jpeg"
-	"image/png"
 	"io"
 	"mime/multipart"
-	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime/debug"
+	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"template-golang/pkg/apperror"
-	"template-golang/pkg/config"
+	"template-golang/pkg/filecheck"
+	"template-golang/pkg/imaging"
 	"template-golang/pkg/logger"
-
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/chai2010/webp"
-)
-
-var (
-	sessionInstance *session.Session
-	svcInstance     *s3.S3
-	once            sync.Once
+	"template-golang/pkg/scanner"
+	"template-golang/pkg/storage"
 )
 
 type QueueUploadFile struct {
 	FilePath         string
 	IsCompressToWebp *bool
-	File             *multipart.FileHeader
+	File             *multipart.FileHeader `json:"-"`
 	FilePathTmp      *string
 	OldFile          *string
-}
-
-// InitS3Client initialize singleton S3 client
-func InitS3Client() error {
-	var err error
-	cfg := config.GetConfig()
-	once.Do(func() {
-		region := cfg.S3Region
-		if region == "" {
-			region = "us-west-2"
-		}
-		sessionInstance, err = session.NewSession(&aws.Config{
-			Region:   aws.String(region),
-			Endpoint: aws.String(cfg.S3End),
-			Credentials: credentials.NewStaticCredentials(
-				cfg.S3Access,
-				cfg.S3Secret,
-				"",
-			),
-		})
-		if err == nil && sessionInstance != nil {
-			svcInstance = s3.New(sessionInstance)
-		}
-	})
-
-	if svcInstance == nil {
-		return fmt.Errorf("S3 session is not initialized")
-	}
-	return err
+	// Sizes jika diisi, upload varian persegi (px) sebagai ganti file asli
+	Sizes []int
+	// OldFiles dihapus setelah upload berhasil
+	OldFiles []string
+	// Presets varian gambar (imaging) yang dibuat di job yang sama, URL-nya lihat PresetURLs
+	Presets []string
+	// FileID baris tabel files yang diupdate worker setelah upload selesai
+	FileID string
+	// ContentType, Size dan Checksum (SHA-256 hex) isi file, diisi StageUpload
+	ContentType string
+	Size        int64
+	Checksum    string
+	// OriginalName nama file untuk download kalau bukan dari multipart (File), mis. PDF hasil generate
+	OriginalName string
 }
 
 type FileUploadOptions struct {
@@ -77,35 +52,39 @@ type FileUploadOptions struct {
 	MaxSizeMB        *int64
 	AllowedMimeTypes []string
 	IsCompressToWebp *bool
+	Sizes            []int
+	// Presets nama preset varian gambar (imaging) untuk UploadFileWithVariants, diupload di samping file utama
+	// sebagai "<nama>_<preset>.<ext>"
+	Presets []string
+	// Resumable file yang gagal diupload bisa dilanjutkan dari part terakhir saat
+	// UploadFileFromPath dipanggil lagi (misal job retry), lihat uploadStream
+	Resumable bool
+	// OnProgress progress upload file yang di-stream (byte)
+	OnProgress func(uploaded, total int64)
+	// Scanner pemindai malware UploadFileWithVariants / UploadFileFromPath / ProcessStoredFile,
+	// wajib diisi: tanpa scanner file tidak diupload
+	Scanner scanner.Scanner
 }
 
-func ExtractFolderFromFilePath(filePath string) string {
+// MultipartSuffix akhiran file penanda multipart upload di samping file tmp, isinya "<key>\n<upload id>"
+const MultipartSuffix = ".multipart"
+
+func ExtractFolderFromFilePath(store storage.Storage, filePath string) string {
 	// If filePath is a URL (e.g. https://is3.***/bucket/folder/file.jpg)
 	if strings.HasPrefix(strings.ToLower(filePath), "http://") || strings.HasPrefix(strings.ToLower(filePath), "https://") {
-		parts := strings.Split(filePath, "/")
-		// Find bucket index (e.g. is3.***)
-		bucketIndex := -1
-		for i, part := range parts {
-			if strings.Contains(part, "is3") { // Adjust to your bucket pattern
-				bucketIndex = i + 1 // Bucket index is usually followed by bucket name
-				break
-			}
+		key, ok := store.Key(filePath)
+		if !ok || !strings.Contains(key, "/") {
+			return ""
 		}
-		if bucketIndex != -1 && bucketIndex+1 < len(parts)-1 {
-			// Take the single folder name right after the bucket
-			return parts[bucketIndex+1]
-		}
-		return ""
+		// Take the folder path after the bucket, e.g. "private/documents"
+		return path.Dir(key)
 	}
 	// If not a URL, use filepath.Dir as usual
 	return filepath.Dir(filePath)
 }
 
-// GenerateFileURLCompressed hanya buat URL file di S3
-func GenerateFileURLCompressed(folder string, image *multipart.FileHeader) (string, error) {
-	if err := InitS3Client(); err != nil {
-		return "", err
-	}
+// GenerateFileURLCompressed hanya buat URL file di storage
+func GenerateFileURLCompressed(store storage.Storage, folder string, image *multipart.FileHeader) (string, error) {
 	if folder == "" {
 		return "", fmt.Errorf("folder cannot be empty")
 	}
@@ -125,14 +104,7 @@ func GenerateFileURLCompressed(folder string, image *multipart.FileHeader) (stri
 	ext := ".webp" // default ke webp
 	key := fmt.Sprintf("%s/%d%s", strings.Trim(folder, "/"), timestamp, ext)
 
-	cfg := config.GetConfig()
-	endpoint := strings.TrimRight(cfg.S3End, "/")
-	if !strings.HasPrefix(endpoint, "http") {
-		endpoint = "https://" + endpoint
-	}
-
-	fileURL := fmt.Sprintf("%s/%s/%s", endpoint, cfg.S3Bucket, key)
-	return fileURL, nil
+	return store.URL(key), nil
 }
 
 func ValidateFileOptions(file *multipart.FileHeader, opts FileUploadOptions) error {
@@ -148,28 +120,35 @@ func ValidateFileOptions(file *multipart.FileHeader, opts FileUploadOptions) err
 	if opts.MaxSizeMB != nil && file.Size > *opts.MaxSizeMB*1024*1024 {
 		return apperror.New("BAD", fmt.Sprintf("file exceeds maximum size of %d MB", *opts.MaxSizeMB), 400, nil, string(debug.Stack()))
 	}
-	if len(opts.AllowedMimeTypes) > 0 {
-		contentType := file.Header.Get("Content-Type")
-		allowed := false
-		for _, t := range opts.AllowedMimeTypes {
-			if contentType == t {
-				allowed = true
-				break
-			}
-		}
-		if !allowed {
-			return apperror.New("BAD", fmt.Sprintf("file type %s is not allowed", contentType), 400, nil, string(debug.Stack()))
-		}
+	_, err := inspectFileHeader(file, opts.AllowedMimeTypes)
+	return err
+}
+
+// inspectFileHeader validasi isi file upload (filecheck.Inspect), error 400 kalau ditolak
+func inspectFileHeader(file *multipart.FileHeader, allowed []string) (filecheck.Result, error) {
+	src, err := file.Open()
+	if err != nil {
+		return filecheck.Result{}, fmt.Errorf("failed to open file: %w", err)
 	}
-	return nil
+	defer src.Close()
+
+	res, err := filecheck.Inspect(src, file.Filename, allowed...)
+	if err != nil {
+		return res, rejectError(err)
+	}
+	return res, nil
 }
 
-// UploadFile upload file ke S3, support compress ke WebP
-func UploadFile(ctx context.Context, file *multipart.FileHeader, opts FileUploadOptions) (string, error) {
-	if err := InitS3Client(); err != nil {
-		return "", err
+// rejectError file yang ditolak filecheck / imaging jadi error 400
+func rejectError(err error) error {
+	if errors.Is(err, filecheck.ErrRejected) || errors.Is(err, imaging.ErrTooLarge) {
+		return apperror.New("BAD", err.Error(), 400, nil, string(debug.Stack()))
 	}
+	return err
+}
 
+// UploadFile upload file ke storage, support compress ke WebP
+func UploadFile(ctx context.Context, store storage.Storage, file *multipart.FileHeader, opts FileUploadOptions) (string, error) {
 	if file == nil {
 		return "", fmt.Errorf("file cannot be nil")
 	}
@@ -182,19 +161,10 @@ func UploadFile(ctx context.Context, file *multipart.FileHeader, opts FileUpload
 		return "", fmt.Errorf("file exceeds maximum size of %d MB", *opts.MaxSizeMB)
 	}
 
-	// Validasi MIME
-	if len(opts.AllowedMimeTypes) > 0 {
-		contentType := file.Header.Get("Content-Type")
-		allowed := false
-		for _, t := range opts.AllowedMimeTypes {
-			if contentType == t {
-				allowed = true
-				break
-			}
-		}
-		if !allowed {
-			return "", apperror.New("BAD", fmt.Sprintf("file type %s is not allowed", contentType), 400, nil, string(debug.Stack()))
-		}
+	// Validasi isi file, Content-Type dari client tidak dipakai
+	checked, err := inspectFileHeader(file, opts.AllowedMimeTypes)
+	if err != nil {
+		return "", err
 	}
 
 	// Open file
@@ -204,210 +174,381 @@ func UploadFile(ctx context.Context, file *multipart.FileHeader, opts FileUpload
 	}
 	defer src.Close()
 
+	contentType := checked.ContentType
+	filename := filepath.Base(opts.NameFile)
+
+	// File yang tidak perlu diproses di-stream tanpa dibaca ke memori
+	if !needsDecode(contentType, opts) {
+		key := fmt.Sprintf("%s/%s", strings.Trim(opts.Folder, "/"), filename)
+		_, err := storage.PutStream(ctx, store, key, src, file.Size, storage.MultipartOptions{
+			ContentType: contentType,
+			OnProgress:  opts.OnProgress,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to upload file: %w", err)
+		}
+		fileURL := store.URL(key)
+		logger.L().Printf("[UploadFile] successfully streamed file: %s", fileURL)
+		return fileURL, nil
+	}
+
 	// Read file into bytes
 	data, err := io.ReadAll(src)
 	if err != nil {
 		return "", fmt.Errorf("failed to read file: %w", err)
 	}
 
-	contentType := file.Header.Get("Content-Type")
-	filename := filepath.Base(opts.NameFile)
-
 	// Kompres ke WebP jika diminta
 	if opts.IsCompressToWebp != nil && *opts.IsCompressToWebp {
-		img, _, err := image.Decode(bytes.NewReader(data))
+		img, _, err := imaging.Decode(bytes.NewReader(data))
 		if err != nil {
-			return "", fmt.Errorf("failed to decode image: %w", err)
+			return "", err
 		}
 
-		buf, err := webp.EncodeRGB(img, 80)
-		if err != nil {
-			return "", fmt.Errorf("failed to encode webp: %w", err)
+		var buf bytes.Buffer
+		if err := imaging.Encode(&buf, img, imaging.FormatWebP, imaging.DefaultQuality()); err != nil {
+			return "", err
 		}
 
-		data = buf
+		data = buf.Bytes()
 		contentType = "image/webp"
 		filename = strings.TrimSuffix(filename, filepath.Ext(filename)) + ".webp"
 
 		logger.L().Printf("[UploadFile] compressed to webp, final size: %d bytes", len(data))
-	} else {
-		// Pastikan kalau bukan webp tetap sesuai ext
-		switch contentType {
-		case "image/png":
-			img, err := png.Decode(bytes.NewReader(data))
-			if err == nil {
-				var buf bytes.Buffer
-				_ = png.Encode(&buf, img)
-				data = buf.Bytes()
-			}
-		case "image/jpeg":
-			img, err := jpeg.Decode(bytes.NewReader(data))
-			if err == nil {
-				var buf bytes.Buffer
-				_ = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
-				data = buf.Bytes()
-			}
-		}
+	} else if data, err = sanitizeImage(data, contentType); err != nil {
+		return "", err
 	}
 
 	// Buat key relatif
 	key := fmt.Sprintf("%s/%s", strings.Trim(opts.Folder, "/"), filename)
 
-	// Upload ke S3
-	cfg := config.GetConfig()
-	_, err = svcInstance.PutObject(&s3.PutObjectInput{
-		Bucket:      aws.String(cfg.S3Bucket),
-		Key:         aws.String(key),
-		Body:        bytes.NewReader(data),
-		ContentType: aws.String(contentType),
-		ACL:         aws.String("public-read"),
-	})
+	// Upload ke storage
+	_, err = store.Put(ctx, key, bytes.NewReader(data), storage.PutOptions{ContentType: contentType})
 	if err != nil {
 		return "", fmt.Errorf("failed to upload file: %w", err)
 	}
 
 	// Generate URL publik
-	endpoint := strings.TrimRight(cfg.S3End, "/")
-	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
-		endpoint = "https://" + endpoint
-	}
-	fileURL := fmt.Sprintf("%s/%s/%s", endpoint, cfg.S3Bucket, key)
+	fileURL := store.URL(key)
 
 	logger.L().Printf("[UploadFile] successfully uploaded file: %s", fileURL)
 	return fileURL, nil
 }
 
-func UploadFileFromPath(ctx context.Context, filePath string, opts FileUploadOptions) error {
-	if err := InitS3Client(); err != nil {
-		return err
+func UploadFileFromPath(ctx context.Context, store storage.Storage, filePath string, opts FileUploadOptions) error {
+	_, err := UploadFileWithVariants(ctx, store, filePath, opts)
+	return err
+}
+
+// UploadFileWithVariants sama dengan UploadFileFromPath, ditambah varian dari opts.Presets
+// (atau opts.Sizes). Gambar di-decode sekali untuk semua varian. Mengembalikan nama preset -> URL.
+func UploadFileWithVariants(ctx context.Context, store storage.Storage, filePath string, opts FileUploadOptions) (map[string]string, error) {
+	presets, err := imaging.Resolve(opts.Presets)
+	if err != nil {
+		return nil, apperror.New("BAD", err.Error(), 400, nil, string(debug.Stack()))
 	}
 
 	if filePath == "" {
-		return fmt.Errorf("filePath cannot be empty")
+		return nil, fmt.Errorf("filePath cannot be empty")
 	}
 	if opts.NameFile == "" {
-		return fmt.Errorf("NameFile cannot be empty")
+		return nil, fmt.Errorf("NameFile cannot be empty")
 	}
 
 	// open file from local path
 	f, err := os.Open(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to open file: %w", err)
+		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 	defer f.Close()
 
 	// get size
 	fi, err := f.Stat()
 	if err != nil {
-		return fmt.Errorf("failed to stat file: %w", err)
+		return nil, fmt.Errorf("failed to stat file: %w", err)
 	}
 	fileSize := fi.Size()
 
 	// Validasi size
 	if opts.MaxSizeMB != nil && fileSize > *opts.MaxSizeMB*1024*1024 {
-		return fmt.Errorf("file exceeds maximum size of %d MB", *opts.MaxSizeMB)
+		return nil, fmt.Errorf("file exceeds maximum size of %d MB", *opts.MaxSizeMB)
+	}
+
+	// Validasi isi file (magic bytes vs ekstensi file asal, polyglot, dimensi).
+	// Error dibiarkan membungkus filecheck.ErrRejected supaya job tidak di-retry.
+	checked, err := filecheck.Inspect(f, filePath, opts.AllowedMimeTypes...)
+	if err != nil {
+		return nil, err
 	}
+	contentType := checked.ContentType
 
-	// detect MIME
-	buf := make([]byte, 512)
-	n, _ := io.ReadFull(f, buf)
-	contentType := http.DetectContentType(buf[:n])
-	// reset cursor
-	if _, err := f.Seek(0, io.SeekStart); err != nil {
-		return fmt.Errorf("failed to rewind file: %w", err)
+	// Scan malware sebelum apa pun diupload, file terinfeksi dikarantina
+	if err := scanFile(ctx, opts.Scanner, f, opts.NameFile); err != nil {
+		return nil, err
 	}
 
-	// Validasi MIME
-	if len(opts.AllowedMimeTypes) > 0 {
-		allowed := false
-		for _, t := range opts.AllowedMimeTypes {
-			if contentType == t {
-				allowed = true
-				break
-			}
-		}
-		if !allowed {
-			return apperror.New("BAD", fmt.Sprintf("file type %s is not allowed", contentType), 400, nil, string(debug.Stack()))
+	filename := filepath.Base(opts.NameFile)
+
+	// File yang tidak perlu diproses (PDF, video, ...) di-stream per part
+	if !needsDecode(contentType, opts) {
+		key := fmt.Sprintf("%s/%s", strings.Trim(opts.Folder, "/"), filename)
+		if err := uploadStream(ctx, store, f, fileSize, key, contentType, opts); err != nil {
+			return nil, fmt.Errorf("failed to upload file: %w", err)
 		}
+		logger.L().Printf("[UploadFileFromPath] successfully streamed file: %s", store.URL(key))
+		return nil, nil
 	}
 
 	// Read full file
 	data, err := io.ReadAll(f)
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	filename := filepath.Base(opts.NameFile)
+	// Upload varian ukuran jika diminta
+	if len(opts.Sizes) > 0 {
+		img, _, err := imaging.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		// varian dicatat di baris files supaya ikut dihapus bersama file-nya
+		return uploadPresets(ctx, store, img, opts, squarePresets(opts.Sizes))
+	}
+
+	var variants map[string]string
+	if len(presets) > 0 {
+		img, _, err := imaging.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if variants, err = uploadPresets(ctx, store, img, opts, presets); err != nil {
+			return nil, err
+		}
+	}
 
 	// Kompres ke WebP jika diminta
 	if opts.IsCompressToWebp != nil && *opts.IsCompressToWebp {
-		img, _, err := image.Decode(bytes.NewReader(data))
+		img, _, err := imaging.Decode(bytes.NewReader(data))
 		if err != nil {
-			return fmt.Errorf("failed to decode image: %w", err)
+			return nil, err
 		}
 
-		buf, err := webp.EncodeRGB(img, 10)
-		if err != nil {
-			return fmt.Errorf("failed to encode webp: %w", err)
+		var buf bytes.Buffer
+		if err := imaging.Encode(&buf, img, imaging.FormatWebP, imaging.DefaultQuality()); err != nil {
+			return nil, err
 		}
 
-		data = buf
+		data = buf.Bytes()
 		contentType = "image/webp"
 		filename = strings.TrimSuffix(filename, filepath.Ext(filename)) + ".webp"
 
 		logger.L().Printf("[UploadFileFromPath] compressed to webp, final size: %d bytes", len(data))
-	} else {
-		// Pastikan kalau bukan webp tetap sesuai ext
-		switch contentType {
-		case "image/png":
-			img, err := png.Decode(bytes.NewReader(data))
-			if err == nil {
-				var buf bytes.Buffer
-				_ = png.Encode(&buf, img)
-				data = buf.Bytes()
-			}
-		case "image/jpeg":
-			img, err := jpeg.Decode(bytes.NewReader(data))
-			if err == nil {
-				var buf bytes.Buffer
-				_ = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
-				data = buf.Bytes()
-			}
-		}
+	} else if data, err = sanitizeImage(data, contentType); err != nil {
+		return nil, err
 	}
 
 	// Buat key relatif
 	key := fmt.Sprintf("%s/%s", strings.Trim(opts.Folder, "/"), filename)
 
-	// Upload ke S3
-	cfg := config.GetConfig()
-	_, err = svcInstance.PutObject(&s3.PutObjectInput{
-		Bucket:      aws.String(cfg.S3Bucket),
-		Key:         aws.String(key),
-		Body:        bytes.NewReader(data),
-		ContentType: aws.String(contentType),
-		ACL:         aws.String("public-read"),
+	// Upload ke storage
+	_, err = store.Put(ctx, key, bytes.NewReader(data), storage.PutOptions{ContentType: contentType})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	logger.L().Printf("[UploadFileFromPath] successfully uploaded file: %s", store.URL(key))
+	return variants, nil
+}
+
+// sanitizeImage buang metadata (EXIF / GPS, XMP, IPTC) dari gambar yang tidak
+// dikonversi ke WebP. PNG dan JPEG di-encode ulang (orientasi EXIF ikut diterapkan),
+// GIF dan WebP dibersihkan tanpa encode ulang supaya animasi tetap utuh.
+func sanitizeImage(data []byte, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+	switch contentType {
+	case "image/png":
+		img, _, err := imaging.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if err := imaging.Encode(&buf, img, imaging.FormatPNG, 0); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case "image/jpeg":
+		img, _, err := imaging.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if err := imaging.Encode(&buf, img, imaging.FormatJPEG, 90); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case "image/gif", "image/webp":
+		return imaging.StripMetadata(data)
+	}
+	return data, nil
+}
+
+// needsDecode file harus dibaca utuh untuk di-decode (WebP, varian, buang metadata)
+func needsDecode(contentType string, opts FileUploadOptions) bool {
+	if len(opts.Sizes) > 0 || len(opts.Presets) > 0 || (opts.IsCompressToWebp != nil && *opts.IsCompressToWebp) {
+		return true
+	}
+	// gambar selalu dibersihkan dari metadata (sanitizeImage)
+	return strings.HasPrefix(contentType, "image/")
+}
+
+// uploadStream upload file per part dengan memori konstan. Upload id multipart disimpan
+// di "<path>.multipart" supaya pemanggilan berikutnya melanjutkan part yang belum
+// terupload. Tanpa opts.Resumable multipart upload di-abort saat gagal.
+func uploadStream(ctx context.Context, store storage.Storage, f *os.File, size int64, key, contentType string, opts FileUploadOptions) error {
+	marker := f.Name() + MultipartSuffix
+	uploadID := readMultipartMarker(marker, key)
+
+	_, err := storage.PutStream(ctx, store, key, f, size, storage.MultipartOptions{
+		ContentType: contentType,
+		UploadID:    uploadID,
+		Resumable:   opts.Resumable,
+		OnProgress:  opts.OnProgress,
+		OnStart: func(id string) {
+			if !opts.Resumable || id == uploadID {
+				return
+			}
+			if err := os.WriteFile(marker, []byte(key+"\n"+id), 0644); err != nil {
+				logger.L().Warnf("[uploadStream] failed to save upload id for %s: %v", key, err)
+			}
+		},
 	})
+	if err == nil || !opts.Resumable {
+		os.Remove(marker)
+	}
+	return err
+}
+
+// readMultipartMarker upload id yang tersimpan untuk key, kosong kalau tidak ada
+func readMultipartMarker(path, key string) string {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("failed to upload file: %w", err)
+		return ""
+	}
+	savedKey, uploadID, ok := strings.Cut(string(data), "\n")
+	if !ok || savedKey != key {
+		return ""
 	}
+	return uploadID
+}
 
-	// Generate URL publik
-	endpoint := strings.TrimRight(cfg.S3End, "/")
-	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
-		endpoint = "https://" + endpoint
+// ProcessStoredFile proses file yang sudah ada di storage (misal hasil presigned upload)
+// lewat pipeline UploadFileWithVariants. Object asal tidak dihapus.
+func ProcessStoredFile(ctx context.Context, store storage.Storage, key string, opts FileUploadOptions) (map[string]string, error) {
+	src, _, err := store.Get(ctx, key)
+	if err != nil {
+		return nil, err
 	}
-	fileURL := fmt.Sprintf("%s/%s/%s", endpoint, cfg.S3Bucket, key)
+	defer src.Close()
 
-	logger.L().Printf("[UploadFileFromPath] successfully uploaded file: %s", fileURL)
-	return nil
+	if err := os.MkdirAll(TmpDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create tmp dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(TmpDir, "stored-*"+filepath.Ext(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tmp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, src)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", key, err)
+	}
+
+	return UploadFileWithVariants(ctx, store, tmp.Name(), opts)
 }
 
-// GenerateFileURL returns a public URL for a file (without uploading)
-func GenerateFileURL(folder string, image *multipart.FileHeader) (string, error) {
-	if err := InitS3Client(); err != nil {
-		return "", err
+// FileKey key storage dari URL file, false kalau URL bukan milik store
+func FileKey(store storage.Storage, fileURL string) (string, bool) {
+	return store.Key(fileURL)
+}
+
+// ContentURL URL content-addressed untuk fileURL: nama file diganti checksum isinya,
+// folder dan ekstensi tetap ("<folder>/<sha256>.<ext>"). Isi yang sama selalu di key yang sama.
+func ContentURL(store storage.Storage, fileURL, checksum string) string {
+	key, ok := store.Key(fileURL)
+	if !ok || checksum == "" {
+		return fileURL
 	}
+	return store.URL(path.Join(path.Dir(key), checksum+path.Ext(key)))
+}
+
+// VariantURL menyisipkan suffix ukuran sebelum ekstensi, misal avatar.webp -> avatar_64.webp
+func VariantURL(fileURL string, size int) string {
+	ext := filepath.Ext(fileURL)
+	return fmt.Sprintf("%s_%d%s", strings.TrimSuffix(fileURL, ext), size, ext)
+}
+
+// PresetURL URL / key varian preset, misal cover.png + thumbnail -> cover_thumbnail.webp
+func PresetURL(fileURL string, p imaging.Preset) string {
+	return fmt.Sprintf("%s_%s%s", strings.TrimSuffix(fileURL, filepath.Ext(fileURL)), p.Name, p.Format.Ext())
+}
+
+// PresetURLs URL semua varian fileURL untuk nama preset, sebelum job selesai
+func PresetURLs(fileURL string, names []string) (map[string]string, error) {
+	presets, err := imaging.Resolve(names)
+	if err != nil {
+		return nil, err
+	}
+	urls := make(map[string]string, len(presets))
+	for _, p := range presets {
+		urls[p.Name] = PresetURL(fileURL, p)
+	}
+	return urls, nil
+}
+
+// squarePresets crop tengah ke persegi WebP untuk opsi Sizes, nama preset = ukuran
+// supaya key-nya sama dengan VariantURL
+func squarePresets(sizes []int) []imaging.Preset {
+	presets := make([]imaging.Preset, 0, len(sizes))
+	for _, size := range sizes {
+		presets = append(presets, imaging.Preset{
+			Name:    strconv.Itoa(size),
+			Width:   size,
+			Height:  size,
+			Mode:    imaging.ModeCrop,
+			Format:  imaging.FormatWebP,
+			Enlarge: true,
+		})
+	}
+	return presets
+}
+
+// uploadPresets encode dan upload img untuk tiap preset, mengembalikan nama preset -> URL
+func uploadPresets(ctx context.Context, store storage.Storage, img image.Image, opts FileUploadOptions, presets []imaging.Preset) (map[string]string, error) {
+	name := filepath.Base(opts.NameFile)
+	urls := make(map[string]string, len(presets))
+
+	for _, p := range presets {
+		buf, err := imaging.Process(img, p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process variant %s: %w", p.Name, err)
+		}
+
+		key := fmt.Sprintf("%s/%s", strings.Trim(opts.Folder, "/"), PresetURL(name, p))
+		_, err = store.Put(ctx, key, bytes.NewReader(buf), storage.PutOptions{ContentType: p.Format.ContentType()})
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload variant %s: %w", p.Name, err)
+		}
+		urls[p.Name] = store.URL(key)
+
+		logger.L().Printf("[uploadPresets] uploaded %s (%d bytes)", key, len(buf))
+	}
+	return urls, nil
+}
+
+// GenerateFileURL returns a public URL for a file (without uploading)
+func GenerateFileURL(store storage.Storage, folder string, image *multipart.FileHeader) (string, error) {
 	if folder == "" {
 		return "", fmt.Errorf("folder cannot be empty")
 	}
@@ -426,23 +567,12 @@ func GenerateFileURL(folder string, image *multipart.FileHeader) (string, error)
 	ext := filepath.Ext(filename)
 	key := fmt.Sprintf("%s/%d%s", strings.Trim(folder, "/"), timestamp, ext)
 
-	cfg := config.GetConfig()
-	endpoint := strings.TrimRight(cfg.S3End, "/")
-	if !strings.HasPrefix(endpoint, "http") {
-		endpoint = "https://" + endpoint
-	}
-
-	fileURL := fmt.Sprintf("%s/%s/%s", endpoint, cfg.S3Bucket, key)
-	return fileURL, nil
+	return store.URL(key), nil
 }
 
-// DeleteFile deletes a file from S3 by its public URL
-func DeleteFile(ctx context.Context, fileURL string) error {
-	if err := InitS3Client(); err != nil {
-		return err
-	}
-
-	folder := ExtractFolderFromFilePath(fileURL)
+// DeleteFile deletes a file from storage by its public URL
+func DeleteFile(ctx context.Context, store storage.Storage, fileURL string) error {
+	folder := ExtractFolderFromFilePath(store, fileURL)
 	if folder == "example" {
 		return nil
 	}
@@ -452,32 +582,22 @@ func DeleteFile(ctx context.Context, fileURL string) error {
 	}
 
 	// Parse URL untuk mendapatkan key
-	parts := strings.Split(fileURL, "/")
-	if len(parts) < 2 {
-		return fmt.Errorf("invalid file URL format")
-	}
-
-	bucketName := config.GetConfig().S3Bucket
-	urlBucketIndex := -1
-	for i, part := range parts {
-		if part == bucketName {
-			urlBucketIndex = i
-			break
-		}
-	}
-
-	if urlBucketIndex == -1 || urlBucketIndex >= len(parts)-1 {
+	key, ok := store.Key(fileURL)
+	if !ok {
 		return fmt.Errorf("could not find valid key in URL")
 	}
 
-	key := strings.Join(parts[urlBucketIndex+1:], "/")
-
-	_, err := svcInstance.DeleteObject(&s3.DeleteObjectInput{
-		Bucket: aws.String(bucketName),
-		Key:    aws.String(key),
-	})
-	if err != nil {
+	if err := store.Delete(ctx, key); err != nil {
 		return fmt.Errorf("failed to delete file: %w", err)
 	}
 	return nil
 }
+
+// StatFile metadata object di storage dari URL publiknya
+func StatFile(ctx context.Context, store storage.Storage, fileURL string) (storage.Object, error) {
+	key, ok := store.Key(fileURL)
+	if !ok {
+		return storage.Object{}, fmt.Errorf("could not find valid key in URL")
+	}
+	return store.Stat(ctx, key)
+}
@@ -0,0 +1,134 @@
+package fileUploader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/filecheck"
+
+	"github.com/nrednav/cuid2"
+)
+
+const (
+	// UploadJob nama job upload file dari tmp (stream upload_jobs)
+	UploadJob = "upload"
+	// PDFUploadJob nama job upload PDF yang digenerate server (stream pdf_upload_jobs).
+	// Tidak di-Enqueue lagi (lihat StageLocalFile), handler-nya tetap ada untuk job lama di antrian.
+	PDFUploadJob = "pdf_upload"
+	// ProcessUploadJob nama job post-processing file yang diupload langsung ke storage (stream process_upload_jobs)
+	ProcessUploadJob = "process_upload"
+	// DeleteFileJob nama job hapus object file yang sudah tidak punya referensi (stream delete_file_jobs)
+	DeleteFileJob = "delete_file"
+
+	// TmpDir folder staging file sebelum diupload worker
+	TmpDir = "tmp"
+)
+
+// QueueUploadPDF payload job PDFUploadJob
+type QueueUploadPDF struct {
+	FilePath string `json:"file_path"`
+	Folder   string `json:"folder"`
+	Name     string `json:"name"`
+}
+
+// QueueProcessUpload payload job ProcessUploadJob
+type QueueProcessUpload struct {
+	// Key object hasil upload client
+	Key string `json:"key"`
+	// FilePath URL file hasil proses
+	FilePath         string `json:"file_path"`
+	IsCompressToWebp bool   `json:"is_compress_to_webp"`
+	Sizes            []int  `json:"sizes,omitempty"`
+	// Presets varian gambar (imaging) yang dibuat bersamaan
+	Presets []string `json:"presets,omitempty"`
+	// FileID baris tabel files yang diupdate worker setelah diproses
+	FileID string `json:"file_id,omitempty"`
+}
+
+// QueueDeleteFile payload job DeleteFileJob
+type QueueDeleteFile struct {
+	// FileID baris tabel files (sudah di-soft delete) yang object dan variannya dihapus
+	FileID string `json:"file_id"`
+}
+
+// StageLocalFile payload UploadJob untuk file yang digenerate server di path (sudah di TmpDir),
+// diupload worker ke fileURL. Isi file diperiksa dan di-hash seperti StageUpload, nama
+// file di path dipakai sebagai OriginalName.
+func StageLocalFile(path, fileURL string) (QueueUploadFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return QueueUploadFile{}, apperror.New("fileUploader", "StageLocalFile", 500, err, "failed to open file")
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	res, err := filecheck.Inspect(io.TeeReader(f, hash), path)
+	if err != nil {
+		return QueueUploadFile{}, apperror.New("fileUploader", "StageLocalFile", 500, err, "failed to inspect file")
+	}
+
+	compress := false
+	return QueueUploadFile{
+		FilePath:         fileURL,
+		IsCompressToWebp: &compress,
+		FilePathTmp:      &path,
+		ContentType:      res.ContentType,
+		Size:             res.Size,
+		Checksum:         hex.EncodeToString(hash.Sum(nil)),
+		OriginalName:     filepath.Base(path),
+	}, nil
+}
+
+// StageUpload validasi isi payload.File (filecheck) lalu simpan ke TmpDir supaya bisa
+// diproses worker, FilePathTmp diisi dengan path file tmp, ContentType / Size / Checksum
+// dengan hasil pemeriksaan isi file
+func StageUpload(payload QueueUploadFile) (QueueUploadFile, error) {
+	if payload.File == nil {
+		return payload, apperror.New("fileUploader", "StageUpload", 400, nil, "QueueUploadFile.File cannot be nil")
+	}
+
+	if err := os.MkdirAll(TmpDir, 0755); err != nil {
+		return payload, apperror.New("fileUploader", "StageUpload", 500, err, "failed to create tmp dir")
+	}
+
+	src, err := payload.File.Open()
+	if err != nil {
+		return payload, apperror.New("fileUploader", "StageUpload", 500, err, "failed to open multipart file")
+	}
+	defer src.Close()
+
+	// Nama file unik di tmp dengan ekstensi asli
+	fileName := filepath.Join(TmpDir, fmt.Sprintf("%s_%d%s", cuid2.Generate(), time.Now().UnixNano(), filepath.Ext(payload.File.Filename)))
+	dst, err := os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return payload, apperror.New("fileUploader", "StageUpload", 500, err, "failed to create tmp file")
+	}
+	// Isi file divalidasi dan di-hash sambil ditulis ke tmp, file yang ditolak tidak sampai ke worker
+	hash := sha256.New()
+	res, err := filecheck.Inspect(io.TeeReader(src, io.MultiWriter(dst, hash)), payload.File.Filename)
+	if err != nil {
+		dst.Close()
+		os.Remove(fileName)
+		if errors.Is(err, filecheck.ErrRejected) {
+			return payload, rejectError(err)
+		}
+		return payload, apperror.New("fileUploader", "StageUpload", 500, err, "failed to write tmp file")
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(fileName)
+		return payload, apperror.New("fileUploader", "StageUpload", 500, err, "failed to write tmp file")
+	}
+
+	payload.FilePathTmp = &fileName
+	payload.ContentType = res.ContentType
+	payload.Size = res.Size
+	payload.Checksum = hex.EncodeToString(hash.Sum(nil))
+	return payload, nil
+}
@@ -0,0 +1,105 @@
+package fileUploader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"template-golang/pkg/config"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/scanner"
+
+	"github.com/goccy/go-json"
+)
+
+// quarantineInfo metadata file karantina, disimpan di "<file>.json"
+type quarantineInfo struct {
+	Signature     string    `json:"signature"`
+	Source        string    `json:"source"`
+	Target        string    `json:"target"`
+	QuarantinedAt time.Time `json:"quarantined_at"`
+}
+
+// scanFile scan isi f dengan s sebelum diupload. File terinfeksi dipindah ke QUARANTINE_DIR
+// dan error-nya membungkus scanner.ErrInfected. Cursor f dikembalikan ke awal.
+func scanFile(ctx context.Context, s scanner.Scanner, f *os.File, target string) error {
+	if s == nil {
+		return errors.New("no malware scanner configured")
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind file: %w", err)
+	}
+	res, err := s.Scan(ctx, f)
+	if err != nil {
+		return fmt.Errorf("failed to scan file: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind file: %w", err)
+	}
+	if res.Clean {
+		return nil
+	}
+
+	logger.L().Warnf("[scanFile] %s is infected (%s), moving to quarantine", f.Name(), res.Signature)
+	if err := quarantine(f.Name(), target, res.Signature); err != nil {
+		logger.L().Errorf("[scanFile] failed to quarantine %s: %v", f.Name(), err)
+	}
+	return res.Err()
+}
+
+// quarantine pindahkan file ke QUARANTINE_DIR (hanya bisa dibaca owner proses)
+func quarantine(path, target, signature string) error {
+	dir := config.GetConfig().QuarantineDir
+	if dir == "" {
+		dir = "quarantine"
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	dst := filepath.Join(dir, fmt.Sprintf("%d_%s", time.Now().UnixNano(), filepath.Base(path)))
+	if err := os.Rename(path, dst); err != nil {
+		// beda filesystem: salin lalu hapus
+		if err := copyFile(path, dst); err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if err := os.Chmod(dst, 0600); err != nil {
+		return err
+	}
+
+	info, err := json.Marshal(quarantineInfo{
+		Signature:     signature,
+		Source:        path,
+		Target:        target,
+		QuarantinedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst+".json", info, 0600)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
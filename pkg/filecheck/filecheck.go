@@ -0,0 +1,146 @@
+// Package filecheck validasi isi file upload: content type asli dari magic bytes,
+// dicocokkan dengan ekstensi, tolak file polyglot dan gambar yang terlalu besar.
+// Content-Type dari client tidak pernah dipercaya.
+package filecheck
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"template-golang/pkg/imaging"
+)
+
+var (
+	// ErrRejected induk semua error validasi, file tidak boleh disimpan
+	ErrRejected = errors.New("file rejected")
+	// ErrMismatch isi file tidak sesuai ekstensinya
+	ErrMismatch = fmt.Errorf("%w: content does not match extension", ErrRejected)
+	// ErrPolyglot file juga valid sebagai format lain (HTML, script, PDF, ZIP)
+	ErrPolyglot = fmt.Errorf("%w: file contains another format", ErrRejected)
+	// ErrNotAllowed content type tidak ada di daftar yang diizinkan
+	ErrNotAllowed = fmt.Errorf("%w: file type is not allowed", ErrRejected)
+)
+
+// extensions ekstensi yang dikenal -> content type hasil sniff yang sah
+var extensions = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".pdf":  "application/pdf",
+	".mp4":  "video/mp4",
+	".webm": "video/webm",
+}
+
+// Result hasil pemeriksaan file
+type Result struct {
+	// ContentType content type asli dari magic bytes
+	ContentType string
+	Size        int64
+	// Width / Height dimensi gambar, 0 untuk selain gambar
+	Width  int
+	Height int
+}
+
+// IsImage file gambar yang bisa diproses pkg/imaging
+func (r Result) IsImage() bool {
+	return strings.HasPrefix(r.ContentType, "image/")
+}
+
+// Inspect baca r sampai habis dengan memori konstan: sniff content type dari 512
+// byte pertama, cocokkan dengan ekstensi filename, scan signature format lain,
+// dan untuk gambar cek dimensi (imaging.CheckConfig) tanpa decode pixel.
+// allowed kosong berarti semua content type boleh.
+func Inspect(r io.Reader, filename string, allowed ...string) (Result, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return Result{}, fmt.Errorf("failed to read file: %w", err)
+	}
+	head = head[:n]
+	if n == 0 {
+		return Result{}, fmt.Errorf("%w: file is empty", ErrRejected)
+	}
+
+	res := Result{ContentType: Sniff(head)}
+	if err := checkExtension(filename, res.ContentType); err != nil {
+		return res, err
+	}
+	if len(allowed) > 0 && !contains(allowed, res.ContentType) {
+		return res, fmt.Errorf("%w: %s", ErrNotAllowed, res.ContentType)
+	}
+
+	// head ditulis manual setelah jenis file diketahui, sisanya lewat tee
+	s := &scanner{image: res.IsImage(), pdf: res.ContentType == "application/pdf"}
+	s.Write(head)
+	tee := io.TeeReader(r, s)
+
+	if res.IsImage() {
+		cfg, _, err := image.DecodeConfig(io.MultiReader(bytes.NewReader(head), tee))
+		if err != nil {
+			return res, fmt.Errorf("%w: invalid image: %v", ErrRejected, err)
+		}
+		if err := imaging.CheckConfig(cfg); err != nil {
+			return res, fmt.Errorf("%w: %v", ErrRejected, err)
+		}
+		res.Width, res.Height = cfg.Width, cfg.Height
+	}
+
+	if _, err := io.Copy(io.Discard, tee); err != nil {
+		return res, fmt.Errorf("failed to read file: %w", err)
+	}
+	res.Size = s.size
+	if err := s.result(); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// Sniff content type dari magic bytes tanpa parameter (charset dll.)
+func Sniff(head []byte) string {
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return "application/octet-stream"
+	}
+	return contentType
+}
+
+// checkExtension ekstensi yang dikenal harus cocok dengan isi, dan isi yang dikenal
+// (gambar / PDF / video) tidak boleh disamarkan dengan ekstensi lain
+func checkExtension(filename, contentType string) error {
+	ext := strings.ToLower(filepath.Ext(filename))
+	want, known := extensions[ext]
+	switch {
+	case known && want != contentType:
+		return fmt.Errorf("%w: %s is %s", ErrMismatch, ext, contentType)
+	case !known && isKnownType(contentType):
+		return fmt.Errorf("%w: %s is %s", ErrMismatch, ext, contentType)
+	}
+	return nil
+}
+
+func isKnownType(contentType string) bool {
+	for _, t := range extensions {
+		if t == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v || (item == "image/jpg" && v == "image/jpeg") {
+			return true
+		}
+	}
+	return false
+}
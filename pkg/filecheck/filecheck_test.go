@@ -0,0 +1,149 @@
+package filecheck
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"testing"
+	"testing/iotest"
+
+	"template-golang/pkg/config"
+)
+
+func TestMain(m *testing.M) {
+	// batas dimensi gambar (imaging.CheckConfig) dibaca dari config
+	config.LoadConfig()
+	os.Exit(m.Run())
+}
+
+func pngBytes(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		img.Set(x, 0, color.RGBA{R: uint8(x), A: 255})
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// pngWithSize PNG valid yang header IHDR-nya mengklaim w x h (decompression bomb)
+func pngWithSize(t *testing.T, w, h uint32) []byte {
+	t.Helper()
+	data := pngBytes(t, 1, 1)
+	// signature 8 byte, lalu chunk IHDR: panjang(4) tipe(4) data(13) crc(4)
+	ihdr := data[8+4 : 8+4+4+13]
+	binary.BigEndian.PutUint32(ihdr[4:8], w)
+	binary.BigEndian.PutUint32(ihdr[8:12], h)
+	binary.BigEndian.PutUint32(data[8+4+4+13:], crc32.ChecksumIEEE(ihdr))
+	return data
+}
+
+func pdfBytes(body string) []byte {
+	return []byte("%PDF-1.4\n1 0 obj\n<< /Type /Catalog >>\nendobj\n" + body + "\n%%EOF\n")
+}
+
+// zipEOCDRecord end of central directory ZIP kosong (22 byte)
+func zipEOCDRecord() []byte {
+	return append([]byte("PK\x05\x06"), make([]byte, 18)...)
+}
+
+func join(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+func TestInspect(t *testing.T) {
+	pngData := pngBytes(t, 16, 8)
+	padding := bytes.Repeat([]byte{0}, 4096)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		filename string
+		allowed  []string
+		want     error
+		// contentType content type hasil sniff kalau lolos
+		contentType string
+	}{
+		{name: "png", data: pngData, filename: "photo.png", contentType: "image/png"},
+		{name: "png uppercase ext", data: pngData, filename: "PHOTO.PNG", contentType: "image/png"},
+		{name: "pdf", data: pdfBytes("stream\nBT /F1 12 Tf (Hello) Tj ET\nendstream"), filename: "doc.pdf", contentType: "application/pdf"},
+		// markup jauh di dalam PDF (setelah 1 KB) tidak dieksekusi browser
+		{name: "pdf with markup after head", data: join(pdfBytes(""), padding, []byte("<script>")), filename: "doc.pdf", contentType: "application/pdf"},
+		{name: "plain text", data: []byte("hello world"), filename: "notes.txt", contentType: "text/plain"},
+		{name: "allowed", data: pngData, filename: "a.png", allowed: []string{"image/png", "image/jpeg"}, contentType: "image/png"},
+
+		{name: "empty", data: nil, filename: "a.png", want: ErrRejected},
+		{name: "png as jpg", data: pngData, filename: "photo.jpg", want: ErrMismatch},
+		{name: "png without known ext", data: pngData, filename: "photo.txt", want: ErrMismatch},
+		{name: "pdf as png", data: pdfBytes(""), filename: "photo.png", want: ErrMismatch},
+		{name: "not allowed", data: pdfBytes(""), filename: "doc.pdf", allowed: []string{"image/png"}, want: ErrNotAllowed},
+		{name: "invalid image", data: join([]byte("\x89PNG\r\n\x1a\n"), []byte("garbage")), filename: "a.png", want: ErrRejected},
+		{name: "decompression bomb", data: pngWithSize(t, 50000, 50000), filename: "bomb.png", want: ErrRejected},
+
+		// polyglot
+		{name: "png with script", data: join(pngData, []byte("<script>alert(1)</script>")), filename: "a.png", want: ErrPolyglot},
+		{name: "png with php deep inside", data: join(pngData, padding, []byte("<?PHP system($_GET['c']); ?>")), filename: "a.png", want: ErrPolyglot},
+		{name: "png with svg", data: join(pngData, []byte("<svg onload=alert(1)>")), filename: "a.png", want: ErrPolyglot},
+		{name: "png with pdf header", data: join(pngData, []byte("%PDF-1.4")), filename: "a.png", want: ErrPolyglot},
+		{name: "gifar (png + zip)", data: join(pngData, zipEOCDRecord()), filename: "a.png", want: ErrPolyglot},
+		{name: "pdf + jar", data: join(pdfBytes(""), zipEOCDRecord()), filename: "doc.pdf", want: ErrPolyglot},
+		{name: "pdf with html in head", data: pdfBytes("<html><body>hi</body></html>"), filename: "doc.pdf", want: ErrPolyglot},
+		{name: "text with pdf header", data: []byte("hello %PDF-1.4 world"), filename: "notes.txt", want: ErrPolyglot},
+		{name: "html as text", data: []byte("<!DOCTYPE html><html><body>x</body></html>"), filename: "notes.txt", want: ErrPolyglot},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// satu byte per Read supaya signature pasti terpotong di antara chunk
+			for _, r := range []io.Reader{bytes.NewReader(tt.data), iotest.OneByteReader(bytes.NewReader(tt.data))} {
+				res, err := Inspect(r, tt.filename, tt.allowed...)
+				if tt.want != nil {
+					if !errors.Is(err, tt.want) {
+						t.Fatalf("Inspect(%s) error = %v, want %v", tt.filename, err, tt.want)
+					}
+					if !errors.Is(err, ErrRejected) {
+						t.Errorf("Inspect(%s) error %v does not wrap ErrRejected", tt.filename, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("Inspect(%s): %v", tt.filename, err)
+				}
+				if res.ContentType != tt.contentType {
+					t.Errorf("ContentType = %s, want %s", res.ContentType, tt.contentType)
+				}
+				if res.Size != int64(len(tt.data)) {
+					t.Errorf("Size = %d, want %d", res.Size, len(tt.data))
+				}
+			}
+		})
+	}
+}
+
+func TestInspectImageSize(t *testing.T) {
+	res, err := Inspect(bytes.NewReader(pngBytes(t, 16, 8)), "a.png")
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	if res.Width != 16 || res.Height != 8 || !res.IsImage() {
+		t.Errorf("Inspect = %+v, want 16x8 image", res)
+	}
+}
+
+// ZIP yang end of central directory-nya jauh sebelum akhir file bukan archive yang valid
+func TestInspectZipSignatureFarFromEnd(t *testing.T) {
+	data := join(pngBytes(t, 4, 4), zipEOCDRecord())
+	data = append(data, bytes.Repeat([]byte{0}, zipTail+1)...)
+	if _, err := Inspect(bytes.NewReader(data), "a.png"); err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+}
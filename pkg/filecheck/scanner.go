@@ -0,0 +1,92 @@
+package filecheck
+
+import (
+	"bytes"
+	"fmt"
+)
+
+const (
+	// headScan markup / header PDF di awal file dikenali browser dan PDF reader
+	headScan = 1024
+	// zipTail end of central directory ZIP ada di 22 byte + komentar (maks 64 KB) terakhir
+	zipTail = 22 + 65535
+	// maxSig panjang signature terpanjang, sisa chunk sebelumnya untuk match lintas chunk
+	maxSig = 9
+)
+
+var (
+	// markup yang membuat file dieksekusi sebagai HTML / script / PHP, dicari
+	// di seluruh isi gambar dan di awal file lain (huruf kecil)
+	markup = [][]byte{
+		[]byte("<?php"),
+		[]byte("<script"),
+		[]byte("<html"),
+		[]byte("<svg"),
+		[]byte("<iframe"),
+		[]byte("<!doctype"),
+		[]byte("<body"),
+	}
+	pdfHeader = []byte("%pdf-")
+	zipEOCD   = []byte("PK\x05\x06")
+)
+
+// scanner io.Writer yang mencari signature format lain di isi file
+type scanner struct {
+	image bool
+	pdf   bool
+
+	size int64
+	// carry byte terakhir chunk sebelumnya, untuk signature yang terpotong
+	carry []byte
+	found string
+	// lastEOCD posisi end of central directory ZIP terakhir
+	lastEOCD int64
+	seenEOCD bool
+}
+
+func (s *scanner) Write(p []byte) (int, error) {
+	// offset buf[0] di file
+	base := s.size - int64(len(s.carry))
+	buf := append(s.carry, p...)
+	s.size += int64(len(p))
+
+	if s.found == "" && (s.image || base < headScan) {
+		lower := bytes.ToLower(buf)
+		for _, sig := range markup {
+			if idx := bytes.Index(lower, sig); idx >= 0 && (s.image || base+int64(idx) < headScan) {
+				s.found = string(sig)
+				break
+			}
+		}
+		// %PDF- di 1 KB pertama cukup untuk dibuka PDF reader
+		if idx := bytes.Index(lower, pdfHeader); !s.pdf && idx >= 0 && base+int64(idx) < headScan {
+			s.found = "%PDF-"
+		}
+	}
+
+	for off := 0; ; {
+		idx := bytes.Index(buf[off:], zipEOCD)
+		if idx < 0 {
+			break
+		}
+		s.lastEOCD = base + int64(off+idx)
+		s.seenEOCD = true
+		off += idx + 1
+	}
+
+	keep := min(maxSig-1, len(buf))
+	s.carry = append(s.carry[:0:0], buf[len(buf)-keep:]...)
+	return len(p), nil
+}
+
+// result error kalau file ternyata polyglot
+func (s *scanner) result() error {
+	if s.found != "" {
+		return fmt.Errorf("%w: %s", ErrPolyglot, s.found)
+	}
+	// gambar / PDF yang juga bisa dibuka sebagai ZIP (GIFAR, PDF+JAR, dll.)
+	if (s.image || s.pdf) && s.seenEOCD && s.size-s.lastEOCD <= zipTail {
+		return fmt.Errorf("%w: zip archive", ErrPolyglot)
+	}
+	return nil
+}
@@ -0,0 +1,356 @@
+// Package gateway WebSocket gateway dengan channel subscription. Pesan
+// dijembatani lewat Redis pub/sub supaya client di instance API yang berbeda
+// tetap saling menerima pesan, presence disimpan di Redis sorted set.
+package gateway
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"template-golang/pkg/logger"
+	"template-golang/pkg/redisx"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/contrib/websocket"
+	"github.com/nrednav/cuid2"
+)
+
+const (
+	// RedisChannel channel Redis pub/sub yang membawa semua pesan gateway
+	RedisChannel = "ws"
+	// PresenceChannel channel gateway yang menerima daftar user online setiap ada perubahan
+	PresenceChannel = "presence"
+
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingInterval   = 30 * time.Second
+	maxMessageSize = 64 * 1024
+	sendBuffer     = 64
+)
+
+// Action aksi client pada channel
+type Action string
+
+const (
+	ActionSubscribe Action = "subscribe"
+	ActionPublish   Action = "publish"
+)
+
+// Identity user pemilik koneksi, dari JWT
+type Identity struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+	Role   string `json:"role"`
+}
+
+// Authorizer cek apakah user boleh subscribe / publish ke channel
+type Authorizer func(id Identity, channel string, action Action) bool
+
+// PresenceFilter user yang dicatat di presence, nil = semua user
+type PresenceFilter func(id Identity) bool
+
+// Frame pesan JSON antara client dan gateway
+type Frame struct {
+	// Type subscribe, unsubscribe, publish, ping (client)
+	// atau ack, error, message, pong (server)
+	Type    string          `json:"type"`
+	ID      string          `json:"id,omitempty"`
+	Channel string          `json:"channel,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+	From    *Identity       `json:"from,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	SentAt  *time.Time      `json:"sent_at,omitempty"`
+}
+
+// envelope pesan yang dikirim lewat Redis
+type envelope struct {
+	Channel string          `json:"channel"`
+	Data    json.RawMessage `json:"data"`
+	From    *Identity       `json:"from,omitempty"`
+	SentAt  time.Time       `json:"sent_at"`
+}
+
+// Gateway mengelola koneksi WebSocket di instance ini
+type Gateway struct {
+	client    *redisx.Client
+	authorize Authorizer
+	tracked   PresenceFilter
+
+	mu    sync.RWMutex
+	conns map[*Conn]struct{}
+}
+
+func New(client *redisx.Client, authorize Authorizer, tracked PresenceFilter) *Gateway {
+	return &Gateway{
+		client:    client,
+		authorize: authorize,
+		tracked:   tracked,
+		conns:     map[*Conn]struct{}{},
+	}
+}
+
+// Run subscribe ke RedisChannel dan jaga presence sampai ctx selesai
+func (g *Gateway) Run(ctx context.Context) {
+	go g.presenceLoop(ctx)
+
+	for ctx.Err() == nil {
+		msgs, err := g.client.Subscribe(ctx, RedisChannel)
+		if err != nil {
+			logger.L().Errorf("gateway: failed to subscribe: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+			continue
+		}
+
+	receive:
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-msgs:
+				if !ok {
+					break receive
+				}
+				var env envelope
+				if err := json.Unmarshal([]byte(msg.Payload), &env); err != nil {
+					logger.L().Warnf("gateway: invalid message: %v", err)
+					continue
+				}
+				g.dispatch(env)
+			}
+		}
+	}
+}
+
+// Publish kirim data ke channel dari server (tanpa cek Authorizer)
+func (g *Gateway) Publish(ctx context.Context, channel string, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return g.publish(ctx, envelope{Channel: channel, Data: raw, SentAt: time.Now().UTC()})
+}
+
+func (g *Gateway) publish(ctx context.Context, env envelope) error {
+	body, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	return g.client.Publish(ctx, RedisChannel, string(body))
+}
+
+func (g *Gateway) dispatch(env envelope) {
+	frame, err := json.Marshal(Frame{
+		Type:    "message",
+		Channel: env.Channel,
+		Data:    env.Data,
+		From:    env.From,
+		SentAt:  &env.SentAt,
+	})
+	if err != nil {
+		return
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for c := range g.conns {
+		if c.subscribed(env.Channel) {
+			c.enqueue(frame)
+		}
+	}
+}
+
+// Serve jalankan koneksi sampai client disconnect, dipanggil dari websocket.New
+func (g *Gateway) Serve(ws *websocket.Conn, id Identity) {
+	connID := cuid2.Generate()
+	c := &Conn{
+		id:       connID,
+		identity: id,
+		ws:       ws,
+		send:     make(chan []byte, sendBuffer),
+		done:     make(chan struct{}),
+		channels: map[string]bool{},
+	}
+	if g.tracked == nil || g.tracked(id) {
+		c.member = newPresenceMember(connID, id, time.Now().UTC())
+	}
+
+	ctx := context.Background()
+	g.mu.Lock()
+	g.conns[c] = struct{}{}
+	g.mu.Unlock()
+	g.join(ctx, c)
+
+	// koneksi dilepas library setelah Serve return, tunggu writeLoop selesai dulu
+	writerDone := make(chan struct{})
+	defer func() {
+		g.mu.Lock()
+		delete(g.conns, c)
+		g.mu.Unlock()
+		c.close()
+		<-writerDone
+		g.leave(ctx, c)
+	}()
+
+	go func() {
+		defer close(writerDone)
+		c.writeLoop()
+	}()
+	g.readLoop(ctx, c)
+}
+
+func (g *Gateway) readLoop(ctx context.Context, c *Conn) {
+	c.ws.SetReadLimit(maxMessageSize)
+	c.ws.SetReadDeadline(time.Now().Add(pongWait))
+	c.ws.SetPongHandler(func(string) error {
+		return c.ws.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, raw, err := c.ws.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				logger.L().Warnf("gateway: connection %s closed: %v", c.id, err)
+			}
+			return
+		}
+		c.ws.SetReadDeadline(time.Now().Add(pongWait))
+
+		var f Frame
+		if err := json.Unmarshal(raw, &f); err != nil {
+			c.reply(Frame{Type: "error", Error: "invalid frame"})
+			continue
+		}
+		g.handle(ctx, c, f)
+	}
+}
+
+func (g *Gateway) handle(ctx context.Context, c *Conn, f Frame) {
+	switch f.Type {
+	case "ping":
+		c.reply(Frame{Type: "pong", ID: f.ID})
+
+	case "subscribe":
+		if f.Channel == "" || !g.authorize(c.identity, f.Channel, ActionSubscribe) {
+			c.reply(Frame{Type: "error", ID: f.ID, Channel: f.Channel, Error: "forbidden"})
+			return
+		}
+		c.setSubscribed(f.Channel, true)
+		c.reply(Frame{Type: "ack", ID: f.ID, Channel: f.Channel})
+		if f.Channel == PresenceChannel {
+			g.sendPresence(ctx, c)
+		}
+
+	case "unsubscribe":
+		c.setSubscribed(f.Channel, false)
+		c.reply(Frame{Type: "ack", ID: f.ID, Channel: f.Channel})
+
+	case "publish":
+		if f.Channel == "" || !g.authorize(c.identity, f.Channel, ActionPublish) {
+			c.reply(Frame{Type: "error", ID: f.ID, Channel: f.Channel, Error: "forbidden"})
+			return
+		}
+		from := c.identity
+		if err := g.publish(ctx, envelope{Channel: f.Channel, Data: f.Data, From: &from, SentAt: time.Now().UTC()}); err != nil {
+			logger.L().Errorf("gateway: failed to publish to %s: %v", f.Channel, err)
+			c.reply(Frame{Type: "error", ID: f.ID, Channel: f.Channel, Error: "publish failed"})
+			return
+		}
+		c.reply(Frame{Type: "ack", ID: f.ID, Channel: f.Channel})
+
+	default:
+		c.reply(Frame{Type: "error", ID: f.ID, Error: "unknown frame type"})
+	}
+}
+
+// ================== CONN ==================
+
+// Conn satu koneksi WebSocket
+type Conn struct {
+	id       string
+	identity Identity
+	ws       *websocket.Conn
+	send     chan []byte
+	done     chan struct{}
+	once     sync.Once
+	// member entri presence koneksi ini, kosong kalau tidak dicatat
+	member string
+
+	mu       sync.RWMutex
+	channels map[string]bool
+}
+
+var errSlowConsumer = errors.New("send buffer full")
+
+func (c *Conn) subscribed(channel string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.channels[channel]
+}
+
+func (c *Conn) setSubscribed(channel string, on bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if on {
+		c.channels[channel] = true
+	} else {
+		delete(c.channels, channel)
+	}
+}
+
+func (c *Conn) reply(f Frame) {
+	body, err := json.Marshal(f)
+	if err != nil {
+		return
+	}
+	c.enqueue(body)
+}
+
+// enqueue antrikan frame, client yang terlalu lambat diputus
+func (c *Conn) enqueue(frame []byte) {
+	select {
+	case <-c.done:
+	case c.send <- frame:
+	default:
+		logger.L().Warnf("gateway: connection %s: %v, closing", c.id, errSlowConsumer)
+		c.close()
+	}
+}
+
+func (c *Conn) close() {
+	c.once.Do(func() {
+		close(c.done)
+	})
+}
+
+func (c *Conn) writeLoop() {
+	ticker := time.NewTicker(pingInterval)
+	defer func() {
+		ticker.Stop()
+		c.ws.Close()
+	}()
+
+	for {
+		select {
+		case <-c.done:
+			c.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(writeWait))
+			return
+		case frame := <-c.send:
+			c.ws.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.ws.WriteMessage(websocket.TextMessage, frame); err != nil {
+				c.close()
+				return
+			}
+		case <-ticker.C:
+			if err := c.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				c.close()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package gateway
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"template-golang/pkg/logger"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	// presenceKey sorted set koneksi aktif, score = waktu kedaluwarsa (ms)
+	presenceKey = "ws:presence"
+	// presenceTTL koneksi dianggap offline kalau tidak diperbarui selama ini (misal instance crash)
+	presenceTTL     = 90 * time.Second
+	presenceRefresh = 30 * time.Second
+)
+
+// Presence user yang sedang online
+type Presence struct {
+	UserID      string    `json:"user_id"`
+	Name        string    `json:"name"`
+	Role        string    `json:"role"`
+	Connections int       `json:"connections"`
+	Since       time.Time `json:"since"`
+}
+
+// presenceMember member sorted set presence, satu per koneksi
+type presenceMember struct {
+	ConnID string `json:"conn_id"`
+	Identity
+	ConnectedAt time.Time `json:"connected_at"`
+}
+
+func newPresenceMember(connID string, id Identity, connectedAt time.Time) string {
+	body, _ := json.Marshal(presenceMember{ConnID: connID, Identity: id, ConnectedAt: connectedAt})
+	return string(body)
+}
+
+func expiry() float64 {
+	return float64(time.Now().Add(presenceTTL).UnixMilli())
+}
+
+// Online daftar user yang online di semua instance
+func (g *Gateway) Online(ctx context.Context) ([]Presence, error) {
+	members, err := g.client.ZRangeByScore(ctx, presenceKey, strconv.FormatInt(time.Now().UnixMilli(), 10), "+inf")
+	if err != nil {
+		return nil, err
+	}
+
+	byUser := map[string]*Presence{}
+	for _, raw := range members {
+		var m presenceMember
+		if err := json.Unmarshal([]byte(raw), &m); err != nil {
+			continue
+		}
+		p, ok := byUser[m.UserID]
+		if !ok {
+			p = &Presence{UserID: m.UserID, Name: m.Name, Role: m.Role, Since: m.ConnectedAt}
+			byUser[m.UserID] = p
+		}
+		p.Connections++
+		if m.ConnectedAt.Before(p.Since) {
+			p.Since = m.ConnectedAt
+		}
+	}
+
+	online := make([]Presence, 0, len(byUser))
+	for _, p := range byUser {
+		online = append(online, *p)
+	}
+	sort.Slice(online, func(i, j int) bool { return online[i].Name < online[j].Name })
+	return online, nil
+}
+
+func (g *Gateway) join(ctx context.Context, c *Conn) {
+	if c.member == "" {
+		return
+	}
+	if err := g.client.ZAdd(ctx, presenceKey, expiry(), c.member); err != nil {
+		logger.L().Warnf("gateway: failed to track presence: %v", err)
+		return
+	}
+	g.broadcastPresence(ctx)
+}
+
+func (g *Gateway) leave(ctx context.Context, c *Conn) {
+	if c.member == "" {
+		return
+	}
+	if err := g.client.ZRem(ctx, presenceKey, c.member); err != nil {
+		logger.L().Warnf("gateway: failed to remove presence: %v", err)
+		return
+	}
+	g.broadcastPresence(ctx)
+}
+
+// broadcastPresence kirim daftar user online ke subscriber PresenceChannel di semua instance
+func (g *Gateway) broadcastPresence(ctx context.Context) {
+	online, err := g.Online(ctx)
+	if err != nil {
+		logger.L().Warnf("gateway: failed to read presence: %v", err)
+		return
+	}
+	if err := g.Publish(ctx, PresenceChannel, online); err != nil {
+		logger.L().Warnf("gateway: failed to publish presence: %v", err)
+	}
+}
+
+// sendPresence kirim daftar user online ke satu koneksi (saat baru subscribe)
+func (g *Gateway) sendPresence(ctx context.Context, c *Conn) {
+	online, err := g.Online(ctx)
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(online)
+	if err != nil {
+		return
+	}
+	now := time.Now().UTC()
+	c.reply(Frame{Type: "message", Channel: PresenceChannel, Data: data, SentAt: &now})
+}
+
+// presenceLoop perpanjang presence koneksi di instance ini dan buang koneksi
+// kedaluwarsa dari instance yang mati
+func (g *Gateway) presenceLoop(ctx context.Context) {
+	ticker := time.NewTicker(presenceRefresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		g.mu.RLock()
+		conns := make([]*Conn, 0, len(g.conns))
+		for c := range g.conns {
+			if c.member != "" {
+				conns = append(conns, c)
+			}
+		}
+		g.mu.RUnlock()
+
+		for _, c := range conns {
+			if err := g.client.ZAdd(ctx, presenceKey, expiry(), c.member); err != nil {
+				logger.L().Warnf("gateway: failed to refresh presence: %v", err)
+				break
+			}
+		}
+
+		n, err := g.client.ZRemRangeByScore(ctx, presenceKey, "-inf", strconv.FormatInt(time.Now().UnixMilli(), 10))
+		if err != nil {
+			logger.L().Warnf("gateway: failed to expire presence: %v", err)
+			continue
+		}
+		if n > 0 {
+			g.broadcastPresence(ctx)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package i18n
+
+// en katalog English (en_US)
+var en = map[string]string{
+	// Error umum, key = "error." + kode apperror
+	"error.BAD_REQUEST":            "Invalid request",
+	"error.INTERNAL_ERROR":         "Internal server error",
+	"error.VALIDATION_ERROR":       "Validation failed",
+	"error.NOT_FOUND":              "Resource not found",
+	"error.UNAUTHORIZED":           "Unauthorized",
+	"error.FORBIDDEN":              "Forbidden",
+	"error.CONFLICT":               "{field} '{value}' already exists",
+	"error.REQUEST_TOO_LARGE":      "File size exceeds maximum limit",
+	"error.INVALID_INT":            "Invalid integer value",
+	"error.UNSUPPORTED_MEDIA_TYPE": "Content-Type must be {expected}",
+	"error.INVALID_CONTENT_TYPE":   "Invalid Content-Type header",
+	"error.INVALID_MERGE_PATCH":    "Invalid merge patch document",
+	"error.INVALID_JSON_PATCH":     "Invalid JSON patch document",
+	"error.PATCH_FAILED":           "Failed to apply JSON patch",
+	"error.PATCH_SCHEMA_MISMATCH":  "Patched document does not match the resource schema",
+
+	// Error service fitur, key = "error." + kode apperror
+	"error.ATTACHMENT_FAILED":                  "Failed to update file attachments",
+	"error.CHANGE_ALUMNI_PUBLICATION_FAILED":   "Failed to change alumni publication",
+	"error.CHANGE_BROCHURE_PUBLICATION_FAILED": "Failed to change brochure publication",
+	"error.CHANGE_FACILITY_PUBLICATION_FAILED": "Failed to change facility publication",
+	"error.CREATE_ALUMNI_FAILED":               "Failed to create alumni",
+	"error.CREATE_BROCHURE_FAILED":             "Failed to create brochure",
+	"error.CREATE_FACILITY_FAILED":             "Failed to create facility",
+	"error.CREATE_FILE_FAILED":                 "Failed to create file",
+	"error.CREATE_USER_FAILED":                 "Failed to create user",
+	"error.FILE_ACQUIRE_FAILED":                "Failed to record file",
+	"error.FILE_CHECK_FAILED":                  "Failed to check file",
+	"error.FILE_ENQUEUE_FAILED":                "Failed to queue file upload",
+	"error.FILE_NOT_READY":                     "File is not ready yet",
+	"error.FILE_RELEASE_FAILED":                "Failed to release file",
+	"error.INVALID_BIRTH_DATE":                 "Invalid birth date",
+	"error.OPEN_REGISTRATION_PROOF_FAILED":     "Failed to open registration proof",
+	"error.PATCH_USER_FAILED":                  "Failed to patch user",
+	"error.PRESIGN_UNSUPPORTED":                "Storage driver does not support presigned uploads",
+	"error.PRESIGN_UPLOAD_FAILED":              "Failed to presign upload",
+	"error.REGISTER_USER_FAILED":               "Failed to register user",
+	"error.REGISTRATION_PROOF_NOT_READY":       "Registration proof is not ready yet",
+	"error.REORDER_ALUMNI_FAILED":              "Failed to reorder alumni",
+	"error.REORDER_BROCHURES_FAILED":           "Failed to reorder brochures",
+	"error.REORDER_FACILITIES_FAILED":          "Failed to reorder facilities",
+	"error.SIGNED_URL_UNSUPPORTED":             "Storage driver does not support signed URLs",
+	"error.SIGN_URL_FAILED":                    "Failed to sign URL",
+	"error.SUBMIT_REGISTRATION_FAILED":         "Failed to submit registration",
+	"error.TRANSACTION_FAILED":                 "Transaction failed",
+	"error.UPDATE_ALUMNI_FAILED":               "Failed to update alumni",
+	"error.UPDATE_BROCHURE_FAILED":             "Failed to update brochure",
+	"error.UPDATE_FACILITY_FAILED":             "Failed to update facility",
+	"error.UPDATE_REGISTRATION_STATUS_FAILED":  "Failed to update registration status",
+	"error.UPDATE_USER_FAILED":                 "Failed to update user",
+	"error.UPLOAD_AVATAR_FAILED":               "Failed to upload avatar",
+
+	// Pesan validasi, key = "validation." + tag validator
+	"validation.default":         "{field} failed {tag} validation",
+	"validation.required":        "{field} is required",
+	"validation.email":           "{field} must be a valid email address",
+	"validation.min":             "{field} must be at least {param} characters long",
+	"validation.max":             "{field} must not exceed {param} characters",
+	"validation.gte":             "{field} must be at least {param}",
+	"validation.lte":             "{field} must be at most {param}",
+	"validation.oneof":           "{field} must be one of: {param}",
+	"validation.datetime":        "{field} must use the {param} format",
+	"validation.strong_password": "{field} must be at least 8 characters and contain both letters and numbers",
+	"validation.alphanum_space":  "{field} must only contain letters, numbers and spaces",
+	"validation.latitude":        "{field} must be a valid latitude between -90 and 90",
+	"validation.longitude":       "{field} must be a valid longitude between -180 and 180",
+	"validation.positive":        "{field} must be a positive number",
+	"validation.image":           "{field} must be a valid image file (jpg, jpeg, png)",
+	"validation.size":            "{field} must not exceed {param} MB",
+	"validation.pdf":             "{field} must be a valid PDF file",
+	"validation.locales":         "{field} only supports locales {param}",
+	"validation.default_locale":  "{field} must have a {param} translation",
+
+	// Pesan response handler (response.Error), key = "message." + kode pesan
+	"message.BROADCAST_NOTICE_FAILED":            "Failed to broadcast notice",
+	"message.COMPLETE_UPLOAD_FAILED":             "Failed to complete upload",
+	"message.CREATE_ALUMNI_FAILED":               "Failed to create alumni",
+	"message.CREATE_BROCHURE_FAILED":             "Failed to create brochure",
+	"message.CREATE_FACILITY_FAILED":             "Failed to create facility",
+	"message.DELETE_ALUMNI_FAILED":               "Failed to delete alumni",
+	"message.DELETE_BROCHURE_FAILED":             "Failed to delete brochure",
+	"message.DELETE_FACILITY_FAILED":             "Failed to delete facility",
+	"message.DELETE_USER_FAILED":                 "Failed to delete user",
+	"message.DOWNLOAD_FILE_FAILED":               "Failed to download file",
+	"message.DOWNLOAD_REGISTRATION_PROOF_FAILED": "Failed to download registration proof",
+	"message.FETCH_ALUMNI_FAILED":                "Failed to fetch alumni",
+	"message.FETCH_BROCHURES_FAILED":             "Failed to fetch brochures",
+	"message.FETCH_BROCHURE_FAILED":              "Failed to fetch brochure",
+	"message.FETCH_DEAD_JOBS_FAILED":             "Failed to fetch dead jobs",
+	"message.FETCH_DEAD_JOB_FAILED":              "Failed to fetch dead job",
+	"message.FETCH_DEAD_LETTER_QUEUES_FAILED":    "Failed to fetch dead-letter queues",
+	"message.FETCH_FACILITIES_FAILED":            "Failed to fetch facilities",
+	"message.FETCH_FACILITY_FAILED":              "Failed to fetch facility",
+	"message.FETCH_FILE_FAILED":                  "Failed to fetch file",
+	"message.FETCH_JOB_FAILED":                   "Failed to fetch job",
+	"message.FETCH_PRESENCE_FAILED":              "Failed to fetch presence",
+	"message.FETCH_REGISTRATIONS_FAILED":         "Failed to fetch registrations",
+	"message.FETCH_REGISTRATION_FAILED":          "Failed to fetch registration",
+	"message.FETCH_USERS_FAILED":                 "Failed to fetch users",
+	"message.FETCH_USER_FAILED":                  "Failed to fetch user",
+	"message.INVALID_CREDENTIALS":                "Invalid credentials",
+	"message.PARSE_QUERY_FAILED":                 "Failed to parse query",
+	"message.PARSE_REQUEST_BODY_FAILED":          "Failed to parse request body",
+	"message.PATCH_USER_FAILED":                  "Failed to patch user",
+	"message.PRESIGN_UPLOAD_FAILED":              "Failed to presign upload",
+	"message.PUBLISH_ALUMNI_FAILED":              "Failed to publish alumni",
+	"message.PUBLISH_BROCHURE_FAILED":            "Failed to publish brochure",
+	"message.PUBLISH_FACILITY_FAILED":            "Failed to publish facility",
+	"message.PURGE_DEAD_JOB_FAILED":              "Failed to purge dead job",
+	"message.PURGE_DEAD_LETTER_QUEUE_FAILED":     "Failed to purge dead-letter queue",
+	"message.REGISTER_USER_FAILED":               "Failed to register user",
+	"message.REGISTRATION_NOT_FOUND":             "Registration not found",
+	"message.REORDER_ALUMNI_FAILED":              "Failed to reorder alumni",
+	"message.REORDER_BROCHURES_FAILED":           "Failed to reorder brochures",
+	"message.REORDER_FACILITIES_FAILED":          "Failed to reorder facilities",
+	"message.REPLAY_DEAD_JOBS_FAILED":            "Failed to replay dead jobs",
+	"message.REPLAY_DEAD_JOB_FAILED":             "Failed to replay dead job",
+	"message.REPLAY_EVENTS_FAILED":               "Failed to replay events",
+	"message.SIGN_FILE_URL_FAILED":               "Failed to sign file url",
+	"message.SUBMIT_REGISTRATION_FAILED":         "Failed to submit registration",
+	"message.UNPUBLISH_ALUMNI_FAILED":            "Failed to unpublish alumni",
+	"message.UNPUBLISH_BROCHURE_FAILED":          "Failed to unpublish brochure",
+	"message.UNPUBLISH_FACILITY_FAILED":          "Failed to unpublish facility",
+	"message.UPDATE_ALUMNI_FAILED":               "Failed to update alumni",
+	"message.UPDATE_BROCHURE_FAILED":             "Failed to update brochure",
+	"message.UPDATE_FACILITY_FAILED":             "Failed to update facility",
+	"message.UPDATE_REGISTRATION_STATUS_FAILED":  "Failed to update registration status",
+	"message.UPDATE_USER_FAILED":                 "Failed to update user",
+	"message.UPLOAD_AVATAR_FAILED":               "Failed to upload avatar",
+}
@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"strings"
+
+	"template-golang/pkg/locale"
+)
+
+// bundles katalog pesan per locale, key: "error.<CODE>", "validation.<tag>", dst.
+var bundles = map[string]map[string]string{
+	locale.ID: id,
+	locale.EN: en,
+}
+
+// Params nilai placeholder pesan, contoh {"field": "email"} untuk "{field} wajib diisi"
+type Params map[string]string
+
+// Lookup cari pesan mentah (tanpa placeholder diganti) untuk key di locale,
+// fallback ke locale default
+func Lookup(l, key string) (string, bool) {
+	if msg, ok := bundles[l][key]; ok {
+		return msg, true
+	}
+	msg, ok := bundles[locale.Default][key]
+	return msg, ok
+}
+
+// Has cek apakah key ada di katalog
+func Has(key string) bool {
+	_, ok := Lookup(locale.Default, key)
+	return ok
+}
+
+// T terjemahkan key ke locale dan ganti placeholder {name} dengan params.
+// Kalau key tidak ada di katalog, key itu sendiri yang dikembalikan.
+func T(l, key string, params Params) string {
+	msg, ok := Lookup(l, key)
+	if !ok {
+		msg = key
+	}
+	if len(params) == 0 {
+		return msg
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for k, v := range params {
+		pairs = append(pairs, "{"+k+"}", v)
+	}
+	return strings.NewReplacer(pairs...).Replace(msg)
+}
+
+// ErrorKey key katalog untuk kode error apperror
+func ErrorKey(code string) string {
+	return "error." + code
+}
+
+// ValidationKey key katalog untuk tag validator
+func ValidationKey(tag string) string {
+	return "validation." + tag
+}
+
+// MessageKey key katalog untuk kode pesan response handler (response.Error)
+func MessageKey(code string) string {
+	return "message." + code
+}
+
+// Message terjemahkan kode pesan response handler ke locale,
+// kode yang tidak ada di katalog dikembalikan apa adanya
+func Message(l, code string) string {
+	key := MessageKey(code)
+	if !Has(key) {
+		return code
+	}
+	return T(l, key, nil)
+}
+
+// Validation pesan validasi untuk tag, fallback ke "validation.default"
+func Validation(l, tag string, params Params) string {
+	key := ValidationKey(tag)
+	if !Has(key) {
+		key = ValidationKey("default")
+	}
+	return T(l, key, params)
+}
@@ -0,0 +1,170 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"template-golang/pkg/locale"
+)
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	for name, bundle := range bundles {
+		for other, otherBundle := range bundles {
+			for key := range bundle {
+				if _, ok := otherBundle[key]; !ok {
+					t.Errorf("key %q ada di katalog %s tapi tidak di %s", key, name, other)
+				}
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale string
+		key    string
+		params Params
+		want   string
+	}{
+		{name: "id", locale: locale.ID, key: "validation.required", params: Params{"field": "Email"}, want: "Email wajib diisi"},
+		{name: "en", locale: locale.EN, key: "validation.required", params: Params{"field": "Email"}, want: "Email is required"},
+		{name: "multiple params", locale: locale.EN, key: "error.CONFLICT", params: Params{"field": "Email", "value": "a@b.c"}, want: "Email 'a@b.c' already exists"},
+		{name: "unknown locale falls back to default", locale: "fr_FR", key: "error.NOT_FOUND", want: id["error.NOT_FOUND"]},
+		{name: "missing key returns key", locale: locale.EN, key: "error.DOES_NOT_EXIST", want: "error.DOES_NOT_EXIST"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := T(tt.locale, tt.key, tt.params); got != tt.want {
+				t.Errorf("T(%q, %q) = %q, want %q", tt.locale, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	if got := Message(locale.EN, "FETCH_USERS_FAILED"); got != "Failed to fetch users" {
+		t.Errorf("Message en = %q", got)
+	}
+	if got := Message(locale.ID, "FETCH_USERS_FAILED"); got != "Gagal mengambil daftar user" {
+		t.Errorf("Message id = %q", got)
+	}
+	if got := Message(locale.EN, "NOT_A_CODE"); got != "NOT_A_CODE" {
+		t.Errorf("Message unknown code = %q, want code itself", got)
+	}
+}
+
+func TestValidationFallsBackToDefault(t *testing.T) {
+	got := Validation(locale.EN, "unknown_tag", Params{"field": "Name", "tag": "unknown_tag"})
+	if got != "Name failed unknown_tag validation" {
+		t.Errorf("Validation = %q", got)
+	}
+}
+
+// TestCatalogCoversCodeUsage scan source repo untuk kode pesan yang dipakai
+// (response.Error, apperror.NewT, i18n.ErrorKey, i18n.MessageKey) dan cek semuanya
+// ada di setiap katalog
+func TestCatalogCoversCodeUsage(t *testing.T) {
+	root := filepath.Join("..", "..")
+	used := map[string][]string{}
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "docs") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if key, ok := catalogKey(file.Name.Name, call); ok {
+				used[key] = append(used[key], fset.Position(call.Pos()).String())
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("scan source: %v", err)
+	}
+	if len(used) == 0 {
+		t.Fatal("tidak ada pemakaian kode pesan yang ditemukan")
+	}
+
+	keys := make([]string, 0, len(used))
+	for key := range used {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for name, bundle := range bundles {
+			if _, ok := bundle[key]; !ok {
+				t.Errorf("key %q tidak ada di katalog %s (dipakai di %s)", key, name, used[key][0])
+			}
+		}
+	}
+}
+
+// catalogKey key katalog dari pemanggilan yang argumen kodenya string literal
+func catalogKey(pkg string, call *ast.CallExpr) (string, bool) {
+	var qualifier, name string
+	switch fn := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		ident, ok := fn.X.(*ast.Ident)
+		if !ok {
+			return "", false
+		}
+		qualifier, name = ident.Name, fn.Sel.Name
+	case *ast.Ident:
+		qualifier, name = pkg, fn.Name
+	default:
+		return "", false
+	}
+
+	var arg int
+	var keyFn func(string) string
+	switch qualifier + "." + name {
+	case "response.Error":
+		arg, keyFn = 1, MessageKey
+	case "i18n.MessageKey":
+		arg, keyFn = 0, MessageKey
+	case "apperror.NewT", "i18n.ErrorKey":
+		arg, keyFn = 0, ErrorKey
+	default:
+		return "", false
+	}
+	if len(call.Args) <= arg {
+		return "", false
+	}
+	lit, ok := call.Args[arg].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	code, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return keyFn(code), true
+}
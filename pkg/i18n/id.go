@@ -0,0 +1,135 @@
+package i18n
+
+// id katalog Bahasa Indonesia (id_ID)
+var id = map[string]string{
+	// Error umum, key = "error." + kode apperror
+	"error.BAD_REQUEST":            "Request tidak valid",
+	"error.INTERNAL_ERROR":         "Kesalahan server",
+	"error.VALIDATION_ERROR":       "Validasi gagal",
+	"error.NOT_FOUND":              "Data tidak ditemukan",
+	"error.UNAUTHORIZED":           "Tidak terautentikasi",
+	"error.FORBIDDEN":              "Akses ditolak",
+	"error.CONFLICT":               "{field} '{value}' sudah digunakan",
+	"error.REQUEST_TOO_LARGE":      "Ukuran file melebihi batas maksimum",
+	"error.INVALID_INT":            "Nilai bilangan bulat tidak valid",
+	"error.UNSUPPORTED_MEDIA_TYPE": "Content-Type harus {expected}",
+	"error.INVALID_CONTENT_TYPE":   "Header Content-Type tidak valid",
+	"error.INVALID_MERGE_PATCH":    "Dokumen merge patch tidak valid",
+	"error.INVALID_JSON_PATCH":     "Dokumen JSON patch tidak valid",
+	"error.PATCH_FAILED":           "Gagal menerapkan JSON patch",
+	"error.PATCH_SCHEMA_MISMATCH":  "Hasil patch tidak sesuai dengan skema data",
+
+	// Error service fitur, key = "error." + kode apperror
+	"error.ATTACHMENT_FAILED":                  "Gagal memperbarui lampiran file",
+	"error.CHANGE_ALUMNI_PUBLICATION_FAILED":   "Gagal mengubah status publikasi alumni",
+	"error.CHANGE_BROCHURE_PUBLICATION_FAILED": "Gagal mengubah status publikasi brosur",
+	"error.CHANGE_FACILITY_PUBLICATION_FAILED": "Gagal mengubah status publikasi fasilitas",
+	"error.CREATE_ALUMNI_FAILED":               "Gagal membuat alumni",
+	"error.CREATE_BROCHURE_FAILED":             "Gagal membuat brosur",
+	"error.CREATE_FACILITY_FAILED":             "Gagal membuat fasilitas",
+	"error.CREATE_FILE_FAILED":                 "Gagal membuat file",
+	"error.CREATE_USER_FAILED":                 "Gagal membuat user",
+	"error.FILE_ACQUIRE_FAILED":                "Gagal mencatat file",
+	"error.FILE_CHECK_FAILED":                  "Gagal memeriksa file",
+	"error.FILE_ENQUEUE_FAILED":                "Gagal mengantrikan upload file",
+	"error.FILE_NOT_READY":                     "File belum siap",
+	"error.FILE_RELEASE_FAILED":                "Gagal melepas file",
+	"error.INVALID_BIRTH_DATE":                 "Tanggal lahir tidak valid",
+	"error.OPEN_REGISTRATION_PROOF_FAILED":     "Gagal membuka bukti pendaftaran",
+	"error.PATCH_USER_FAILED":                  "Gagal memperbarui user",
+	"error.PRESIGN_UNSUPPORTED":                "Driver storage tidak mendukung presigned upload",
+	"error.PRESIGN_UPLOAD_FAILED":              "Gagal membuat URL upload",
+	"error.REGISTER_USER_FAILED":               "Gagal mendaftarkan user",
+	"error.REGISTRATION_PROOF_NOT_READY":       "Bukti pendaftaran belum siap",
+	"error.REORDER_ALUMNI_FAILED":              "Gagal mengurutkan ulang alumni",
+	"error.REORDER_BROCHURES_FAILED":           "Gagal mengurutkan ulang brosur",
+	"error.REORDER_FACILITIES_FAILED":          "Gagal mengurutkan ulang fasilitas",
+	"error.SIGNED_URL_UNSUPPORTED":             "Driver storage tidak mendukung URL bertanda tangan",
+	"error.SIGN_URL_FAILED":                    "Gagal membuat URL bertanda tangan",
+	"error.SUBMIT_REGISTRATION_FAILED":         "Gagal mengirim pendaftaran",
+	"error.TRANSACTION_FAILED":                 "Transaksi gagal",
+	"error.UPDATE_ALUMNI_FAILED":               "Gagal memperbarui alumni",
+	"error.UPDATE_BROCHURE_FAILED":             "Gagal memperbarui brosur",
+	"error.UPDATE_FACILITY_FAILED":             "Gagal memperbarui fasilitas",
+	"error.UPDATE_REGISTRATION_STATUS_FAILED":  "Gagal memperbarui status pendaftaran",
+	"error.UPDATE_USER_FAILED":                 "Gagal memperbarui user",
+	"error.UPLOAD_AVATAR_FAILED":               "Gagal mengupload avatar",
+
+	// Pesan validasi, key = "validation." + tag validator
+	"validation.default":         "{field} tidak lolos validasi {tag}",
+	"validation.required":        "{field} wajib diisi",
+	"validation.email":           "{field} harus berupa alamat email yang valid",
+	"validation.min":             "{field} minimal {param} karakter",
+	"validation.max":             "{field} maksimal {param} karakter",
+	"validation.gte":             "{field} minimal {param}",
+	"validation.lte":             "{field} maksimal {param}",
+	"validation.oneof":           "{field} harus salah satu dari: {param}",
+	"validation.datetime":        "{field} harus berformat {param}",
+	"validation.strong_password": "{field} minimal 8 karakter dan harus mengandung huruf dan angka",
+	"validation.alphanum_space":  "{field} hanya boleh berisi huruf, angka dan spasi",
+	"validation.latitude":        "{field} harus berupa latitude yang valid antara -90 dan 90",
+	"validation.longitude":       "{field} harus berupa longitude yang valid antara -180 dan 180",
+	"validation.positive":        "{field} harus berupa angka positif",
+	"validation.image":           "{field} harus berupa file gambar yang valid (jpg, jpeg, png)",
+	"validation.size":            "{field} tidak boleh melebihi {param} MB",
+	"validation.pdf":             "{field} harus berupa file PDF yang valid",
+	"validation.locales":         "{field} hanya mendukung locale {param}",
+	"validation.default_locale":  "{field} wajib memiliki terjemahan {param}",
+
+	// Pesan response handler (response.Error), key = "message." + kode pesan
+	"message.BROADCAST_NOTICE_FAILED":            "Gagal mengirim pengumuman",
+	"message.COMPLETE_UPLOAD_FAILED":             "Gagal menyelesaikan upload",
+	"message.CREATE_ALUMNI_FAILED":               "Gagal membuat alumni",
+	"message.CREATE_BROCHURE_FAILED":             "Gagal membuat brosur",
+	"message.CREATE_FACILITY_FAILED":             "Gagal membuat fasilitas",
+	"message.DELETE_ALUMNI_FAILED":               "Gagal menghapus alumni",
+	"message.DELETE_BROCHURE_FAILED":             "Gagal menghapus brosur",
+	"message.DELETE_FACILITY_FAILED":             "Gagal menghapus fasilitas",
+	"message.DELETE_USER_FAILED":                 "Gagal menghapus user",
+	"message.DOWNLOAD_FILE_FAILED":               "Gagal mengunduh file",
+	"message.DOWNLOAD_REGISTRATION_PROOF_FAILED": "Gagal mengunduh bukti pendaftaran",
+	"message.FETCH_ALUMNI_FAILED":                "Gagal mengambil alumni",
+	"message.FETCH_BROCHURES_FAILED":             "Gagal mengambil daftar brosur",
+	"message.FETCH_BROCHURE_FAILED":              "Gagal mengambil brosur",
+	"message.FETCH_DEAD_JOBS_FAILED":             "Gagal mengambil daftar job gagal",
+	"message.FETCH_DEAD_JOB_FAILED":              "Gagal mengambil job gagal",
+	"message.FETCH_DEAD_LETTER_QUEUES_FAILED":    "Gagal mengambil daftar dead-letter queue",
+	"message.FETCH_FACILITIES_FAILED":            "Gagal mengambil daftar fasilitas",
+	"message.FETCH_FACILITY_FAILED":              "Gagal mengambil fasilitas",
+	"message.FETCH_FILE_FAILED":                  "Gagal mengambil file",
+	"message.FETCH_JOB_FAILED":                   "Gagal mengambil job",
+	"message.FETCH_PRESENCE_FAILED":              "Gagal mengambil presence",
+	"message.FETCH_REGISTRATIONS_FAILED":         "Gagal mengambil daftar pendaftaran",
+	"message.FETCH_REGISTRATION_FAILED":          "Gagal mengambil pendaftaran",
+	"message.FETCH_USERS_FAILED":                 "Gagal mengambil daftar user",
+	"message.FETCH_USER_FAILED":                  "Gagal mengambil user",
+	"message.INVALID_CREDENTIALS":                "Email atau password salah",
+	"message.PARSE_QUERY_FAILED":                 "Gagal membaca query",
+	"message.PARSE_REQUEST_BODY_FAILED":          "Gagal membaca body request",
+	"message.PATCH_USER_FAILED":                  "Gagal memperbarui user",
+	"message.PRESIGN_UPLOAD_FAILED":              "Gagal membuat URL upload",
+	"message.PUBLISH_ALUMNI_FAILED":              "Gagal mempublikasikan alumni",
+	"message.PUBLISH_BROCHURE_FAILED":            "Gagal mempublikasikan brosur",
+	"message.PUBLISH_FACILITY_FAILED":            "Gagal mempublikasikan fasilitas",
+	"message.PURGE_DEAD_JOB_FAILED":              "Gagal menghapus job gagal",
+	"message.PURGE_DEAD_LETTER_QUEUE_FAILED":     "Gagal mengosongkan dead-letter queue",
+	"message.REGISTER_USER_FAILED":               "Gagal mendaftarkan user",
+	"message.REGISTRATION_NOT_FOUND":             "Pendaftaran tidak ditemukan",
+	"message.REORDER_ALUMNI_FAILED":              "Gagal mengurutkan ulang alumni",
+	"message.REORDER_BROCHURES_FAILED":           "Gagal mengurutkan ulang brosur",
+	"message.REORDER_FACILITIES_FAILED":          "Gagal mengurutkan ulang fasilitas",
+	"message.REPLAY_DEAD_JOBS_FAILED":            "Gagal mengantrikan ulang semua job gagal",
+	"message.REPLAY_DEAD_JOB_FAILED":             "Gagal mengantrikan ulang job gagal",
+	"message.REPLAY_EVENTS_FAILED":               "Gagal memutar ulang event",
+	"message.SIGN_FILE_URL_FAILED":               "Gagal membuat URL file bertanda tangan",
+	"message.SUBMIT_REGISTRATION_FAILED":         "Gagal mengirim pendaftaran",
+	"message.UNPUBLISH_ALUMNI_FAILED":            "Gagal membatalkan publikasi alumni",
+	"message.UNPUBLISH_BROCHURE_FAILED":          "Gagal membatalkan publikasi brosur",
+	"message.UNPUBLISH_FACILITY_FAILED":          "Gagal membatalkan publikasi fasilitas",
+	"message.UPDATE_ALUMNI_FAILED":               "Gagal memperbarui alumni",
+	"message.UPDATE_BROCHURE_FAILED":             "Gagal memperbarui brosur",
+	"message.UPDATE_FACILITY_FAILED":             "Gagal memperbarui fasilitas",
+	"message.UPDATE_REGISTRATION_STATUS_FAILED":  "Gagal memperbarui status pendaftaran",
+	"message.UPDATE_USER_FAILED":                 "Gagal memperbarui user",
+	"message.UPLOAD_AVATAR_FAILED":               "Gagal mengupload avatar",
+}
@@ -0,0 +1,137 @@
+// Package imaging pipeline gambar: decode (JPEG, PNG, GIF, WebP) dengan orientasi
+// EXIF, resize sesuai preset, lalu encode ke WebP / JPEG / PNG.
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"github.com/chai2010/webp"
+	"golang.org/x/image/draw"
+)
+
+// Format format output varian
+type Format string
+
+const (
+	FormatWebP Format = "webp"
+	FormatJPEG Format = "jpeg"
+	FormatPNG  Format = "png"
+)
+
+// Ext ekstensi file untuk format
+func (f Format) Ext() string {
+	if f == FormatJPEG {
+		return ".jpg"
+	}
+	return "." + string(f)
+}
+
+// ContentType MIME type untuk format
+func (f Format) ContentType() string {
+	return "image/" + string(f)
+}
+
+// Mode cara gambar disesuaikan ke ukuran preset
+type Mode string
+
+const (
+	// ModeFit perkecil sampai muat di Width x Height, rasio tetap
+	ModeFit Mode = "fit"
+	// ModeCrop potong bagian tengah ke rasio Width:Height lalu perkecil
+	ModeCrop Mode = "crop"
+)
+
+// Decode decode gambar (frame pertama untuk GIF animasi) lalu putar sesuai
+// orientasi EXIF, format yang dikembalikan nama dari image.Decode.
+// Dimensi dicek dulu dengan CheckConfig.
+func Decode(r io.Reader) (image.Image, string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read image: %w", err)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode image: %w", err)
+	}
+	if err := CheckConfig(cfg); err != nil {
+		return nil, "", err
+	}
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode image: %w", err)
+	}
+	return applyOrientation(img, orientation(data)), format, nil
+}
+
+// Resize sesuaikan img ke preset, gambar hanya diperbesar kalau p.Enlarge
+func Resize(img image.Image, p Preset) image.Image {
+	b := img.Bounds()
+	src := b
+	w, h := b.Dx(), b.Dy()
+
+	if p.Mode == ModeCrop && p.Width > 0 && p.Height > 0 {
+		// potong ke rasio preset, bagian tengah
+		cw, ch := w, w*p.Height/p.Width
+		if ch > h {
+			cw, ch = h*p.Width/p.Height, h
+		}
+		x0 := b.Min.X + (w-cw)/2
+		y0 := b.Min.Y + (h-ch)/2
+		src = image.Rect(x0, y0, x0+cw, y0+ch)
+		w, h = cw, ch
+	}
+
+	scale := 0.0
+	if p.Width > 0 {
+		scale = float64(p.Width) / float64(w)
+	}
+	if sh := float64(p.Height) / float64(h); p.Height > 0 && (scale == 0 || sh < scale) {
+		scale = sh
+	}
+	if scale == 0 || (scale > 1 && !p.Enlarge) {
+		scale = 1
+	}
+	dw := max(1, int(float64(w)*scale+0.5))
+	dh := max(1, int(float64(h)*scale+0.5))
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, src, draw.Src, nil)
+	return dst
+}
+
+// Encode tulis img dalam format f, quality 1-100 (diabaikan untuk PNG)
+func Encode(w io.Writer, img image.Image, f Format, quality int) error {
+	if quality <= 0 || quality > 100 {
+		quality = DefaultQuality()
+	}
+	var err error
+	switch f {
+	case FormatWebP:
+		err = webp.Encode(w, img, &webp.Options{Quality: float32(quality)})
+	case FormatJPEG:
+		err = jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+	case FormatPNG:
+		err = png.Encode(w, img)
+	default:
+		return fmt.Errorf("unsupported image format %q", f)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", f, err)
+	}
+	return nil
+}
+
+// Process resize img sesuai preset lalu encode
+func Process(img image.Image, p Preset) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, Resize(img, p), p.Format, p.Quality); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
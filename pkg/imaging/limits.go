@@ -0,0 +1,32 @@
+package imaging
+
+import (
+	"errors"
+	"fmt"
+	"image"
+
+	"template-golang/pkg/config"
+)
+
+// ErrTooLarge dimensi gambar melebihi IMAGE_MAX_DIMENSION / IMAGE_MAX_PIXELS
+var ErrTooLarge = errors.New("imaging: image dimensions exceed limit")
+
+// CheckConfig tolak gambar yang terlalu besar sebelum di-decode (decompression bomb):
+// file kecil bisa mengklaim dimensi yang butuh gigabyte memori saat di-decode
+func CheckConfig(cfg image.Config) error {
+	c := config.GetConfig()
+	maxDim, maxPixels := c.ImageMaxDimension, c.ImageMaxPixels
+	if maxDim <= 0 {
+		maxDim = 10000
+	}
+	if maxPixels <= 0 {
+		maxPixels = 40_000_000
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return fmt.Errorf("invalid image dimensions %dx%d", cfg.Width, cfg.Height)
+	}
+	if cfg.Width > maxDim || cfg.Height > maxDim || int64(cfg.Width)*int64(cfg.Height) > maxPixels {
+		return fmt.Errorf("%w: %dx%d", ErrTooLarge, cfg.Width, cfg.Height)
+	}
+	return nil
+}
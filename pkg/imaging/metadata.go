@@ -0,0 +1,189 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// ErrMalformed struktur file gambar rusak saat metadata dibuang
+var ErrMalformed = errors.New("imaging: malformed image")
+
+// StripMetadata buang EXIF (termasuk GPS), XMP, IPTC dan komentar tanpa
+// meng-encode ulang pixel. Mendukung JPEG, PNG, WebP dan GIF, format lain
+// dikembalikan apa adanya.
+func StripMetadata(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8}):
+		return stripJPEG(data)
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return stripPNG(data)
+	case len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return stripWebP(data)
+	case bytes.HasPrefix(data, []byte("GIF87a")) || bytes.HasPrefix(data, []byte("GIF89a")):
+		return stripGIF(data)
+	}
+	return data, nil
+}
+
+// stripJPEG buang segmen APP1 (EXIF, XMP), APP13 (IPTC) dan COM sebelum scan data
+func stripJPEG(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[0:2]...)
+	i := 2
+	for {
+		// byte 0xFF tambahan sebelum marker boleh ada (fill byte)
+		for i < len(data) && data[i] == 0xFF && i+1 < len(data) && data[i+1] == 0xFF {
+			i++
+		}
+		if i+4 > len(data) || data[i] != 0xFF {
+			return nil, ErrMalformed
+		}
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 {
+			// scan data dan seterusnya disalin apa adanya
+			return append(out, data[i:]...), nil
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			return nil, ErrMalformed
+		}
+		if marker != 0xE1 && marker != 0xED && marker != 0xFE {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+}
+
+// pngMetadata chunk metadata PNG (XMP disimpan di iTXt)
+var pngMetadata = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true}
+
+func stripPNG(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[0:8]...)
+	i := 8
+	for i < len(data) {
+		if i+12 > len(data) {
+			return nil, ErrMalformed
+		}
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		end := i + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, ErrMalformed
+		}
+		if !pngMetadata[string(data[i+4:i+8])] {
+			out = append(out, data[i:end]...)
+		}
+		if string(data[i+4:i+8]) == "IEND" {
+			break
+		}
+		i = end
+	}
+	return out, nil
+}
+
+// stripWebP buang chunk EXIF dan XMP, flag-nya di VP8X ikut dimatikan
+func stripWebP(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[0:12]...)
+	i := 12
+	for i < len(data) {
+		if i+8 > len(data) {
+			return nil, ErrMalformed
+		}
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + size + size%2
+		if size < 0 || end > len(data) {
+			// padding chunk terakhir kadang tidak ditulis encoder
+			if i+8+size != len(data) {
+				return nil, ErrMalformed
+			}
+			end = len(data)
+		}
+		switch string(data[i : i+4]) {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := append([]byte{}, data[i:end]...)
+			if len(chunk) > 8 {
+				// bit 3 EXIF, bit 2 XMP
+				chunk[8] &^= 0x08 | 0x04
+			}
+			out = append(out, chunk...)
+		default:
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, nil
+}
+
+// stripGIF buang comment extension dan application extension XMP
+func stripGIF(data []byte) ([]byte, error) {
+	if len(data) < 13 {
+		return nil, ErrMalformed
+	}
+	i := 13
+	if flags := data[10]; flags&0x80 != 0 {
+		i += 3 << ((flags & 0x07) + 1)
+	}
+	if i > len(data) {
+		return nil, ErrMalformed
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:i]...)
+
+	for i < len(data) {
+		start := i
+		switch data[i] {
+		case 0x3B: // trailer
+			return append(out, data[i]), nil
+		case 0x21: // extension
+			if i+2 > len(data) {
+				return nil, ErrMalformed
+			}
+			label := data[i+1]
+			end, ok := gifSubBlocks(data, i+2)
+			if !ok {
+				return nil, ErrMalformed
+			}
+			xmp := label == 0xFF && i+14 <= len(data) && string(data[i+3:i+14]) == "XMP DataXMP"
+			if label != 0xFE && !xmp {
+				out = append(out, data[start:end]...)
+			}
+			i = end
+		case 0x2C: // image descriptor
+			if i+10 > len(data) {
+				return nil, ErrMalformed
+			}
+			i += 10
+			if flags := data[i-1]; flags&0x80 != 0 {
+				i += 3 << ((flags & 0x07) + 1)
+			}
+			// LZW minimum code size lalu sub-block data gambar
+			end, ok := gifSubBlocks(data, i+1)
+			if !ok {
+				return nil, ErrMalformed
+			}
+			out = append(out, data[start:end]...)
+			i = end
+		default:
+			return nil, ErrMalformed
+		}
+	}
+	return nil, ErrMalformed
+}
+
+// gifSubBlocks posisi setelah rangkaian sub-block (size + data) yang diakhiri 0
+func gifSubBlocks(data []byte, i int) (int, bool) {
+	for i < len(data) {
+		size := int(data[i])
+		i++
+		if size == 0 {
+			return i, true
+		}
+		i += size
+	}
+	return 0, false
+}
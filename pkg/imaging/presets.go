@@ -0,0 +1,154 @@
+package imaging
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"template-golang/pkg/config"
+	"template-golang/pkg/logger"
+)
+
+// Preset deklarasi satu varian gambar
+type Preset struct {
+	Name string `json:"name"`
+	// Width / Height ukuran maksimal (px), 0 = bebas
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Mode   Mode   `json:"mode"`
+	Format Format `json:"format"`
+	// Quality 1-100, 0 = IMAGE_QUALITY
+	Quality int `json:"quality"`
+	// Enlarge gambar yang lebih kecil dari preset ikut diperbesar
+	Enlarge bool `json:"enlarge,omitempty"`
+}
+
+// defaultPresets preset bawaan, bisa ditimpa / ditambah lewat IMAGE_PRESETS
+var defaultPresets = []Preset{
+	{Name: "thumbnail", Width: 200, Height: 200, Mode: ModeCrop, Format: FormatWebP, Quality: 75},
+	{Name: "card", Width: 640, Height: 360, Mode: ModeCrop, Format: FormatWebP, Quality: 80},
+	{Name: "hero", Width: 1920, Height: 1080, Mode: ModeFit, Format: FormatWebP, Quality: 85},
+}
+
+var (
+	presetsOnce sync.Once
+	presets     map[string]Preset
+)
+
+// DefaultQuality kualitas encode kalau preset tidak mengatur (IMAGE_QUALITY)
+func DefaultQuality() int {
+	if q := config.GetConfig().ImageQuality; q > 0 && q <= 100 {
+		return q
+	}
+	return 80
+}
+
+// Lookup preset berdasarkan nama
+func Lookup(name string) (Preset, bool) {
+	presetsOnce.Do(loadPresets)
+	p, ok := presets[name]
+	return p, ok
+}
+
+// Resolve preset untuk semua nama, error kalau ada yang tidak dikenal
+func Resolve(names []string) ([]Preset, error) {
+	out := make([]Preset, 0, len(names))
+	for _, name := range names {
+		p, ok := Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown image preset %q", name)
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// Names nama semua preset, terurut
+func Names() []string {
+	presetsOnce.Do(loadPresets)
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func loadPresets() {
+	presets = map[string]Preset{}
+	for _, p := range defaultPresets {
+		presets[p.Name] = p
+	}
+	raw := config.GetConfig().ImagePresets
+	if raw == "" {
+		return
+	}
+	for _, def := range strings.Split(raw, ",") {
+		p, err := ParsePreset(strings.TrimSpace(def))
+		if err != nil {
+			logger.L().Warnf("imaging: ignoring preset %q: %v", def, err)
+			continue
+		}
+		presets[p.Name] = p
+	}
+}
+
+// ParsePreset parse "name=WxH:mode:format:quality", contoh "thumbnail=200x200:crop:webp:75".
+// mode, format dan quality boleh dihilangkan (default fit, webp, IMAGE_QUALITY).
+func ParsePreset(def string) (Preset, error) {
+	name, spec, ok := strings.Cut(def, "=")
+	if !ok || name == "" {
+		return Preset{}, fmt.Errorf("expected name=WxH[:mode[:format[:quality]]]")
+	}
+	p := Preset{Name: name, Mode: ModeFit, Format: FormatWebP}
+	parts := strings.Split(spec, ":")
+
+	ws, hs, ok := strings.Cut(parts[0], "x")
+	if !ok {
+		return Preset{}, fmt.Errorf("invalid size %q", parts[0])
+	}
+	var err error
+	if p.Width, err = parseDim(ws); err != nil {
+		return Preset{}, err
+	}
+	if p.Height, err = parseDim(hs); err != nil {
+		return Preset{}, err
+	}
+
+	if len(parts) > 1 && parts[1] != "" {
+		p.Mode = Mode(parts[1])
+		if p.Mode != ModeFit && p.Mode != ModeCrop {
+			return Preset{}, fmt.Errorf("invalid mode %q", parts[1])
+		}
+	}
+	if len(parts) > 2 && parts[2] != "" {
+		p.Format = Format(parts[2])
+		if p.Format != FormatWebP && p.Format != FormatJPEG && p.Format != FormatPNG {
+			return Preset{}, fmt.Errorf("invalid format %q", parts[2])
+		}
+	}
+	if len(parts) > 3 && parts[3] != "" {
+		p.Quality, err = strconv.Atoi(parts[3])
+		if err != nil || p.Quality < 1 || p.Quality > 100 {
+			return Preset{}, fmt.Errorf("invalid quality %q", parts[3])
+		}
+	}
+	if p.Mode == ModeCrop && (p.Width == 0 || p.Height == 0) {
+		return Preset{}, fmt.Errorf("crop needs both width and height")
+	}
+	return p, nil
+}
+
+// parseDim ukuran px, kosong / 0 berarti bebas
+func parseDim(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid dimension %q", s)
+	}
+	return n, nil
+}
@@ -0,0 +1,49 @@
+package imaging
+
+import "testing"
+
+func TestParsePreset(t *testing.T) {
+	tests := []struct {
+		def     string
+		want    Preset
+		wantErr bool
+	}{
+		{def: "thumbnail=200x200:crop:webp:75", want: Preset{Name: "thumbnail", Width: 200, Height: 200, Mode: ModeCrop, Format: FormatWebP, Quality: 75}},
+		{def: "hero=1920x1080", want: Preset{Name: "hero", Width: 1920, Height: 1080, Mode: ModeFit, Format: FormatWebP}},
+		{def: "wide=800x", want: Preset{Name: "wide", Width: 800, Mode: ModeFit, Format: FormatWebP}},
+		{def: "tall=x600:fit:png", want: Preset{Name: "tall", Height: 600, Mode: ModeFit, Format: FormatPNG}},
+		{def: "photo=1024x768::jpeg", want: Preset{Name: "photo", Width: 1024, Height: 768, Mode: ModeFit, Format: FormatJPEG}},
+		{def: "q=100x100:::90", want: Preset{Name: "q", Width: 100, Height: 100, Mode: ModeFit, Format: FormatWebP, Quality: 90}},
+
+		{def: "200x200", wantErr: true},
+		{def: "=200x200", wantErr: true},
+		{def: "bad=200", wantErr: true},
+		{def: "bad=ax200", wantErr: true},
+		{def: "bad=-1x200", wantErr: true},
+		{def: "bad=200x200:stretch", wantErr: true},
+		{def: "bad=200x200:fit:gif", wantErr: true},
+		{def: "bad=200x200:fit:webp:0", wantErr: true},
+		{def: "bad=200x200:fit:webp:101", wantErr: true},
+		{def: "bad=200x200:fit:webp:high", wantErr: true},
+		{def: "bad=200x:crop", wantErr: true},
+		{def: "bad=x200:crop", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.def, func(t *testing.T) {
+			got, err := ParsePreset(tt.def)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParsePreset(%q) = %+v, want error", tt.def, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePreset(%q): %v", tt.def, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePreset(%q) = %+v, want %+v", tt.def, got, tt.want)
+			}
+		})
+	}
+}
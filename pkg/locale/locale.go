@@ -0,0 +1,112 @@
+package locale
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ID Bahasa Indonesia
+	ID = "id_ID"
+	// EN English
+	EN = "en_US"
+	// Default dipakai kalau client tidak meminta bahasa yang didukung
+	Default = ID
+
+	// ContextKey key Locals / context untuk locale hasil negosiasi
+	ContextKey = "locale"
+)
+
+// Supported daftar locale yang didukung, urutan pertama = default
+var Supported = []string{ID, EN}
+
+// Normalize ubah tag bahasa (id, en-US, en_us, in) ke locale yang didukung.
+// Mengembalikan string kosong kalau tidak dikenali.
+func Normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	tag = strings.ReplaceAll(tag, "-", "_")
+	if tag == "" {
+		return ""
+	}
+
+	for _, l := range Supported {
+		if tag == strings.ToLower(l) {
+			return l
+		}
+	}
+
+	// Cocokkan berdasarkan bahasa utama saja (en_GB -> en_US)
+	primary := strings.SplitN(tag, "_", 2)[0]
+	switch primary {
+	case "id", "in":
+		return ID
+	case "en":
+		return EN
+	}
+	return ""
+}
+
+// IsSupported cek apakah locale termasuk yang didukung
+func IsSupported(l string) bool {
+	for _, s := range Supported {
+		if s == l {
+			return true
+		}
+	}
+	return false
+}
+
+// Negotiate pilih locale dari parameter ?lang= lalu header Accept-Language,
+// fallback ke Default.
+func Negotiate(query, acceptLanguage string) string {
+	if l := Normalize(query); l != "" {
+		return l
+	}
+
+	type candidate struct {
+		tag string
+		q   float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		if fields[0] == "" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if strings.HasPrefix(f, "q=") {
+				if v, err := strconv.ParseFloat(f[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{tag: fields[0], q: q})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	for _, c := range candidates {
+		if l := Normalize(c.tag); l != "" {
+			return l
+		}
+	}
+	return Default
+}
+
+// FromContext ambil locale dari context request, fallback ke Default
+func FromContext(ctx context.Context) string {
+	if l, ok := ctx.Value(ContextKey).(string); ok && l != "" {
+		return l
+	}
+	return Default
+}
+
+// HTMLLang ubah locale ke format tag bahasa HTTP (id_ID -> id-ID)
+func HTMLLang(l string) string {
+	return strings.ReplaceAll(l, "_", "-")
+}
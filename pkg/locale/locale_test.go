@@ -0,0 +1,56 @@
+package locale
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"id":     ID,
+		"in":     ID,
+		"id-ID":  ID,
+		"ID_id":  ID,
+		"en":     EN,
+		"en-US":  EN,
+		"en_gb":  EN,
+		" EN ":   EN,
+		"fr":     "",
+		"":       "",
+		"indo":   "",
+		"en_US_": EN,
+	}
+	for tag, want := range tests {
+		if got := Normalize(tag); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "default", want: Default},
+		{name: "query", query: "en", want: EN},
+		{name: "query wins over header", query: "id", acceptLanguage: "en-US", want: ID},
+		{name: "unknown query falls back to header", query: "fr", acceptLanguage: "en-US,en;q=0.9", want: EN},
+		{name: "header", acceptLanguage: "en-GB", want: EN},
+		{name: "first supported tag", acceptLanguage: "fr-FR, de;q=0.9, en;q=0.8", want: EN},
+		{name: "sorted by q", acceptLanguage: "id;q=0.5, en;q=0.9", want: EN},
+		{name: "equal q keeps order", acceptLanguage: "id, en", want: ID},
+		{name: "q=0 excluded", acceptLanguage: "en;q=0, fr", want: Default},
+		{name: "invalid q treated as 1", acceptLanguage: "id;q=0.5, en;q=abc", want: EN},
+		{name: "wildcard", acceptLanguage: "*", want: Default},
+		{name: "empty parts", acceptLanguage: ", ,en", want: EN},
+		{name: "unsupported only", acceptLanguage: "fr-FR,de", want: Default},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.query, tt.acceptLanguage); got != tt.want {
+				t.Errorf("Negotiate(%q, %q) = %q, want %q", tt.query, tt.acceptLanguage, got, tt.want)
+			}
+		})
+	}
+}
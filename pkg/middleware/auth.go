@@ -5,6 +5,7 @@ import (
 
 	"template-golang/internal/db/model"
 	"template-golang/pkg/helper"
+	"template-golang/pkg/i18n"
 	"template-golang/pkg/response"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,13 +16,13 @@ func AuthMiddleware(roles *[]string) fiber.Handler {
 		// Ambil token dari header
 		tokenString, err := helper.GetTokenFromHeader(c)
 		if err != nil {
-			return response.Json(c.Status(fiber.StatusUnauthorized), err.Error(), "Unauthorized")
+			return response.Json(c.Status(fiber.StatusUnauthorized), err.Error(), i18n.T(requestLocale(c), i18n.ErrorKey("UNAUTHORIZED"), nil))
 		}
 
 		// Verifikasi token
 		claims, err := helper.VerifyJwtToken(tokenString)
 		if err != nil {
-			return response.Json(c.Status(fiber.StatusUnauthorized), err.Error(), "Unauthorized")
+			return response.Json(c.Status(fiber.StatusUnauthorized), err.Error(), i18n.T(requestLocale(c), i18n.ErrorKey("UNAUTHORIZED"), nil))
 		}
 
 		// Extract data dari claims (asumsikan helper.VerifyJwtToken return jwt.MapClaims / custom struct)
@@ -45,7 +46,7 @@ func AuthMiddleware(roles *[]string) fiber.Handler {
 		// Role-based check
 		if len(*roles) > 0 {
 			if !containsRole(*roles, role) {
-				return response.Json(c.Status(fiber.StatusForbidden), nil, "Forbidden")
+				return response.Json(c.Status(fiber.StatusForbidden), nil, i18n.T(requestLocale(c), i18n.ErrorKey("FORBIDDEN"), nil))
 			}
 		}
 
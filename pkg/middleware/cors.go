@@ -6,11 +6,11 @@ import (
 )
 
 func CorsMiddleware() fiber.Handler {
-    return cors.New(cors.Config{
-        AllowOrigins:     "http://localhost:3000, https://myapp.com",
-        AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS",
-        AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
-        AllowCredentials: true,
-    })
+	return cors.New(cors.Config{
+		AllowOrigins:     "http://localhost:3000, https://myapp.com",
+		AllowMethods:     "GET,POST,PUT,PATCH,DELETE,OPTIONS",
+		AllowHeaders:     "Origin, Content-Type, Accept, Accept-Language, Authorization, Last-Event-ID, Idempotency-Key",
+		ExposeHeaders:    "Content-Language",
+		AllowCredentials: true,
+	})
 }
-
@@ -13,6 +13,8 @@ import (
 
 	"template-golang/pkg/apperror"
 	"template-golang/pkg/helper"
+	"template-golang/pkg/i18n"
+	"template-golang/pkg/locale"
 	"template-golang/pkg/logger"
 	"template-golang/pkg/response"
 
@@ -24,13 +26,24 @@ import (
 
 var Env = os.Getenv("SERVER_ENV") // bisa "dev" atau "prod"
 
+// requestLocale ambil locale hasil LocaleMiddleware, atau negosiasi ulang
+// kalau error terjadi sebelum middleware tersebut jalan
+func requestLocale(c *fiber.Ctx) string {
+	if l, ok := c.Locals(locale.ContextKey).(string); ok && l != "" {
+		return l
+	}
+	return locale.Negotiate(c.Query("lang"), c.Get(fiber.HeaderAcceptLanguage))
+}
+
 func ErrorHandlerFunc(c *fiber.Ctx, err error) error {
+	lang := requestLocale(c)
+
 	// ----------- 1. Default values -----------
 	if errors.Is(err, fiber.ErrRequestEntityTooLarge) {
-		return response.Json(c.Status(400), nil, "File size exceeds maximum limit")
+		return response.Json(c.Status(400), nil, i18n.T(lang, i18n.ErrorKey("REQUEST_TOO_LARGE"), nil))
 	}
 	statusCode := fiber.StatusInternalServerError
-	message := "Internal Server Error"
+	message := i18n.T(lang, i18n.ErrorKey("INTERNAL_ERROR"), nil)
 
 	// ----------- 2. Error khusus Fiber (404, dll.) -----------
 	if e, ok := err.(*fiber.Error); ok {
@@ -42,6 +55,8 @@ func ErrorHandlerFunc(c *fiber.Ctx, err error) error {
 	// ----------- 3. Error khusus app -----------
 	if appErr, ok := err.(*apperror.AppError); ok {
 		logger.L().Infof("AppError: %v", appErr)
+		appErr = appErr.Localize(lang)
+		err = appErr
 		statusCode = appErr.StatusCode
 		message = appErr.Message
 
@@ -54,14 +69,14 @@ func ErrorHandlerFunc(c *fiber.Ctx, err error) error {
 
 	if err == sql.ErrNoRows {
 		statusCode = fiber.StatusNotFound
-		message = "Resource not found"
+		message = i18n.T(lang, i18n.ErrorKey("NOT_FOUND"), nil)
 		return response.Json(c.Status(statusCode), nil, message)
 	}
 
 	// Handle GORM errors
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		statusCode = fiber.StatusNotFound
-		message = "Resource not found"
+		message = i18n.T(lang, i18n.ErrorKey("NOT_FOUND"), nil)
 		return response.Json(c.Status(statusCode), nil, message)
 	}
 
@@ -87,7 +102,10 @@ func ErrorHandlerFunc(c *fiber.Ctx, err error) error {
 				}
 			}
 			if column != "" && value != "" {
-				message = fmt.Sprintf("%s '%s' already exists", helper.FormatWord(column), value)
+				message = i18n.T(lang, i18n.ErrorKey("CONFLICT"), i18n.Params{
+					"field": helper.FormatWord(column),
+					"value": value,
+				})
 			} else {
 				message = detail
 			}
@@ -101,7 +119,7 @@ func ErrorHandlerFunc(c *fiber.Ctx, err error) error {
 	// ----------- 4. Error khusus lain (misal file terlalu besar) -----------
 	if err.Error() == "Request Entity Too Large" {
 		statusCode = fiber.StatusRequestEntityTooLarge
-		message = "File size exceeds maximum limit"
+		message = i18n.T(lang, i18n.ErrorKey("REQUEST_TOO_LARGE"), nil)
 		return response.Json(c.Status(fiber.StatusRequestEntityTooLarge), nil, message)
 	}
 
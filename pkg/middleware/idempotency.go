@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"template-golang/pkg/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// HeaderIdempotencyKey header dari client supaya retry request tidak membuat job dobel
+	HeaderIdempotencyKey = "Idempotency-Key"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotencyKey simpan header Idempotency-Key ke Locals "idempotency_key",
+// dibaca service lewat ctx.Value (lihat BaseService.EnqueueUploadFile)
+func IdempotencyKey() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(HeaderIdempotencyKey)
+		if len(key) > maxIdempotencyKeyLength {
+			return apperror.BadRequest("Idempotency-Key must be at most 255 characters")
+		}
+		if key != "" {
+			c.Locals("idempotency_key", key)
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"template-golang/pkg/locale"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LocaleMiddleware negosiasi bahasa dari ?lang= atau Accept-Language
+// lalu simpan ke Locals("locale")
+func LocaleMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		l := locale.Negotiate(c.Query("lang"), c.Get(fiber.HeaderAcceptLanguage))
+
+		c.Locals(locale.ContextKey, l)
+		c.Set(fiber.HeaderContentLanguage, locale.HTMLLang(l))
+		c.Vary(fiber.HeaderAcceptLanguage)
+
+		return c.Next()
+	}
+}
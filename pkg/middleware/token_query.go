@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// TokenFromQuery pakai token dari query param sebagai header Authorization
+// kalau header kosong. Hanya untuk endpoint yang tidak bisa kirim header,
+// misal EventSource (SSE) di browser. Pasang sebelum AuthMiddleware.
+func TokenFromQuery(param string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Get(fiber.HeaderAuthorization) == "" {
+			if token := c.Query(param); token != "" {
+				c.Request().Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+			}
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,108 @@
+package patch
+
+import (
+	"mime"
+	"net/http"
+	"runtime/debug"
+
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/i18n"
+	"template-golang/pkg/validator"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// ContentTypeMergePatch JSON Merge Patch (RFC 7396)
+	ContentTypeMergePatch = "application/merge-patch+json"
+	// ContentTypeJSONPatch JSON Patch (RFC 6902)
+	ContentTypeJSONPatch = "application/json-patch+json"
+)
+
+// Apply menerapkan body patch ke original sesuai content type.
+// application/json diperlakukan sebagai merge patch.
+func Apply[T any](contentType string, original T, body []byte) (T, error) {
+	var result T
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return result, apperror.NewT("INVALID_CONTENT_TYPE", http.StatusUnsupportedMediaType, err.Error(), nil)
+	}
+
+	doc, err := json.Marshal(original)
+	if err != nil {
+		return result, apperror.New("PATCH", "failed to marshal original document", http.StatusInternalServerError, err, string(debug.Stack()))
+	}
+
+	var patched []byte
+	switch mediaType {
+	case ContentTypeMergePatch, fiber.MIMEApplicationJSON:
+		patched, err = jsonpatch.MergePatch(doc, body)
+		if err != nil {
+			return result, apperror.NewT("INVALID_MERGE_PATCH", http.StatusBadRequest, err.Error(), nil)
+		}
+	case ContentTypeJSONPatch:
+		ops, err := jsonpatch.DecodePatch(body)
+		if err != nil {
+			return result, apperror.NewT("INVALID_JSON_PATCH", http.StatusBadRequest, err.Error(), nil)
+		}
+		patched, err = ops.Apply(doc)
+		if err != nil {
+			return result, apperror.NewT("PATCH_FAILED", http.StatusUnprocessableEntity, err.Error(), nil)
+		}
+	default:
+		return result, apperror.NewT("UNSUPPORTED_MEDIA_TYPE", http.StatusUnsupportedMediaType, mediaType, i18n.Params{
+			"expected": ContentTypeMergePatch + ", " + ContentTypeJSONPatch,
+		})
+	}
+
+	// Unmarshal ke value baru supaya field yang dihapus / di-null-kan benar-benar kosong
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return result, apperror.NewT("PATCH_SCHEMA_MISMATCH", http.StatusUnprocessableEntity, err.Error(), nil)
+	}
+	return result, nil
+}
+
+// Patch body request PATCH yang belum diterapkan. Diterapkan dengan Bind ke dokumen
+// terbaru yang dibaca di dalam transaksi (SELECT ... FOR UPDATE), supaya tidak ada update
+// yang hilang dan op "test" JSON Patch memeriksa data terbaru.
+type Patch struct {
+	ContentType string
+	Body        []byte
+}
+
+// Parse ambil patch dari request. Body disalin karena buffer fiber dipakai ulang.
+func Parse(c *fiber.Ctx) Patch {
+	return Patch{
+		ContentType: c.Get(fiber.HeaderContentType),
+		Body:        append([]byte(nil), c.Body()...),
+	}
+}
+
+// Rejected error Bind: patch tidak valid, op gagal atau hasilnya tidak lolos validasi.
+// Handler mengembalikan AppError-nya ke ErrorHandler apa adanya (status 4xx).
+type Rejected struct {
+	*apperror.AppError
+}
+
+// Bind menerapkan p ke current lalu memvalidasi hasilnya
+func Bind[T any](p Patch, current T) (T, error) {
+	result, err := Apply(p.ContentType, current, p.Body)
+	if err != nil {
+		return result, reject(err)
+	}
+
+	if err := validator.ValidateStruct(result); err != nil {
+		return result, reject(err)
+	}
+	return result, nil
+}
+
+func reject(err error) error {
+	if appErr, ok := err.(*apperror.AppError); ok {
+		return &Rejected{AppError: appErr}
+	}
+	return err
+}
@@ -0,0 +1,158 @@
+package patch
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"template-golang/pkg/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type doc struct {
+	Name  string   `json:"name" validate:"required"`
+	Email string   `json:"email" validate:"required,email"`
+	Note  *string  `json:"note"`
+	Tags  []string `json:"tags"`
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestApply(t *testing.T) {
+	original := doc{Name: "John", Email: "john@example.com", Note: strPtr("old"), Tags: []string{"a"}}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        doc
+		// code kode AppError yang diharapkan, kosong kalau berhasil
+		code   string
+		status int
+	}{
+		{
+			name:        "merge patch",
+			contentType: ContentTypeMergePatch,
+			body:        `{"name":"Jane"}`,
+			want:        doc{Name: "Jane", Email: "john@example.com", Note: strPtr("old"), Tags: []string{"a"}},
+		},
+		{
+			name:        "merge patch null clears field",
+			contentType: ContentTypeMergePatch + "; charset=utf-8",
+			body:        `{"note":null,"tags":["b","c"]}`,
+			want:        doc{Name: "John", Email: "john@example.com", Tags: []string{"b", "c"}},
+		},
+		{
+			name:        "application/json is merge patch",
+			contentType: fiber.MIMEApplicationJSON,
+			body:        `{"email":"jane@example.com"}`,
+			want:        doc{Name: "John", Email: "jane@example.com", Note: strPtr("old"), Tags: []string{"a"}},
+		},
+		{
+			name:        "json patch",
+			contentType: ContentTypeJSONPatch,
+			body:        `[{"op":"test","path":"/name","value":"John"},{"op":"replace","path":"/name","value":"Jane"},{"op":"add","path":"/tags/-","value":"b"},{"op":"remove","path":"/note"}]`,
+			want:        doc{Name: "Jane", Email: "john@example.com", Tags: []string{"a", "b"}},
+		},
+		{
+			name:        "json patch test op fails",
+			contentType: ContentTypeJSONPatch,
+			body:        `[{"op":"test","path":"/name","value":"Jane"},{"op":"replace","path":"/name","value":"Bob"}]`,
+			code:        "PATCH_FAILED",
+			status:      http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "json patch path missing",
+			contentType: ContentTypeJSONPatch,
+			body:        `[{"op":"replace","path":"/missing/field","value":"x"}]`,
+			code:        "PATCH_FAILED",
+			status:      http.StatusUnprocessableEntity,
+		},
+		{name: "invalid json patch", contentType: ContentTypeJSONPatch, body: `{"op":"replace"}`, code: "INVALID_JSON_PATCH", status: http.StatusBadRequest},
+		{name: "invalid merge patch", contentType: ContentTypeMergePatch, body: `{"name":`, code: "INVALID_MERGE_PATCH", status: http.StatusBadRequest},
+		{name: "schema mismatch", contentType: ContentTypeMergePatch, body: `{"tags":"not a list"}`, code: "PATCH_SCHEMA_MISMATCH", status: http.StatusUnprocessableEntity},
+		{name: "unsupported media type", contentType: "text/plain", body: `name=Jane`, code: "UNSUPPORTED_MEDIA_TYPE", status: http.StatusUnsupportedMediaType},
+		{name: "missing content type", contentType: "", body: `{}`, code: "INVALID_CONTENT_TYPE", status: http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply(tt.contentType, original, []byte(tt.body))
+			if tt.code != "" {
+				var appErr *apperror.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.code || appErr.StatusCode != tt.status {
+					t.Fatalf("Apply error = %v, want %s (%d)", err, tt.code, tt.status)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Apply = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if original.Name != "John" || *original.Note != "old" || len(original.Tags) != 1 {
+		t.Errorf("original modified: %+v", original)
+	}
+}
+
+func TestBind(t *testing.T) {
+	current := doc{Name: "John", Email: "john@example.com"}
+
+	got, err := Bind(Patch{ContentType: ContentTypeMergePatch, Body: []byte(`{"name":"Jane"}`)}, current)
+	if err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	if got.Name != "Jane" {
+		t.Errorf("Bind name = %q, want Jane", got.Name)
+	}
+
+	tests := []struct {
+		name  string
+		patch Patch
+		code  string
+	}{
+		{name: "invalid result", patch: Patch{ContentType: ContentTypeMergePatch, Body: []byte(`{"email":"not-an-email"}`)}, code: "VALIDATION_ERROR"},
+		{name: "required field removed", patch: Patch{ContentType: ContentTypeJSONPatch, Body: []byte(`[{"op":"remove","path":"/name"}]`)}, code: "VALIDATION_ERROR"},
+		{name: "failed op", patch: Patch{ContentType: ContentTypeJSONPatch, Body: []byte(`[{"op":"test","path":"/name","value":"Jane"}]`)}, code: "PATCH_FAILED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Bind(tt.patch, current)
+			var rejected *Rejected
+			if !errors.As(err, &rejected) || rejected.Code != tt.code {
+				t.Fatalf("Bind error = %v, want Rejected %s", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	var parsed Patch
+	app := fiber.New()
+	app.Patch("/", func(c *fiber.Ctx) error {
+		parsed = Parse(c)
+		return c.SendStatus(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`[{"op":"remove","path":"/note"}]`))
+	req.Header.Set(fiber.HeaderContentType, ContentTypeJSONPatch)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	io.Copy(io.Discard, resp.Body)
+
+	// body harus tetap utuh setelah request selesai (buffer fiber sudah dipakai ulang)
+	if parsed.ContentType != ContentTypeJSONPatch || string(parsed.Body) != `[{"op":"remove","path":"/note"}]` {
+		t.Errorf("Parse = %q %q", parsed.ContentType, parsed.Body)
+	}
+}
@@ -0,0 +1,210 @@
+package queue
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/redisx"
+)
+
+const dlqSuffix = ":dlq"
+
+// DeadJob job yang gagal permanen / habis percobaan, disimpan di dead-letter stream
+type DeadJob struct {
+	// ID id pesan di dead-letter stream
+	ID        string `json:"id"`
+	JobID     string `json:"job_id"`
+	Name      string `json:"name"`
+	MessageID string `json:"message_id"`
+	// Payload payload asli (JSON string)
+	Payload    string    `json:"payload"`
+	Error      string    `json:"error"`
+	Attempts   int64     `json:"attempts"`
+	EnqueuedAt time.Time `json:"enqueued_at"`
+	FailedAt   time.Time `json:"failed_at"`
+}
+
+// DeadLetterQueue ringkasan dead-letter stream per job
+type DeadLetterQueue struct {
+	Name   string `json:"name"`
+	Stream string `json:"stream"`
+	Count  int64  `json:"count"`
+}
+
+// DeadLetterStream nama dead-letter stream untuk job name, contoh "upload" -> "upload_jobs:dlq"
+func DeadLetterStream(name string) string {
+	return Stream(name) + dlqSuffix
+}
+
+// deadLetter ACK pesan job di stream group lalu pindahkan ke dead-letter stream dalam satu
+// operasi atomik. false kalau pesan sudah tidak pending (sudah di-ACK consumer lain).
+func deadLetter(ctx context.Context, c *redisx.Client, group, name, jobID, messageID, payload string, attempts int64, cause error) (bool, error) {
+	msg := ""
+	if cause != nil {
+		msg = cause.Error()
+	}
+	_, moved, err := c.AckMoveStreamMessage(ctx, Stream(name), group, messageID, DeadLetterStream(name), map[string]any{
+		"job_id":      jobID,
+		"name":        name,
+		"message_id":  messageID,
+		"payload":     payload,
+		"error":       msg,
+		"attempts":    attempts,
+		"enqueued_at": messageTime(messageID).Format(time.RFC3339Nano),
+		"failed_at":   now(),
+	})
+	return moved, err
+}
+
+// DeadLetterQueues semua dead-letter stream yang berisi job. Dicari dengan SCAN karena
+// proses API tidak punya daftar handler (Register hanya dipanggil di worker).
+func (c *Client) DeadLetterQueues(ctx context.Context) ([]DeadLetterQueue, error) {
+	keys, err := c.redis.ScanKeys(ctx, "*"+streamSuffix+dlqSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	queues := make([]DeadLetterQueue, 0, len(keys))
+	for _, key := range keys {
+		n, err := c.redis.StreamLen(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSuffix(strings.TrimSuffix(key, dlqSuffix), streamSuffix)
+		queues = append(queues, DeadLetterQueue{Name: name, Stream: key, Count: n})
+	}
+	return queues, nil
+}
+
+// ListDead job di dead-letter stream name, terbaru dulu
+func (c *Client) ListDead(ctx context.Context, name string, limit int64) ([]DeadJob, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	msgs, err := c.redis.StreamRevRange(ctx, DeadLetterStream(name), "+", "-", limit)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]DeadJob, 0, len(msgs))
+	for _, msg := range msgs {
+		jobs = append(jobs, toDeadJob(msg))
+	}
+	return jobs, nil
+}
+
+// GetDead satu job di dead-letter stream name
+func (c *Client) GetDead(ctx context.Context, name, id string) (*DeadJob, error) {
+	msgs, err := c.redis.StreamRange(ctx, DeadLetterStream(name), id, id, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 {
+		return nil, apperror.NotFound("dead job not found")
+	}
+
+	job := toDeadJob(msgs[0])
+	return &job, nil
+}
+
+// ReplayDead antrikan ulang job dari dead-letter stream (id job tetap) sekaligus hapus dari
+// DLQ secara atomik, jadi replay bersamaan tidak menggandakan job. Mengembalikan id job.
+func (c *Client) ReplayDead(ctx context.Context, name, id string) (string, error) {
+	job, err := c.GetDead(ctx, name, id)
+	if err != nil {
+		return "", err
+	}
+
+	_, moved, err := c.redis.MoveStreamMessage(ctx, DeadLetterStream(name), id, Stream(name), map[string]any{
+		"id":      job.JobID,
+		"name":    name,
+		"payload": job.Payload,
+	})
+	if err != nil {
+		return "", err
+	}
+	if !moved {
+		return "", apperror.NotFound("dead job not found")
+	}
+	// attempts dihitung ulang dari delivery count pesan baru
+	updateStatus(ctx, c.redis, job.JobID, map[string]any{
+		"name":        name,
+		"status":      string(StateQueued),
+		"attempts":    0,
+		"progress":    0,
+		"finished_at": "",
+	}, 0)
+	return job.JobID, nil
+}
+
+// ReplayAllDead antrikan ulang semua job di dead-letter stream name, mengembalikan id job
+func (c *Client) ReplayAllDead(ctx context.Context, name string) ([]string, error) {
+	ids := []string{}
+	start := "-"
+	for {
+		msgs, err := c.redis.StreamRange(ctx, DeadLetterStream(name), start, "+", 100)
+		if err != nil {
+			return ids, err
+		}
+		if len(msgs) == 0 {
+			return ids, nil
+		}
+		for _, msg := range msgs {
+			jobID, err := c.ReplayDead(ctx, name, msg.ID)
+			if err != nil {
+				return ids, err
+			}
+			ids = append(ids, jobID)
+		}
+		// pesan yang sudah di-replay terhapus, mulai setelah id terakhir
+		start = "(" + msgs[len(msgs)-1].ID
+	}
+}
+
+// PurgeDead hapus job dari dead-letter stream name, tanpa ids hapus semua.
+// Mengembalikan jumlah job yang dihapus.
+func (c *Client) PurgeDead(ctx context.Context, name string, ids ...string) (int64, error) {
+	if len(ids) > 0 {
+		return c.redis.StreamDelete(ctx, DeadLetterStream(name), ids...)
+	}
+
+	return c.redis.DeleteStream(ctx, DeadLetterStream(name))
+}
+
+func toDeadJob(msg redisx.Job) DeadJob {
+	values, _ := msg.Payload.(map[string]any)
+	str := func(key string) string {
+		s, _ := values[key].(string)
+		return s
+	}
+	at := func(key string) time.Time {
+		t, _ := time.Parse(time.RFC3339Nano, str(key))
+		return t
+	}
+	attempts, _ := strconv.ParseInt(str("attempts"), 10, 64)
+
+	return DeadJob{
+		ID:         msg.ID,
+		JobID:      str("job_id"),
+		Name:       str("name"),
+		MessageID:  str("message_id"),
+		Payload:    str("payload"),
+		Error:      str("error"),
+		Attempts:   attempts,
+		EnqueuedAt: at("enqueued_at"),
+		FailedAt:   at("failed_at"),
+	}
+}
+
+// messageTime waktu pesan dari id Redis Stream ("<ms>-<seq>")
+func messageTime(messageID string) time.Time {
+	ms, err := strconv.ParseInt(strings.SplitN(messageID, "-", 2)[0], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms).UTC()
+}
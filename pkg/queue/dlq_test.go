@@ -0,0 +1,154 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/redisx"
+)
+
+// deadJobs Enqueue n job name lalu pindahkan semuanya ke dead-letter stream
+func deadJobs(t *testing.T, client *redisx.Client, name string, n int) []string {
+	t.Helper()
+	ctx := context.Background()
+	w := newTestWorker(t, client, name)
+
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		id, err := NewClient(client).Enqueue(ctx, name, testPayload{Value: name})
+		if err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+		msg := consumeOne(t, w, name)
+		moved, err := deadLetter(ctx, client, w.opts.Group, name, id, msg.ID, `{"value":"`+name+`"}`, 3, errors.New("boom"))
+		if err != nil || !moved {
+			t.Fatalf("deadLetter moved=%v err=%v", moved, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestDeadLetterQueues(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	deadJobs(t, client, "dlq_a", 2)
+	deadJobs(t, client, "dlq_b", 1)
+
+	queues, err := NewClient(client).DeadLetterQueues(ctx)
+	if err != nil {
+		t.Fatalf("DeadLetterQueues: %v", err)
+	}
+	counts := map[string]int64{}
+	for _, q := range queues {
+		counts[q.Name] = q.Count
+		if q.Stream != DeadLetterStream(q.Name) {
+			t.Errorf("stream = %s, want %s", q.Stream, DeadLetterStream(q.Name))
+		}
+	}
+	if len(counts) != 2 || counts["dlq_a"] != 2 || counts["dlq_b"] != 1 {
+		t.Errorf("queues = %+v", queues)
+	}
+}
+
+func TestReplayDead(t *testing.T) {
+	const name = "dlq_replay"
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	q := NewClient(client)
+	ids := deadJobs(t, client, name, 1)
+
+	dead, err := q.ListDead(ctx, name, 10)
+	if err != nil || len(dead) != 1 {
+		t.Fatalf("ListDead = %+v, %v", dead, err)
+	}
+	got, err := q.GetDead(ctx, name, dead[0].ID)
+	if err != nil || got.JobID != ids[0] || got.Attempts != 3 || got.Error != "boom" || got.EnqueuedAt.IsZero() {
+		t.Fatalf("GetDead = %+v, %v", got, err)
+	}
+
+	jobID, err := q.ReplayDead(ctx, name, dead[0].ID)
+	if err != nil || jobID != ids[0] {
+		t.Fatalf("ReplayDead = %s, %v", jobID, err)
+	}
+	if status := mustStatus(t, client, jobID); status.Status != StateQueued || status.Attempts != 0 {
+		t.Errorf("status after replay = %+v", status)
+	}
+
+	// pesan baru di stream dengan id job & payload yang sama
+	msgs, err := client.StreamRange(ctx, Stream(name), "-", "+", 10)
+	if err != nil || len(msgs) != 1 {
+		t.Fatalf("stream = %+v, %v", msgs, err)
+	}
+	values := msgs[0].Payload.(map[string]any)
+	if values["id"] != jobID || values["payload"] != `{"value":"`+name+`"}` {
+		t.Errorf("replayed message = %+v", values)
+	}
+
+	// replay kedua (bersamaan / diulang) tidak menggandakan job
+	var appErr *apperror.AppError
+	if _, err := q.ReplayDead(ctx, name, dead[0].ID); !errors.As(err, &appErr) || appErr.StatusCode != 404 {
+		t.Errorf("second ReplayDead error = %v, want not found", err)
+	}
+	if n, _ := client.StreamLen(ctx, Stream(name)); n != 1 {
+		t.Errorf("stream length = %d, want 1", n)
+	}
+}
+
+func TestReplayAllDead(t *testing.T) {
+	const name = "dlq_replay_all"
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	ids := deadJobs(t, client, name, 3)
+
+	replayed, err := NewClient(client).ReplayAllDead(ctx, name)
+	if err != nil {
+		t.Fatalf("ReplayAllDead: %v", err)
+	}
+	if len(replayed) != len(ids) {
+		t.Fatalf("replayed %v, want %v", replayed, ids)
+	}
+	for i := range ids {
+		if replayed[i] != ids[i] {
+			t.Errorf("replayed[%d] = %s, want %s", i, replayed[i], ids[i])
+		}
+	}
+	if n, _ := client.StreamLen(ctx, DeadLetterStream(name)); n != 0 {
+		t.Errorf("dead-letter stream length = %d, want 0", n)
+	}
+	if n, _ := client.StreamLen(ctx, Stream(name)); n != 3 {
+		t.Errorf("stream length = %d, want 3", n)
+	}
+}
+
+func TestPurgeDead(t *testing.T) {
+	const name = "dlq_purge"
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	q := NewClient(client)
+	deadJobs(t, client, name, 3)
+
+	dead, err := q.ListDead(ctx, name, 10)
+	if err != nil {
+		t.Fatalf("ListDead: %v", err)
+	}
+	n, err := q.PurgeDead(ctx, name, dead[0].ID)
+	if err != nil || n != 1 {
+		t.Fatalf("PurgeDead(id) = %d, %v", n, err)
+	}
+
+	n, err = q.PurgeDead(ctx, name)
+	if err != nil || n != 2 {
+		t.Fatalf("PurgeDead(all) = %d, %v", n, err)
+	}
+	if queues, _ := q.DeadLetterQueues(ctx); len(queues) != 0 {
+		t.Errorf("queues after purge = %+v", queues)
+	}
+
+	// stream yang sudah kosong
+	if n, err := q.PurgeDead(ctx, name); err != nil || n != 0 {
+		t.Errorf("PurgeDead(empty) = %d, %v", n, err)
+	}
+}
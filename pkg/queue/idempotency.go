@@ -0,0 +1,165 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"template-golang/pkg/logger"
+	"template-golang/pkg/redisx"
+
+	"github.com/nrednav/cuid2"
+)
+
+const (
+	// IdempotencyTTL lama idempotency key producer diingat
+	IdempotencyTTL = 24 * time.Hour
+
+	idempotencyPrefix = "queue:idempotency:"
+	processedPrefix   = "queue:processed:"
+	processingPrefix  = "queue:processing:"
+)
+
+// ErrDuplicate job dengan idempotency key yang sama sudah pernah di-Enqueue,
+// Enqueue mengembalikan id job yang lama bersama error ini
+var ErrDuplicate = errors.New("queue: job with the same idempotency key already enqueued")
+
+// ================== PRODUCER ==================
+
+// IdempotencyKey Enqueue dengan key yang sama (per job name) selama IdempotencyTTL
+// tidak membuat job baru, tapi mengembalikan id job pertama dan ErrDuplicate
+func IdempotencyKey(key string) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+func idempotencyKey(name, key string) string {
+	return idempotencyPrefix + name + ":" + key
+}
+
+// LookupIdempotencyKey id job yang di-Enqueue dengan key, kosong kalau belum ada.
+// Dipakai untuk melewati pekerjaan mahal (simpan file tmp, dll.) saat client retry.
+func (c *Client) LookupIdempotencyKey(ctx context.Context, name, key string) (string, error) {
+	if key == "" {
+		return "", nil
+	}
+	id, _, err := c.redis.Lookup(ctx, idempotencyKey(name, key))
+	return id, err
+}
+
+// ClaimIdempotencyKey klaim key untuk id job yang baru akan di-Enqueue (JobID), misal
+// setelah transaksi commit. Kalau key sudah dipakai, mengembalikan id job lama dan ErrDuplicate.
+// Lepas dengan ReleaseIdempotencyKey kalau job batal di-Enqueue.
+func (c *Client) ClaimIdempotencyKey(ctx context.Context, name, key, id string) (string, error) {
+	return claimIdempotencyKey(ctx, c.redis, name, key, id)
+}
+
+// ReleaseIdempotencyKey lepas key hasil ClaimIdempotencyKey
+func (c *Client) ReleaseIdempotencyKey(ctx context.Context, name, key string) error {
+	return c.redis.Del(ctx, idempotencyKey(name, key))
+}
+
+// claimIdempotencyKey simpan key -> id, kalau key sudah dipakai kembalikan id job lama
+func claimIdempotencyKey(ctx context.Context, c *redisx.Client, name, key, id string) (string, error) {
+	ok, err := c.SetNX(ctx, idempotencyKey(name, key), id, IdempotencyTTL)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return "", nil
+	}
+	existing, _, err := c.Lookup(ctx, idempotencyKey(name, key))
+	if err != nil {
+		return "", err
+	}
+	return existing, ErrDuplicate
+}
+
+// ================== CONSUMER ==================
+
+// Idempotent handler aman dijalankan ulang untuk job yang sama,
+// worker tidak mencatat job yang sudah selesai di ledger
+func Idempotent() HandlerOption {
+	return func(o *handlerOptions) {
+		o.idempotent = true
+		o.dedupe = false
+	}
+}
+
+// Dedupe selain ledger, job yang sama tidak boleh berjalan bersamaan di dua consumer
+// (misal pesan diambil alih ReclaimLoop saat handler lama masih berjalan).
+// Pakai untuk handler dengan efek samping yang tidak bisa diulang.
+func Dedupe() HandlerOption {
+	return func(o *handlerOptions) {
+		o.dedupe = true
+		o.idempotent = false
+	}
+}
+
+func processedKey(name, id string) string {
+	return processedPrefix + name + ":" + id
+}
+
+func processingKey(name, id string) string {
+	return processingPrefix + name + ":" + id
+}
+
+// processed cek ledger apakah job sudah pernah selesai
+func (w *Worker) processed(ctx context.Context, reg *registration, jobID string) (bool, error) {
+	_, ok, err := w.client.Lookup(ctx, processedKey(reg.name, jobID))
+	return ok, err
+}
+
+// markProcessed catat job selesai di ledger sebelum ACK, supaya pesan yang
+// terkirim ulang (ACK gagal, consumer crash, pesan dobel) tidak diproses lagi
+func (w *Worker) markProcessed(ctx context.Context, reg *registration, jobID, messageID string) {
+	if err := w.client.Set(ctx, processedKey(reg.name, jobID), messageID, w.opts.LedgerTTL); err != nil {
+		logger.L().Warnf("job %s (%s): failed to record in ledger: %v", jobID, reg.name, err)
+	}
+}
+
+// lease tandai job sedang berjalan (handler Dedupe) dan perpanjang selama handler
+// berjalan. false kalau job yang sama sedang berjalan di consumer lain, pesan
+// dibiarkan pending dan dicek lagi oleh ReclaimLoop.
+func (w *Worker) lease(ctx context.Context, reg *registration, jobID string) (func(), bool) {
+	key := processingKey(reg.name, jobID)
+	token := cuid2.Generate()
+	ttl := w.opts.ReclaimIdle
+
+	ok, err := w.client.AcquireLock(ctx, key, token, ttl)
+	if err != nil {
+		logger.L().Errorf("job %s (%s): failed to acquire lease: %v", jobID, reg.name, err)
+		return nil, false
+	}
+	if !ok {
+		logger.L().Warnf("job %s (%s): already running on another consumer, skipped", jobID, reg.name)
+		return nil, false
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if _, err := w.client.RefreshLock(ctx, key, token, ttl); err != nil {
+					logger.L().Warnf("job %s (%s): failed to refresh lease: %v", jobID, reg.name, err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+		if err := w.client.ReleaseLock(context.WithoutCancel(ctx), key, token); err != nil {
+			logger.L().Warnf("job %s (%s): failed to release lease: %v", jobID, reg.name, err)
+		}
+	}, true
+}
@@ -0,0 +1,178 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEnqueueIdempotencyKey(t *testing.T) {
+	const name = "test_idempotency"
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	q := NewClient(client)
+
+	first, err := q.Enqueue(ctx, name, testPayload{}, IdempotencyKey("k1"))
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	second, err := q.Enqueue(ctx, name, testPayload{}, IdempotencyKey("k1"))
+	if !errors.Is(err, ErrDuplicate) || second != first {
+		t.Fatalf("duplicate Enqueue = %s, %v, want %s, ErrDuplicate", second, err, first)
+	}
+	if n, _ := client.StreamLen(ctx, Stream(name)); n != 1 {
+		t.Errorf("stream length = %d, want 1", n)
+	}
+
+	// key dicatat per job name
+	if _, err := q.Enqueue(ctx, name+"_other", testPayload{}, IdempotencyKey("k1")); err != nil {
+		t.Errorf("same key on another job: %v", err)
+	}
+	if id, err := q.LookupIdempotencyKey(ctx, name, "k1"); err != nil || id != first {
+		t.Errorf("LookupIdempotencyKey = %s, %v", id, err)
+	}
+}
+
+func TestClaimIdempotencyKeyBeforeEnqueue(t *testing.T) {
+	const name = "test_claim"
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	q := NewClient(client)
+
+	// id job dibuat & key diklaim di request, Enqueue setelah transaksi commit
+	id := NewJobID()
+	if existing, err := q.ClaimIdempotencyKey(ctx, name, "k1", id); err != nil || existing != "" {
+		t.Fatalf("ClaimIdempotencyKey = %s, %v", existing, err)
+	}
+	if existing, err := q.ClaimIdempotencyKey(ctx, name, "k1", NewJobID()); !errors.Is(err, ErrDuplicate) || existing != id {
+		t.Fatalf("second claim = %s, %v, want %s, ErrDuplicate", existing, err, id)
+	}
+	if got, err := q.Enqueue(ctx, name, testPayload{}, JobID(id), IdempotencyKey("k1")); err != nil || got != id {
+		t.Fatalf("Enqueue claimed key = %s, %v", got, err)
+	}
+
+	// job batal: key dilepas dan bisa dipakai lagi
+	other := NewJobID()
+	if err := q.ReleaseIdempotencyKey(ctx, name, "k1"); err != nil {
+		t.Fatalf("ReleaseIdempotencyKey: %v", err)
+	}
+	if existing, err := q.ClaimIdempotencyKey(ctx, name, "k1", other); err != nil || existing != "" {
+		t.Errorf("claim after release = %s, %v", existing, err)
+	}
+}
+
+func TestProcessSkipsJobInLedger(t *testing.T) {
+	const name = "test_ledger"
+	var calls atomic.Int64
+	reg := registerTest(t, name, func(ctx context.Context, job Job[testPayload]) error {
+		calls.Add(1)
+		return nil
+	})
+
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	w := newTestWorker(t, client, name)
+	q := NewClient(client)
+
+	id, err := q.Enqueue(ctx, name, testPayload{})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	w.process(ctx, reg, "test-1", consumeOne(t, w, name))
+
+	// pesan yang sama terkirim dua kali (misal replay setelah ACK gagal)
+	if _, err := q.Enqueue(ctx, name, testPayload{}, JobID(id)); err != nil {
+		t.Fatalf("Enqueue again: %v", err)
+	}
+	msg := consumeOne(t, w, name)
+	w.process(ctx, reg, "test-1", msg)
+
+	if calls.Load() != 1 {
+		t.Errorf("handler called %d times, want 1", calls.Load())
+	}
+	if n, _ := client.DeliveryCount(ctx, Stream(name), w.opts.Group, msg.ID); n != 0 {
+		t.Errorf("skipped message still pending (delivery count %d)", n)
+	}
+}
+
+func TestProcessIdempotentHandlerRunsAgain(t *testing.T) {
+	const name = "test_idempotent"
+	var calls atomic.Int64
+	reg := registerTest(t, name, func(ctx context.Context, job Job[testPayload]) error {
+		calls.Add(1)
+		return nil
+	}, Idempotent())
+
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	w := newTestWorker(t, client, name)
+	q := NewClient(client)
+
+	id, err := q.Enqueue(ctx, name, testPayload{})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	w.process(ctx, reg, "test-1", consumeOne(t, w, name))
+	if _, err := q.Enqueue(ctx, name, testPayload{}, JobID(id)); err != nil {
+		t.Fatalf("Enqueue again: %v", err)
+	}
+	w.process(ctx, reg, "test-1", consumeOne(t, w, name))
+
+	if calls.Load() != 2 {
+		t.Errorf("handler called %d times, want 2 (no ledger)", calls.Load())
+	}
+	if _, ok, _ := client.Lookup(ctx, processedKey(name, id)); ok {
+		t.Error("idempotent handler recorded in ledger")
+	}
+}
+
+func TestProcessDedupeSkipsJobRunningElsewhere(t *testing.T) {
+	const name = "test_dedupe"
+	var calls atomic.Int64
+	reg := registerTest(t, name, func(ctx context.Context, job Job[testPayload]) error {
+		calls.Add(1)
+		return nil
+	}, Dedupe(), MaxAttempts(1))
+
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	w := newTestWorker(t, client, name)
+
+	id, err := NewClient(client).Enqueue(ctx, name, testPayload{})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	first := consumeOne(t, w, name)
+
+	// consumer lain masih menjalankan job ini (lease dipegang)
+	if ok, err := client.AcquireLock(ctx, processingKey(name, id), "other", time.Minute); err != nil || !ok {
+		t.Fatalf("AcquireLock = %v, %v", ok, err)
+	}
+	msg := reclaimOne(t, w, name)
+	w.process(ctx, reg, "test-reclaim", msg)
+	if calls.Load() != 0 {
+		t.Fatalf("handler ran while another consumer holds the lease")
+	}
+	// tidak dipindah ke dead-letter stream walaupun melewati MaxAttempts
+	if n, _ := client.StreamLen(ctx, DeadLetterStream(name)); n != 0 {
+		t.Fatalf("job running elsewhere moved to dead-letter stream")
+	}
+	if n, _ := client.DeliveryCount(ctx, Stream(name), w.opts.Group, first.ID); n == 0 {
+		t.Fatal("skipped message no longer pending")
+	}
+
+	// lease dilepas: percobaan sudah habis, baru sekarang pindah ke dead-letter stream
+	if err := client.ReleaseLock(ctx, processingKey(name, id), "other"); err != nil {
+		t.Fatalf("ReleaseLock: %v", err)
+	}
+	w.process(ctx, reg, "test-reclaim", reclaimOne(t, w, name))
+	if calls.Load() != 0 {
+		t.Errorf("handler called %d times, want 0", calls.Load())
+	}
+	assertDead(t, client, name, id, 2, "exceeded 1 attempts")
+	if _, ok, _ := client.Lookup(ctx, processingKey(name, id)); ok {
+		t.Error("lease not released")
+	}
+}
@@ -0,0 +1,351 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/redisx"
+
+	"github.com/goccy/go-json"
+	"github.com/nrednav/cuid2"
+)
+
+const (
+	// DefaultGroup consumer group Redis Stream untuk semua job
+	DefaultGroup = "worker"
+
+	streamSuffix  = "_jobs"
+	delayedSuffix = ":delayed"
+)
+
+// Job job yang diterima handler
+type Job[T any] struct {
+	// ID id job, sama dengan yang dikembalikan Enqueue
+	ID string
+	// MessageID id pesan di Redis Stream
+	MessageID string
+	Name      string
+	// Attempt percobaan ke berapa (delivery count Redis Stream), mulai dari 1
+	Attempt int64
+	// MaxAttempts batas percobaan dari opsi MaxAttempts, Attempt == MaxAttempts berarti percobaan terakhir
+	MaxAttempts int64
+	Payload     T
+
+	// client Redis worker, dipakai Progress & SetResult
+	client *redisx.Client
+}
+
+// Handler memproses satu job bertipe T
+type Handler[T any] func(ctx context.Context, job Job[T]) error
+
+// Codec encode / decode payload job
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec codec default, payload disimpan sebagai JSON string
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (JSONCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// DefaultCodec dipakai producer (Enqueue) dan worker
+var DefaultCodec Codec = JSONCodec{}
+
+type handlerOptions struct {
+	maxAttempts int64
+	// idempotent tanpa ledger, dedupe ledger + lease per job
+	idempotent bool
+	dedupe     bool
+}
+
+// HandlerOption opsi saat Register
+type HandlerOption func(*handlerOptions)
+
+// MaxAttempts jumlah percobaan (delivery) sebelum job dianggap gagal (default 5).
+// Jeda antar percobaan = WorkerOptions.ReclaimIdle.
+func MaxAttempts(n int) HandlerOption {
+	return func(o *handlerOptions) {
+		if n > 0 {
+			o.maxAttempts = int64(n)
+		}
+	}
+}
+
+type registration struct {
+	name   string
+	stream string
+	opts   handlerOptions
+	handle func(ctx context.Context, c *redisx.Client, id, messageID string, attempt int64, data []byte) error
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*registration{}
+)
+
+// Register daftarkan handler bertipe untuk job name.
+// Biasanya dipanggil dari jobs.Register di internal/jobs.
+func Register[T any](name string, handler Handler[T], opts ...HandlerOption) {
+	o := handlerOptions{
+		maxAttempts: 5,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	reg := &registration{
+		name:   name,
+		stream: Stream(name),
+		opts:   o,
+		handle: func(ctx context.Context, c *redisx.Client, id, messageID string, attempt int64, data []byte) error {
+			var payload T
+			if err := DefaultCodec.Unmarshal(data, &payload); err != nil {
+				return Permanent(fmt.Errorf("failed to decode payload: %w", err))
+			}
+			return handler(ctx, Job[T]{ID: id, MessageID: messageID, Name: name, Attempt: attempt, MaxAttempts: o.maxAttempts, Payload: payload, client: c})
+		},
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[name]; exists {
+		panic("queue: handler already registered for job " + name)
+	}
+	registry[name] = reg
+}
+
+// Registered nama job yang punya handler, terurut
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func registrations() []*registration {
+	names := Registered()
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	regs := make([]*registration, 0, len(names))
+	for _, name := range names {
+		regs = append(regs, registry[name])
+	}
+	return regs
+}
+
+// Stream nama Redis Stream untuk job name, contoh "upload" -> "upload_jobs"
+func Stream(name string) string {
+	return name + streamSuffix
+}
+
+// ================== PERMANENT ERROR ==================
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent tandai error yang tidak perlu di-retry (payload rusak, file hilang, dll.)
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent cek apakah error ditandai Permanent
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// ================== PRODUCER ==================
+
+// Client producer job: Enqueue, status job dan dead-letter stream.
+// Dibuat sekali (wire / CLI) lalu diteruskan ke yang membutuhkan.
+type Client struct {
+	redis *redisx.Client
+}
+
+func NewClient(redis *redisx.Client) *Client {
+	return &Client{redis: redis}
+}
+
+type enqueueOptions struct {
+	id             string
+	runAt          time.Time
+	owner          string
+	idempotencyKey string
+}
+
+// EnqueueOption opsi saat Enqueue
+type EnqueueOption func(*enqueueOptions)
+
+// NewJobID id job baru, untuk JobID
+func NewJobID() string {
+	return cuid2.Generate()
+}
+
+// JobID pakai id job yang sudah dibuat sebelumnya (NewJobID), misal supaya id job bisa
+// dikembalikan ke client sebelum job di-Enqueue setelah transaksi commit
+func JobID(id string) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.id = id
+	}
+}
+
+// Delay jalankan job setelah d
+func Delay(d time.Duration) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.runAt = time.Now().Add(d)
+	}
+}
+
+// RunAt jalankan job pada waktu t
+func RunAt(t time.Time) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.runAt = t
+	}
+}
+
+// Owner user pemilik job, dipakai untuk membatasi akses status & event job
+func Owner(userID string) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.owner = userID
+	}
+}
+
+// DelayedKey sorted set job tertunda untuk job name, contoh "upload" -> "upload_jobs:delayed"
+func DelayedKey(name string) string {
+	return Stream(name) + delayedSuffix
+}
+
+// Enqueue antrikan payload untuk job name, mengembalikan id job.
+// Dengan Delay / RunAt job disimpan dulu di DelayedKey sampai waktunya.
+// Dengan IdempotencyKey yang sudah pernah dipakai, mengembalikan id job lama dan ErrDuplicate.
+func (c *Client) Enqueue(ctx context.Context, name string, payload any, opts ...EnqueueOption) (string, error) {
+	return enqueue(ctx, c.redis, name, payload, opts...)
+}
+
+func enqueue(ctx context.Context, c *redisx.Client, name string, payload any, opts ...EnqueueOption) (string, error) {
+	var o enqueueOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	data, err := DefaultCodec.Marshal(payload)
+	if err != nil {
+		return "", apperror.New("queue", "Enqueue", 500, err, "failed to marshal job payload")
+	}
+
+	id := o.id
+	if id == "" {
+		id = cuid2.Generate()
+	}
+	if o.idempotencyKey != "" {
+		// key yang sudah diklaim untuk id ini (ClaimIdempotencyKey) bukan duplikat
+		existing, err := claimIdempotencyKey(ctx, c, name, o.idempotencyKey, id)
+		if err != nil && (!errors.Is(err, ErrDuplicate) || existing != id) {
+			return existing, err
+		}
+	}
+
+	values := map[string]any{
+		"id":      id,
+		"name":    name,
+		"payload": string(data),
+	}
+
+	// status ditulis sebelum pesan masuk stream supaya worker selalu menemukannya
+	status := map[string]any{
+		"name":        name,
+		"status":      string(StateQueued),
+		"owner":       o.owner,
+		"attempts":    0,
+		"progress":    0,
+		"enqueued_at": now(),
+	}
+	delayed := o.runAt.After(time.Now())
+	ttl := StatusTTL
+	if delayed {
+		status["run_at"] = o.runAt.UTC().Format(time.RFC3339Nano)
+		ttl += time.Until(o.runAt)
+	}
+	updateStatus(ctx, c, id, status, ttl)
+
+	if delayed {
+		err = c.ScheduleToStream(ctx, DelayedKey(name), o.runAt, values)
+	} else {
+		_, err = c.AddToStream(ctx, Stream(name), values)
+	}
+	if err != nil {
+		if err := c.Del(ctx, statusKey(id)); err != nil {
+			logger.L().Warnf("queue: failed to delete status of job %s: %v", id, err)
+		}
+		if o.idempotencyKey != "" {
+			if err := c.Del(ctx, idempotencyKey(name, o.idempotencyKey)); err != nil {
+				logger.L().Warnf("queue: failed to release idempotency key of job %s: %v", id, err)
+			}
+		}
+		return "", err
+	}
+	return id, nil
+}
+
+// livePageSize jumlah pesan stream yang dibaca per XRANGE oleh LivePayloads
+const livePageSize = 500
+
+// LivePayloads payload job name yang masih hidup: masih di stream (antri, berjalan atau
+// menunggu retry) atau tertunda di DelayedKey. Pesan yang sudah di-ACK tapi belum terpangkas
+// dari stream ikut terhitung, job yang sudah di dead-letter stream tidak.
+func (c *Client) LivePayloads(ctx context.Context, name string) ([]string, error) {
+	var payloads []string
+	start := "-"
+	for {
+		msgs, err := c.redis.StreamRange(ctx, Stream(name), start, "+", livePageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, msg := range msgs {
+			if values, ok := msg.Payload.(map[string]any); ok {
+				if data, ok := values["payload"].(string); ok {
+					payloads = append(payloads, data)
+				}
+			}
+		}
+		if len(msgs) < livePageSize {
+			break
+		}
+		start = "(" + msgs[len(msgs)-1].ID
+	}
+
+	members, err := c.redis.ZRangeByScore(ctx, DelayedKey(name), "-inf", "+inf")
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		var values map[string]any
+		if err := json.Unmarshal([]byte(member), &values); err != nil {
+			continue
+		}
+		if data, ok := values["payload"].(string); ok {
+			payloads = append(payloads, data)
+		}
+	}
+	return payloads, nil
+}
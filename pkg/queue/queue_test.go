@@ -0,0 +1,115 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"template-golang/pkg/config"
+	"template-golang/pkg/redisx"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// newTestRedis Redis in-memory (miniredis) untuk satu test
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redisx.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	t.Setenv("REDIS_ADDR", mr.Addr())
+	config.LoadConfig()
+
+	client, err := redisx.New()
+	if err != nil {
+		t.Fatalf("redisx.New: %v", err)
+	}
+	return mr, client
+}
+
+// newTestWorker worker dengan consumer group siap untuk job name, tanpa menjalankan Run
+func newTestWorker(t *testing.T, client *redisx.Client, name string) *Worker {
+	t.Helper()
+	w := NewWorker(client, WorkerOptions{Consumer: "test", ReclaimIdle: time.Hour})
+	if err := client.InitConsumerGroup(context.Background(), Stream(name), w.opts.Group); err != nil {
+		t.Fatalf("InitConsumerGroup: %v", err)
+	}
+	return w
+}
+
+// registerTest Register handler untuk satu test, dihapus lagi dari registry setelah test
+// selesai supaya test bisa diulang (-count)
+func registerTest[T any](t *testing.T, name string, handler Handler[T], opts ...HandlerOption) *registration {
+	t.Helper()
+	Register(name, handler, opts...)
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		delete(registry, name)
+	})
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return registry[name]
+}
+
+// consumeOne baca satu pesan baru dari stream job name
+func consumeOne(t *testing.T, w *Worker, name string) redisx.Job {
+	t.Helper()
+	msgs, err := w.client.ConsumeJob(context.Background(), Stream(name), w.opts.Group, "test-1", 1, time.Millisecond)
+	if err != nil {
+		t.Fatalf("ConsumeJob: %v", err)
+	}
+	if len(msgs) != 1 {
+		t.Fatalf("ConsumeJob got %d messages, want 1", len(msgs))
+	}
+	return msgs[0]
+}
+
+// reclaimOne ambil alih pesan pending job name seperti ReclaimLoop
+func reclaimOne(t *testing.T, w *Worker, name string) redisx.Job {
+	t.Helper()
+	msgs, err := w.client.ReclaimJobs(context.Background(), Stream(name), w.opts.Group, "test-reclaim", 0, 1)
+	if err != nil {
+		t.Fatalf("ReclaimJobs: %v", err)
+	}
+	if len(msgs) != 1 {
+		t.Fatalf("ReclaimJobs got %d messages, want 1", len(msgs))
+	}
+	return msgs[0]
+}
+
+func mustStatus(t *testing.T, client *redisx.Client, id string) *Status {
+	t.Helper()
+	status, err := getStatus(context.Background(), client, id)
+	if err != nil {
+		t.Fatalf("getStatus %s: %v", id, err)
+	}
+	return status
+}
+
+func TestLivePayloads(t *testing.T) {
+	const name = "test_live"
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	q := NewClient(client)
+
+	// lebih dari satu halaman XRANGE
+	for i := 0; i < livePageSize+1; i++ {
+		if _, err := q.Enqueue(ctx, name, testPayload{Value: "queued"}); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+	if _, err := q.Enqueue(ctx, name, testPayload{Value: "delayed"}, Delay(time.Hour)); err != nil {
+		t.Fatalf("Enqueue delayed: %v", err)
+	}
+
+	payloads, err := q.LivePayloads(ctx, name)
+	if err != nil {
+		t.Fatalf("LivePayloads: %v", err)
+	}
+	if len(payloads) != livePageSize+2 {
+		t.Fatalf("LivePayloads returned %d payloads, want %d", len(payloads), livePageSize+2)
+	}
+	if last := payloads[len(payloads)-1]; last != `{"value":"delayed"}` {
+		t.Errorf("delayed payload = %s", last)
+	}
+}
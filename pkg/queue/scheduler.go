@@ -0,0 +1,201 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"template-golang/pkg/logger"
+	"template-golang/pkg/redisx"
+
+	"github.com/robfig/cron/v3"
+)
+
+// DefaultSchedulerLock key lock Redis untuk leader scheduler
+const DefaultSchedulerLock = "queue:scheduler:leader"
+
+type schedule struct {
+	name     string
+	spec     string
+	schedule cron.Schedule
+	job      string
+	payload  any
+}
+
+var (
+	schedulesMu sync.RWMutex
+	schedules   = map[string]*schedule{}
+)
+
+// Schedule daftarkan job berulang dengan format cron 5 field ("0 2 * * *")
+// atau descriptor ("@daily", "@every 1h"). Setiap kali jatuh tempo, payload
+// di-Enqueue ke job oleh satu instance worker saja (leader).
+// Biasanya dipanggil dari jobs.Register di internal/jobs.
+func Schedule(name, spec, job string, payload any) {
+	sched, err := cron.ParseStandard(spec)
+	if err != nil {
+		panic("queue: invalid schedule " + name + ": " + err.Error())
+	}
+
+	schedulesMu.Lock()
+	defer schedulesMu.Unlock()
+	if _, exists := schedules[name]; exists {
+		panic("queue: schedule already registered " + name)
+	}
+	schedules[name] = &schedule{name: name, spec: spec, schedule: sched, job: job, payload: payload}
+}
+
+// Schedules nama schedule yang terdaftar, terurut
+func Schedules() []string {
+	schedulesMu.RLock()
+	defer schedulesMu.RUnlock()
+	names := make([]string, 0, len(schedules))
+	for name := range schedules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SchedulerOptions konfigurasi Scheduler
+type SchedulerOptions struct {
+	// LockKey key lock leader, default DefaultSchedulerLock
+	LockKey string
+	// LockTTL masa berlaku lock, diperpanjang setiap LockTTL/3
+	LockTTL time.Duration
+	// Token identitas instance pemegang lock, default DefaultConsumer()
+	Token string
+}
+
+// Scheduler menjalankan schedule terdaftar. Semua instance menjalankan cron,
+// tapi hanya pemegang lock Redis (leader) yang meng-Enqueue job.
+type Scheduler struct {
+	client *redisx.Client
+	opts   SchedulerOptions
+	leader atomic.Bool
+}
+
+func NewScheduler(client *redisx.Client, opts SchedulerOptions) *Scheduler {
+	if opts.LockKey == "" {
+		opts.LockKey = DefaultSchedulerLock
+	}
+	if opts.LockTTL <= 0 {
+		opts.LockTTL = 30 * time.Second
+	}
+	if opts.Token == "" {
+		opts.Token = DefaultConsumer()
+	}
+	return &Scheduler{client: client, opts: opts}
+}
+
+// Run jalankan cron & pemilihan leader sampai ctx selesai
+func (s *Scheduler) Run(ctx context.Context) error {
+	schedulesMu.RLock()
+	entries := make([]*schedule, 0, len(schedules))
+	for _, sc := range schedules {
+		entries = append(entries, sc)
+	}
+	schedulesMu.RUnlock()
+	if len(entries) == 0 {
+		return nil
+	}
+
+	c := cron.New()
+	start := time.Now()
+	for _, sc := range entries {
+		sc := sc
+		ticks := &plannedTicks{schedule: sc.schedule, prev: start}
+		c.Schedule(sc.schedule, cron.FuncJob(func() { s.fire(ctx, sc, ticks.next(time.Now())) }))
+		logger.L().Infof("queue: schedule %s (%s) -> %s", sc.name, sc.spec, sc.job)
+	}
+	c.Start()
+
+	ticker := time.NewTicker(s.opts.LockTTL / 3)
+	defer ticker.Stop()
+	for {
+		s.elect(ctx)
+
+		select {
+		case <-ctx.Done():
+			<-c.Stop().Done()
+			if s.leader.Load() {
+				if err := s.client.ReleaseLock(context.WithoutCancel(ctx), s.opts.LockKey, s.opts.Token); err != nil {
+					logger.L().Warnf("queue: failed to release scheduler lock: %v", err)
+				}
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// elect ambil atau perpanjang lock leader
+func (s *Scheduler) elect(ctx context.Context) {
+	var (
+		ok  bool
+		err error
+	)
+	if s.leader.Load() {
+		ok, err = s.client.RefreshLock(ctx, s.opts.LockKey, s.opts.Token, s.opts.LockTTL)
+	} else {
+		ok, err = s.client.AcquireLock(ctx, s.opts.LockKey, s.opts.Token, s.opts.LockTTL)
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.L().Errorf("queue: scheduler election failed: %v", err)
+		}
+		ok = false
+	}
+
+	if was := s.leader.Swap(ok); was != ok {
+		if ok {
+			logger.L().Infof("queue: %s is scheduler leader", s.opts.Token)
+		} else {
+			logger.L().Warnf("queue: %s lost scheduler leadership", s.opts.Token)
+		}
+	}
+}
+
+// plannedTicks urutan tick terencana satu schedule
+type plannedTicks struct {
+	mu       sync.Mutex
+	schedule cron.Schedule
+	prev     time.Time
+}
+
+// next tick terencana yang sedang dijalankan pada now: schedule.Next(prev), atau tick
+// terakhir yang sudah lewat kalau ada tick yang terlewat (misal jam sistem melompat).
+// Tidak bergantung pada kapan goroutine cron benar-benar berjalan.
+func (p *plannedTicks) next(now time.Time) time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	tick := p.schedule.Next(p.prev)
+	for t := p.schedule.Next(tick); !t.After(now); t = p.schedule.Next(t) {
+		tick = t
+	}
+	p.prev = tick
+	return tick
+}
+
+// fire Enqueue job milik schedule untuk tick terencana. Key idempotency dari nama schedule
+// dan tick itu, jadi kalau sempat ada dua leader (lock habis saat Redis lambat) tick yang
+// sama tetap hanya menghasilkan satu job walaupun cron-nya berjalan di detik berbeda.
+func (s *Scheduler) fire(ctx context.Context, sc *schedule, tick time.Time) {
+	if !s.leader.Load() || ctx.Err() != nil {
+		return
+	}
+	tick = tick.UTC()
+	id, err := enqueue(ctx, s.client, sc.job, sc.payload, IdempotencyKey(sc.name+":"+tick.Format(time.RFC3339)))
+	if errors.Is(err, ErrDuplicate) {
+		logger.L().Infof("queue: schedule %s tick %s already enqueued as %s", sc.name, tick.Format(time.RFC3339), id)
+		return
+	}
+	if err != nil {
+		logger.L().Errorf("queue: schedule %s failed to enqueue %s: %v", sc.name, sc.job, err)
+		return
+	}
+	logger.L().Infof("⏰ Schedule %s enqueued %s (%s)", sc.name, id, sc.job)
+}
@@ -0,0 +1,125 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestPlannedTicks(t *testing.T) {
+	sched, err := cron.ParseStandard("*/5 * * * *")
+	if err != nil {
+		t.Fatalf("ParseStandard: %v", err)
+	}
+	start := time.Date(2025, 1, 1, 10, 1, 30, 0, time.UTC)
+	ticks := &plannedTicks{schedule: sched, prev: start}
+
+	// cron terlambat beberapa detik: tick tetap waktu terencana
+	if got := ticks.next(time.Date(2025, 1, 1, 10, 5, 2, 0, time.UTC)); !got.Equal(time.Date(2025, 1, 1, 10, 5, 0, 0, time.UTC)) {
+		t.Errorf("first tick = %s", got)
+	}
+	if got := ticks.next(time.Date(2025, 1, 1, 10, 10, 0, 0, time.UTC)); !got.Equal(time.Date(2025, 1, 1, 10, 10, 0, 0, time.UTC)) {
+		t.Errorf("second tick = %s", got)
+	}
+	// tick 10:15 dan 10:20 terlewat (jam sistem melompat), lanjut dari tick terakhir yang lewat
+	if got := ticks.next(time.Date(2025, 1, 1, 10, 22, 0, 0, time.UTC)); !got.Equal(time.Date(2025, 1, 1, 10, 20, 0, 0, time.UTC)) {
+		t.Errorf("tick after jump = %s", got)
+	}
+	if got := ticks.next(time.Date(2025, 1, 1, 10, 25, 0, 0, time.UTC)); !got.Equal(time.Date(2025, 1, 1, 10, 25, 0, 0, time.UTC)) {
+		t.Errorf("tick after jump = %s", got)
+	}
+}
+
+func TestFireEnqueuesOncePerTick(t *testing.T) {
+	const job = "test_scheduled"
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+
+	sc := &schedule{name: "test_schedule", spec: "@hourly", job: job, payload: testPayload{Value: "tick"}}
+	tick := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	follower := NewScheduler(client, SchedulerOptions{Token: "follower"})
+	follower.fire(ctx, sc, tick)
+	if n, _ := client.StreamLen(ctx, Stream(job)); n != 0 {
+		t.Fatalf("non-leader enqueued %d job(s)", n)
+	}
+
+	// dua leader sesaat (lock kedaluwarsa) menjalankan tick yang sama
+	a := NewScheduler(client, SchedulerOptions{Token: "a"})
+	b := NewScheduler(client, SchedulerOptions{Token: "b"})
+	a.leader.Store(true)
+	b.leader.Store(true)
+	a.fire(ctx, sc, tick)
+	b.fire(ctx, sc, tick.In(time.FixedZone("WIB", 7*3600)))
+	if n, _ := client.StreamLen(ctx, Stream(job)); n != 1 {
+		t.Fatalf("same tick enqueued %d jobs, want 1", n)
+	}
+
+	a.fire(ctx, sc, tick.Add(time.Hour))
+	if n, _ := client.StreamLen(ctx, Stream(job)); n != 2 {
+		t.Errorf("next tick: stream length = %d, want 2", n)
+	}
+}
+
+func TestElectSingleLeader(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+
+	a := NewScheduler(client, SchedulerOptions{Token: "a"})
+	b := NewScheduler(client, SchedulerOptions{Token: "b"})
+	a.elect(ctx)
+	b.elect(ctx)
+	if !a.leader.Load() || b.leader.Load() {
+		t.Fatalf("leaders a=%v b=%v, want only a", a.leader.Load(), b.leader.Load())
+	}
+
+	// leader lama melepas lock, instance lain mengambil alih
+	if err := client.ReleaseLock(ctx, a.opts.LockKey, "a"); err != nil {
+		t.Fatalf("ReleaseLock: %v", err)
+	}
+	b.elect(ctx)
+	a.elect(ctx)
+	if a.leader.Load() || !b.leader.Load() {
+		t.Errorf("leaders a=%v b=%v, want only b", a.leader.Load(), b.leader.Load())
+	}
+}
+
+func TestDelayedJobPromotedWhenDue(t *testing.T) {
+	const name = "test_delayed"
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+
+	runAt := time.Now().Add(time.Hour)
+	id, err := NewClient(client).Enqueue(ctx, name, testPayload{}, RunAt(runAt))
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if status := mustStatus(t, client, id); status.RunAt == nil || !status.RunAt.Equal(runAt.UTC()) {
+		t.Errorf("status run_at = %v, want %s", status.RunAt, runAt)
+	}
+	if n, _ := client.StreamLen(ctx, Stream(name)); n != 0 {
+		t.Fatalf("delayed job already in stream")
+	}
+
+	// belum jatuh tempo
+	if n, err := client.PromoteDue(ctx, DelayedKey(name), Stream(name), time.Now(), 10); err != nil || n != 0 {
+		t.Fatalf("PromoteDue before run_at = %d, %v", n, err)
+	}
+
+	n, err := client.PromoteDue(ctx, DelayedKey(name), Stream(name), runAt.Add(time.Second), 10)
+	if err != nil || n != 1 {
+		t.Fatalf("PromoteDue after run_at = %d, %v", n, err)
+	}
+	msgs, err := client.StreamRange(ctx, Stream(name), "-", "+", 10)
+	if err != nil || len(msgs) != 1 {
+		t.Fatalf("stream = %+v, %v", msgs, err)
+	}
+	if values := msgs[0].Payload.(map[string]any); values["id"] != id {
+		t.Errorf("promoted message = %+v, want job %s", values, id)
+	}
+	if n, _ := client.ZCard(ctx, DelayedKey(name)); n != 0 {
+		t.Errorf("delayed set size = %d, want 0", n)
+	}
+}
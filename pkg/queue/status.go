@@ -0,0 +1,161 @@
+package queue
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/events"
+	"template-golang/pkg/logger"
+	"template-golang/pkg/redisx"
+
+	"github.com/goccy/go-json"
+)
+
+// State status job
+type State string
+
+const (
+	StateQueued    State = "queued"
+	StateRunning   State = "running"
+	StateSucceeded State = "succeeded"
+	StateFailed    State = "failed"
+)
+
+const (
+	// StatusTTL lama status job disimpan setelah update terakhir
+	StatusTTL = 24 * time.Hour
+
+	statusPrefix = "queue:job:"
+)
+
+// Status status job yang disimpan di Redis hash "queue:job:<id>"
+type Status struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status State  `json:"status"`
+	// Owner user yang meng-Enqueue job (kalau ada)
+	Owner    string `json:"owner,omitempty"`
+	Attempts int64  `json:"attempts"`
+	// Progress 0-100
+	Progress   int             `json:"progress"`
+	Message    string          `json:"message,omitempty"`
+	Result     json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	Error      string          `json:"error,omitempty"`
+	EnqueuedAt *time.Time      `json:"enqueued_at,omitempty"`
+	RunAt      *time.Time      `json:"run_at,omitempty"`
+	StartedAt  *time.Time      `json:"started_at,omitempty"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+	UpdatedAt  *time.Time      `json:"updated_at,omitempty"`
+}
+
+// Done job sudah selesai (berhasil atau gagal permanen)
+func (s Status) Done() bool {
+	return s.Status == StateSucceeded || s.Status == StateFailed
+}
+
+func statusKey(id string) string {
+	return statusPrefix + id
+}
+
+// GetStatus status job id
+func (c *Client) GetStatus(ctx context.Context, id string) (*Status, error) {
+	return getStatus(ctx, c.redis, id)
+}
+
+func getStatus(ctx context.Context, c *redisx.Client, id string) (*Status, error) {
+	values, err := c.HGetAll(ctx, statusKey(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, apperror.NotFound("job not found")
+	}
+
+	at := func(key string) *time.Time {
+		t, err := time.Parse(time.RFC3339Nano, values[key])
+		if err != nil {
+			return nil
+		}
+		return &t
+	}
+	attempts, _ := strconv.ParseInt(values["attempts"], 10, 64)
+	progress, _ := strconv.Atoi(values["progress"])
+
+	s := &Status{
+		ID:         id,
+		Name:       values["name"],
+		Status:     State(values["status"]),
+		Owner:      values["owner"],
+		Attempts:   attempts,
+		Progress:   progress,
+		Message:    values["message"],
+		Error:      values["error"],
+		EnqueuedAt: at("enqueued_at"),
+		RunAt:      at("run_at"),
+		StartedAt:  at("started_at"),
+		FinishedAt: at("finished_at"),
+		UpdatedAt:  at("updated_at"),
+	}
+	if values["result"] != "" {
+		s.Result = json.RawMessage(values["result"])
+	}
+	return s, nil
+}
+
+// updateStatus tulis field status job lalu publish status lengkap sebagai event
+// events.TypeJobStatus ke pemilik job (atau admin kalau job tanpa pemilik).
+// Error hanya di-log, status tidak boleh menggagalkan job.
+func updateStatus(ctx context.Context, c *redisx.Client, id string, fields map[string]any, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = StatusTTL
+	}
+	fields["updated_at"] = now()
+	if err := c.HSet(ctx, statusKey(id), fields, ttl); err != nil {
+		logger.L().Warnf("queue: failed to update status of job %s: %v", id, err)
+		return
+	}
+
+	status, err := getStatus(ctx, c, id)
+	if err != nil {
+		logger.L().Warnf("queue: failed to read status of job %s: %v", id, err)
+		return
+	}
+	audience := events.ToRoles("admin", "superadmin")
+	if status.Owner != "" {
+		audience = events.ToUser(status.Owner)
+	}
+	if _, err := events.Publish(ctx, c, events.TypeJobStatus, status, audience); err != nil {
+		logger.L().Warnf("queue: failed to publish status of job %s: %v", id, err)
+	}
+}
+
+// Progress laporkan progress job (0-100) beserta pesan singkat
+func (j Job[T]) Progress(ctx context.Context, percent int, message string) {
+	if j.client == nil {
+		return
+	}
+	percent = min(max(percent, 0), 100)
+	updateStatus(ctx, j.client, j.ID, map[string]any{
+		"progress": percent,
+		"message":  message,
+	}, 0)
+}
+
+// SetResult simpan hasil job (JSON), dibaca client lewat status job
+func (j Job[T]) SetResult(ctx context.Context, result any) error {
+	if j.client == nil {
+		return nil
+	}
+	data, err := DefaultCodec.Marshal(result)
+	if err != nil {
+		return apperror.New("queue", "SetResult", 500, err, "failed to marshal job result")
+	}
+	updateStatus(ctx, j.client, j.ID, map[string]any{"result": string(data)}, 0)
+	return nil
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,389 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"template-golang/pkg/logger"
+	"template-golang/pkg/redisx"
+)
+
+// ErrShutdownTimeout job yang sedang berjalan belum selesai saat ShutdownTimeout habis
+var ErrShutdownTimeout = errors.New("queue: shutdown timeout, in-flight jobs cancelled")
+
+// WorkerOptions konfigurasi Worker
+type WorkerOptions struct {
+	Group string
+	// Consumer prefix nama consumer, default "<hostname>-<pid>".
+	// Setiap goroutine memakai "<Consumer>-<n>".
+	Consumer string
+	// Concurrency jumlah goroutine consumer per stream
+	Concurrency int
+	// ShutdownTimeout batas waktu menunggu job yang sedang berjalan setelah ctx Run selesai
+	ShutdownTimeout time.Duration
+	// Batch jumlah pesan per XREADGROUP
+	Batch int
+	// Block lama menunggu pesan baru
+	Block time.Duration
+	// ReclaimIdle pesan pending yang idle selama ini diambil alih & dicoba ulang
+	ReclaimIdle time.Duration
+	// ReclaimInterval seberapa sering pesan pending dicek
+	ReclaimInterval time.Duration
+	// PromoteInterval seberapa sering job tertunda (Delay / RunAt) yang jatuh tempo dipindah ke stream
+	PromoteInterval time.Duration
+	// LedgerTTL lama job yang sudah selesai dicatat di ledger (kecuali handler Idempotent)
+	LedgerTTL time.Duration
+}
+
+// Worker menjalankan semua handler yang sudah di-Register
+type Worker struct {
+	client *redisx.Client
+	opts   WorkerOptions
+}
+
+func NewWorker(client *redisx.Client, opts WorkerOptions) *Worker {
+	if opts.Group == "" {
+		opts.Group = DefaultGroup
+	}
+	if opts.Consumer == "" {
+		opts.Consumer = DefaultConsumer()
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.ShutdownTimeout <= 0 {
+		opts.ShutdownTimeout = 30 * time.Second
+	}
+	if opts.Batch <= 0 {
+		opts.Batch = 10
+	}
+	if opts.Block <= 0 {
+		opts.Block = 5 * time.Second
+	}
+	if opts.ReclaimIdle <= 0 {
+		opts.ReclaimIdle = time.Minute
+	}
+	if opts.ReclaimInterval <= 0 {
+		opts.ReclaimInterval = 30 * time.Second
+	}
+	if opts.PromoteInterval <= 0 {
+		opts.PromoteInterval = time.Second
+	}
+	if opts.LedgerTTL <= 0 {
+		opts.LedgerTTL = 24 * time.Hour
+	}
+	return &Worker{client: client, opts: opts}
+}
+
+// DefaultConsumer nama consumer unik per proses, "<hostname>-<pid>"
+func DefaultConsumer() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "worker"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// Run buat consumer group lalu konsumsi setiap stream dengan Concurrency goroutine.
+// Saat ctx selesai worker berhenti membaca pesan baru dan menunggu job yang sedang
+// berjalan maksimal ShutdownTimeout. Setelah itu ctx job dibatalkan dan
+// ErrShutdownTimeout dikembalikan, pesan yang belum di-ACK diambil alih worker lain.
+func (w *Worker) Run(ctx context.Context) error {
+	regs := registrations()
+	if len(regs) == 0 {
+		return errors.New("queue: no job handlers registered")
+	}
+
+	for _, reg := range regs {
+		if err := w.client.InitConsumerGroup(ctx, reg.stream, w.opts.Group); err != nil {
+			return err
+		}
+	}
+
+	// jobCtx tetap hidup setelah ctx selesai supaya job bisa diselesaikan
+	jobCtx, cancelJobs := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelJobs()
+
+	var wg sync.WaitGroup
+	for _, reg := range regs {
+		for i := 1; i <= w.opts.Concurrency; i++ {
+			consumer := fmt.Sprintf("%s-%d", w.opts.Consumer, i)
+			wg.Add(1)
+			go func(reg *registration, consumer string) {
+				defer wg.Done()
+				w.consume(ctx, jobCtx, reg, consumer)
+			}(reg, consumer)
+		}
+
+		// Pesan yang tidak di-ACK (job gagal / consumer crash) dicoba ulang setelah ReclaimIdle
+		consumer := w.opts.Consumer + "-reclaim"
+		wg.Add(1)
+		go func(reg *registration, consumer string) {
+			defer wg.Done()
+			w.client.ReclaimLoop(ctx, reg.stream, w.opts.Group, consumer, w.opts.ReclaimIdle, w.opts.ReclaimInterval, w.opts.Batch, func(msg redisx.Job) {
+				w.process(jobCtx, reg, consumer, msg)
+			})
+			w.removeConsumer(jobCtx, reg, consumer)
+		}(reg, consumer)
+		wg.Add(1)
+		go func(reg *registration) {
+			defer wg.Done()
+			w.promoteLoop(ctx, reg)
+		}(reg)
+		logger.L().Infof("queue: listening %s (%s) with %d consumer(s)", reg.name, reg.stream, w.opts.Concurrency)
+	}
+
+	// Job berulang (Schedule), hanya leader yang meng-Enqueue
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := NewScheduler(w.client, SchedulerOptions{Token: w.opts.Consumer}).Run(ctx); err != nil {
+			logger.L().Errorf("queue: scheduler stopped: %v", err)
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return ctx.Err()
+	case <-ctx.Done():
+	}
+
+	logger.L().Infof("queue: shutting down, waiting up to %s for in-flight jobs", w.opts.ShutdownTimeout)
+	timer := time.NewTimer(w.opts.ShutdownTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		logger.L().Info("queue: all in-flight jobs finished")
+		return nil
+	case <-timer.C:
+		cancelJobs()
+		return ErrShutdownTimeout
+	}
+}
+
+// consume baca pesan baru sampai ctx selesai, job dijalankan dengan jobCtx
+func (w *Worker) consume(ctx, jobCtx context.Context, reg *registration, consumer string) {
+	for ctx.Err() == nil {
+		msgs, err := w.client.ConsumeJob(ctx, reg.stream, w.opts.Group, consumer, w.opts.Batch, w.opts.Block)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			logger.L().Errorf("queue %s: failed to consume job: %v", reg.name, err)
+			sleep(ctx, time.Second)
+			continue
+		}
+
+		for _, msg := range msgs {
+			w.process(jobCtx, reg, consumer, msg)
+		}
+	}
+	w.removeConsumer(jobCtx, reg, consumer)
+}
+
+// promoteLoop pindahkan job tertunda yang jatuh tempo ke stream setiap PromoteInterval.
+// Pemindahan atomik (Lua), aman dijalankan di banyak instance.
+func (w *Worker) promoteLoop(ctx context.Context, reg *registration) {
+	ticker := time.NewTicker(w.opts.PromoteInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := w.client.PromoteDue(ctx, DelayedKey(reg.name), reg.stream, time.Now(), w.opts.Batch)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.L().Errorf("queue %s: failed to promote delayed jobs: %v", reg.name, err)
+				}
+				break
+			}
+			if n < int64(w.opts.Batch) {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// removeConsumer hapus consumer dari group saat berhenti supaya nama host-pid
+// lama tidak menumpuk, consumer yang masih punya pesan pending dibiarkan
+func (w *Worker) removeConsumer(ctx context.Context, reg *registration, consumer string) {
+	if ctx.Err() != nil {
+		return
+	}
+	if _, err := w.client.RemoveConsumer(ctx, reg.stream, w.opts.Group, consumer); err != nil {
+		logger.L().Warnf("queue %s: failed to remove consumer %s: %v", reg.name, consumer, err)
+	}
+}
+
+// process jalankan handler satu kali per delivery. Job yang gagal tidak di-ACK
+// sehingga diambil alih ReclaimLoop dan dicoba lagi sampai MaxAttempts,
+// setelah itu (atau saat error Permanent) dipindah ke dead-letter stream.
+// Job yang sudah tercatat di ledger langsung di-ACK tanpa menjalankan handler.
+// Selama handler berjalan pesan di-heartbeat supaya tidak diambil alih consumer lain.
+func (w *Worker) process(ctx context.Context, reg *registration, consumer string, msg redisx.Job) {
+	values, _ := msg.Payload.(map[string]any)
+	jobID, _ := values["id"].(string)
+	if jobID == "" {
+		jobID = msg.ID
+	}
+
+	// "data" dipakai pesan lama dari redisx.EnqueueJob
+	data, ok := values["payload"].(string)
+	if !ok {
+		data, ok = values["data"].(string)
+	}
+	if !ok {
+		w.deadLetter(ctx, reg, msg.ID, jobID, "", msg.Deliveries, errors.New("payload field missing"))
+		return
+	}
+
+	if !reg.opts.idempotent {
+		done, err := w.processed(ctx, reg, jobID)
+		if err != nil {
+			// ledger tidak terbaca, biarkan pending dan coba lagi lewat ReclaimLoop
+			logger.L().Errorf("job %s (%s): failed to read ledger: %v", jobID, reg.name, err)
+			return
+		}
+		if done {
+			w.ack(ctx, reg, msg.ID, jobID)
+			logger.L().Infof("job %s (%s): already processed, skipped", jobID, reg.name)
+			return
+		}
+	}
+
+	// lease dicek sebelum batas percobaan: job yang masih berjalan di consumer lain
+	// tidak boleh dipindah ke dead-letter stream
+	if reg.opts.dedupe {
+		release, ok := w.lease(ctx, reg, jobID)
+		if !ok {
+			return
+		}
+		defer release()
+	}
+
+	attempt := msg.Deliveries
+	if attempt < 1 {
+		attempt = 1
+	}
+	if attempt > reg.opts.maxAttempts {
+		// percobaan terakhir tidak selesai (consumer crash)
+		w.deadLetter(ctx, reg, msg.ID, jobID, data, attempt-1, fmt.Errorf("exceeded %d attempts", reg.opts.maxAttempts))
+		return
+	}
+
+	updateStatus(ctx, w.client, jobID, map[string]any{
+		"name":       reg.name,
+		"status":     string(StateRunning),
+		"attempts":   attempt,
+		"started_at": now(),
+	}, 0)
+
+	stop := w.heartbeat(ctx, reg, consumer, msg.ID, jobID)
+	err := reg.handle(ctx, w.client, jobID, msg.ID, attempt, []byte(data))
+	stop()
+	switch {
+	case err == nil:
+		if !reg.opts.idempotent {
+			w.markProcessed(ctx, reg, jobID, msg.ID)
+		}
+		w.ack(ctx, reg, msg.ID, jobID)
+		updateStatus(ctx, w.client, jobID, map[string]any{
+			"status":      string(StateSucceeded),
+			"progress":    100,
+			"message":     "",
+			"error":       "",
+			"finished_at": now(),
+		}, 0)
+		logger.L().Infof("✅ Job done: %s (%s)", jobID, reg.name)
+	case IsPermanent(err), attempt >= reg.opts.maxAttempts:
+		w.deadLetter(ctx, reg, msg.ID, jobID, data, attempt, err)
+	default:
+		updateStatus(ctx, w.client, jobID, map[string]any{
+			"status": string(StateQueued),
+			"error":  err.Error(),
+		}, 0)
+		logger.L().Warnf("job %s (%s): attempt %d/%d failed, retry in %s: %v", jobID, reg.name, attempt, reg.opts.maxAttempts, w.opts.ReclaimIdle, err)
+	}
+}
+
+// heartbeat reset idle time pesan (TouchJob) setiap ReclaimIdle/3 sampai fungsi yang
+// dikembalikan dipanggil, supaya job yang berjalan lebih lama dari ReclaimIdle tidak
+// diambil alih ReclaimLoop dan dijalankan dua kali
+func (w *Worker) heartbeat(ctx context.Context, reg *registration, consumer, messageID, jobID string) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(w.opts.ReclaimIdle / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := w.client.TouchJob(ctx, reg.stream, w.opts.Group, consumer, messageID); err != nil {
+					logger.L().Warnf("job %s (%s): failed to send heartbeat: %v", jobID, reg.name, err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+// deadLetter ACK job dan pindahkan ke dead-letter stream secara atomik. Kalau gagal,
+// pesan tetap pending dan dicoba dipindah lagi oleh ReclaimLoop.
+func (w *Worker) deadLetter(ctx context.Context, reg *registration, messageID, jobID, data string, attempts int64, cause error) {
+	moved, err := deadLetter(ctx, w.client, w.opts.Group, reg.name, jobID, messageID, data, attempts, cause)
+	if err != nil {
+		logger.L().Errorf("job %s (%s): failed to move to dead-letter stream: %v", jobID, reg.name, err)
+		return
+	}
+	if !moved {
+		logger.L().Warnf("job %s (%s): message %s no longer pending, not moved to dead-letter stream", jobID, reg.name, messageID)
+		return
+	}
+	updateStatus(ctx, w.client, jobID, map[string]any{
+		"status":      string(StateFailed),
+		"attempts":    attempts,
+		"error":       cause.Error(),
+		"finished_at": now(),
+	}, 0)
+	logger.L().Errorf("☠️ Job dead: %s (%s) after %d attempts: %v", jobID, reg.name, attempts, cause)
+}
+
+func (w *Worker) ack(ctx context.Context, reg *registration, messageID, jobID string) {
+	if err := w.client.AckJob(ctx, reg.stream, w.opts.Group, messageID); err != nil {
+		logger.L().Errorf("job %s (%s): ack error: %v", jobID, reg.name, err)
+	}
+}
+
+// sleep tunggu d atau sampai ctx selesai
+func sleep(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
@@ -0,0 +1,174 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"template-golang/pkg/redisx"
+)
+
+type testPayload struct {
+	Value string `json:"value"`
+}
+
+func TestProcessRetriesUntilMaxAttempts(t *testing.T) {
+	const name = "test_retry"
+	var calls atomic.Int64
+	reg := registerTest(t, name, func(ctx context.Context, job Job[testPayload]) error {
+		calls.Add(1)
+		if job.Payload.Value != "x" {
+			t.Errorf("payload = %+v", job.Payload)
+		}
+		return errors.New("temporary failure")
+	}, MaxAttempts(2))
+
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	w := newTestWorker(t, client, name)
+
+	id, err := NewClient(client).Enqueue(ctx, name, testPayload{Value: "x"})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	// percobaan pertama gagal: tetap pending, status kembali queued
+	first := consumeOne(t, w, name)
+	w.process(ctx, reg, "test-1", first)
+	if status := mustStatus(t, client, id); status.Status != StateQueued || status.Attempts != 1 || status.Error != "temporary failure" {
+		t.Fatalf("status after attempt 1 = %+v", status)
+	}
+	if n, _ := client.DeliveryCount(ctx, Stream(name), w.opts.Group, first.ID); n != 1 {
+		t.Fatalf("delivery count = %d, want 1 (still pending)", n)
+	}
+
+	// percobaan terakhir (diambil alih ReclaimLoop) gagal: pindah ke dead-letter stream
+	msg := reclaimOne(t, w, name)
+	if msg.Deliveries != 2 {
+		t.Fatalf("reclaimed deliveries = %d, want 2", msg.Deliveries)
+	}
+	w.process(ctx, reg, "test-reclaim", msg)
+
+	if calls.Load() != 2 {
+		t.Errorf("handler called %d times, want 2", calls.Load())
+	}
+	assertDead(t, client, name, id, 2, "temporary failure")
+}
+
+func TestProcessPermanentErrorSkipsRetry(t *testing.T) {
+	const name = "test_permanent"
+	reg := registerTest(t, name, func(ctx context.Context, job Job[testPayload]) error {
+		return Permanent(errors.New("broken payload"))
+	}, MaxAttempts(5))
+
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	w := newTestWorker(t, client, name)
+
+	id, err := NewClient(client).Enqueue(ctx, name, testPayload{})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	w.process(ctx, reg, "test-1", consumeOne(t, w, name))
+
+	assertDead(t, client, name, id, 1, "broken payload")
+}
+
+func TestProcessExceededAttemptsAfterCrash(t *testing.T) {
+	const name = "test_crash"
+	var calls atomic.Int64
+	reg := registerTest(t, name, func(ctx context.Context, job Job[testPayload]) error {
+		calls.Add(1)
+		return nil
+	}, MaxAttempts(1))
+
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	w := newTestWorker(t, client, name)
+
+	id, err := NewClient(client).Enqueue(ctx, name, testPayload{})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	// consumer crash sebelum ACK: pesan terkirim sekali tanpa diproses
+	consumeOne(t, w, name)
+
+	w.process(ctx, reg, "test-reclaim", reclaimOne(t, w, name))
+	if calls.Load() != 0 {
+		t.Errorf("handler called %d times, want 0", calls.Load())
+	}
+	assertDead(t, client, name, id, 1, "exceeded 1 attempts")
+}
+
+func TestProcessSucceeds(t *testing.T) {
+	const name = "test_success"
+	reg := registerTest(t, name, func(ctx context.Context, job Job[testPayload]) error {
+		return nil
+	})
+
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	w := newTestWorker(t, client, name)
+
+	id, err := NewClient(client).Enqueue(ctx, name, testPayload{})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	msg := consumeOne(t, w, name)
+	w.process(ctx, reg, "test-1", msg)
+
+	if status := mustStatus(t, client, id); status.Status != StateSucceeded || status.Progress != 100 {
+		t.Errorf("status = %+v", status)
+	}
+	if n, _ := client.DeliveryCount(ctx, Stream(name), w.opts.Group, msg.ID); n != 0 {
+		t.Errorf("message still pending (delivery count %d)", n)
+	}
+}
+
+func TestDeadLetterSkipsMessageNoLongerPending(t *testing.T) {
+	const name = "test_dead_acked"
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	w := newTestWorker(t, client, name)
+
+	if _, err := NewClient(client).Enqueue(ctx, name, testPayload{}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	msg := consumeOne(t, w, name)
+	if err := client.AckJob(ctx, Stream(name), w.opts.Group, msg.ID); err != nil {
+		t.Fatalf("AckJob: %v", err)
+	}
+
+	moved, err := deadLetter(ctx, client, w.opts.Group, name, "job", msg.ID, "{}", 1, errors.New("failed"))
+	if err != nil {
+		t.Fatalf("deadLetter: %v", err)
+	}
+	if moved {
+		t.Error("deadLetter moved a message that was already acked")
+	}
+	if n, _ := client.StreamLen(ctx, DeadLetterStream(name)); n != 0 {
+		t.Errorf("dead-letter stream length = %d, want 0", n)
+	}
+}
+
+// assertDead job id ada di dead-letter stream, sudah tidak ada di stream & pending, status failed
+func assertDead(t *testing.T, client *redisx.Client, name, id string, attempts int64, cause string) {
+	t.Helper()
+	ctx := context.Background()
+	q := NewClient(client)
+
+	dead, err := q.ListDead(ctx, name, 10)
+	if err != nil {
+		t.Fatalf("ListDead: %v", err)
+	}
+	if len(dead) != 1 || dead[0].JobID != id || dead[0].Attempts != attempts || dead[0].Error != cause {
+		t.Fatalf("dead jobs = %+v, want job %s after %d attempts (%s)", dead, id, attempts, cause)
+	}
+	if n, _ := client.StreamLen(ctx, Stream(name)); n != 0 {
+		t.Errorf("stream length = %d, want 0 (message moved)", n)
+	}
+	if status := mustStatus(t, client, id); status.Status != StateFailed || status.Attempts != attempts {
+		t.Errorf("status = %+v", status)
+	}
+}
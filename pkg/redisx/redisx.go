@@ -3,21 +3,17 @@ package redisx
 import (
 	"context"
 	"fmt"
-	"io"
 	"log"
-	"os"
-	"path/filepath"
 	"runtime/debug"
+	"sort"
 	"strings"
 	"time"
 
 	"template-golang/pkg/apperror"
 	"template-golang/pkg/config"
-	"template-golang/pkg/fileUploader"
 	"template-golang/pkg/logger"
 
 	"github.com/goccy/go-json"
-	"github.com/nrednav/cuid2"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -30,6 +26,8 @@ type Client struct {
 type Job struct {
 	ID      string      `json:"id"`
 	Payload interface{} `json:"payload"`
+	// Deliveries berapa kali pesan sudah dikirim ke consumer (1 = pertama kali)
+	Deliveries int64 `json:"deliveries"`
 }
 
 // New membuat koneksi ke Redis
@@ -78,6 +76,27 @@ func (c *Client) Get(ctx context.Context, key string) (string, error) {
 	return res, nil
 }
 
+// Lookup nilai key, ok false kalau key tidak ada
+func (c *Client) Lookup(ctx context.Context, key string) (string, bool, error) {
+	res, err := c.rdb.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, apperror.New("redisx", "Lookup", 500, err, "failed to get key")
+	}
+	return res, true, nil
+}
+
+// SetNX set key hanya kalau belum ada, false kalau key sudah ada
+func (c *Client) SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+	ok, err := c.rdb.SetNX(ctx, key, value, ttl).Result()
+	if err != nil {
+		return false, apperror.New("redisx", "SetNX", 500, err, "failed to set key")
+	}
+	return ok, nil
+}
+
 // Del key
 func (c *Client) Del(ctx context.Context, key string) error {
 	if err := c.rdb.Del(ctx, key).Err(); err != nil {
@@ -124,81 +143,173 @@ func (c *Client) EnqueueJob(ctx context.Context, stream string, payload Job) err
 	return nil
 }
 
-func QueuePDFUpload(ctx context.Context, client *Client, filePath string) error {
-	job := Job{
-		ID: cuid2.Generate(),
-		Payload: map[string]interface{}{
-			"file_path": filePath,
-			"folder":    "bukti_pendaftaran",
-			"name":      filepath.Base(filePath),
-		},
+// AddToStream tambahkan pesan ke Redis Stream, mengembalikan id pesan
+func (c *Client) AddToStream(ctx context.Context, stream string, values map[string]any) (string, error) {
+	id, err := c.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		ID:     "*", // biarkan Redis generate ID
+		Values: values,
+	}).Result()
+	if err != nil {
+		return "", apperror.New("redisx", "AddToStream", 500, err, "failed to add message to stream")
 	}
-
-	return client.EnqueueJob(ctx, "pdf_upload_jobs", job)
+	return id, nil
 }
 
-// EnqueueJobFile menambahkan job ke Redis Stream dengan menyimpan payload ke file
-func (c *Client) EnqueueJobFile(ctx context.Context, payload Job) error {
-	// Pastikan folder tmp ada
-	if err := os.MkdirAll("tmp", 0755); err != nil {
-		return apperror.New("redisx", "EnqueueJobFile", 500, err, "failed to create tmp dir")
+// AddToStreamCapped seperti AddToStream tapi stream dipangkas ke sekitar maxLen pesan terakhir
+func (c *Client) AddToStreamCapped(ctx context.Context, stream string, maxLen int64, values map[string]any) (string, error) {
+	id, err := c.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLen,
+		Approx: true,
+		ID:     "*",
+		Values: values,
+	}).Result()
+	if err != nil {
+		return "", apperror.New("redisx", "AddToStreamCapped", 500, err, "failed to add message to stream")
 	}
+	return id, nil
+}
 
-	// Cast to the concrete type so we can access the file data
-	qf, ok := payload.Payload.(fileUploader.QueueUploadFile)
-	if !ok {
-		return apperror.New("redisx", "EnqueueJobFile", 500, nil, "payload is not of type QueueUploadFile")
+// StreamRange baca pesan stream dari start sampai end (XRANGE), "-" / "+" untuk awal / akhir
+func (c *Client) StreamRange(ctx context.Context, stream, start, end string, count int64) ([]Job, error) {
+	res, err := c.rdb.XRangeN(ctx, stream, start, end, count).Result()
+	if err != nil && err != redis.Nil {
+		return nil, apperror.New("redisx", "StreamRange", 500, err, "failed to read stream")
 	}
+	return toJobs(res), nil
+}
 
-	// Buat nama file unik di tmp dengan ekstensi asli
-	if qf.File == nil {
-		return apperror.New("redisx", "EnqueueJobFile", 400, nil, "QueueUploadFile.File cannot be nil")
+// StreamRevRange baca pesan stream terbaru dulu (XREVRANGE)
+func (c *Client) StreamRevRange(ctx context.Context, stream, end, start string, count int64) ([]Job, error) {
+	res, err := c.rdb.XRevRangeN(ctx, stream, end, start, count).Result()
+	if err != nil && err != redis.Nil {
+		return nil, apperror.New("redisx", "StreamRevRange", 500, err, "failed to read stream")
 	}
+	return toJobs(res), nil
+}
 
-	// Dapatkan ekstensi dari nama file asli
-	ext := filepath.Ext(qf.File.Filename)
-
-	// Baca konten file dari *multipart.FileHeader
-	src, err := qf.File.Open()
+// StreamDelete hapus pesan dari stream (XDEL)
+func (c *Client) StreamDelete(ctx context.Context, stream string, ids ...string) (int64, error) {
+	n, err := c.rdb.XDel(ctx, stream, ids...).Result()
 	if err != nil {
-		return apperror.New("redisx", "EnqueueJobFile", 500, err, "failed to open multipart file")
+		return 0, apperror.New("redisx", "StreamDelete", 500, err, "failed to delete stream messages")
 	}
-	defer src.Close()
+	return n, nil
+}
 
-	data, err := io.ReadAll(src)
+// moveStreamMessageScript hapus pesan ARGV[1] dari stream KEYS[1] lalu tambahkan field
+// ARGV[2..] sebagai pesan baru di KEYS[2], atomik. nil kalau pesan sudah tidak ada.
+var moveStreamMessageScript = redis.NewScript(`
+if redis.call('XDEL', KEYS[1], ARGV[1]) == 0 then
+	return false
+end
+return redis.call('XADD', KEYS[2], '*', unpack(ARGV, 2))
+`)
+
+// MoveStreamMessage hapus pesan id dari stream from dan tambahkan values ke stream to dalam
+// satu operasi atomik. Mengembalikan id pesan baru, false kalau pesan id sudah tidak ada
+// (misal sudah dipindah pemanggil lain).
+func (c *Client) MoveStreamMessage(ctx context.Context, from, id, to string, values map[string]any) (string, bool, error) {
+	newID, err := moveStreamMessageScript.Run(ctx, c.rdb, []string{from, to}, streamArgs(values, id)...).Text()
+	if err == redis.Nil {
+		return "", false, nil
+	}
 	if err != nil {
-		return apperror.New("redisx", "EnqueueJobFile", 500, err, "failed to read multipart file")
+		return "", false, apperror.New("redisx", "MoveStreamMessage", 500, err, "failed to move stream message")
 	}
+	return newID, true, nil
+}
 
-	// Buat nama file unik di tmp
-	fileName := fmt.Sprintf("tmp/%s_%d%s", payload.ID, time.Now().UnixNano(), ext)
-	qf.FilePathTmp = &fileName
-	payload.Payload = qf
+// ackMoveStreamMessageScript ACK pesan ARGV[2] milik group ARGV[1] di stream KEYS[1], hapus
+// pesannya, lalu tambahkan field ARGV[3..] sebagai pesan baru di KEYS[2], atomik.
+// nil kalau pesan sudah tidak pending (sudah di-ACK consumer lain).
+var ackMoveStreamMessageScript = redis.NewScript(`
+if redis.call('XACK', KEYS[1], ARGV[1], ARGV[2]) == 0 then
+	return false
+end
+redis.call('XDEL', KEYS[1], ARGV[2])
+return redis.call('XADD', KEYS[2], '*', unpack(ARGV, 3))
+`)
+
+// AckMoveStreamMessage ACK & hapus pesan pending id dari stream from lalu tambahkan values ke
+// stream to dalam satu operasi atomik, misal pindah ke dead-letter stream. Mengembalikan id
+// pesan baru, false kalau pesan sudah tidak pending (sudah di-ACK / dipindah consumer lain).
+func (c *Client) AckMoveStreamMessage(ctx context.Context, from, group, id, to string, values map[string]any) (string, bool, error) {
+	newID, err := ackMoveStreamMessageScript.Run(ctx, c.rdb, []string{from, to}, streamArgs(values, group, id)...).Text()
+	if err == redis.Nil {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, apperror.New("redisx", "AckMoveStreamMessage", 500, err, "failed to move stream message")
+	}
+	return newID, true, nil
+}
 
-	// Tulis file gambarnya ke tmp
-	if err := os.WriteFile(fileName, data, 0644); err != nil {
-		return apperror.New("redisx", "EnqueueJobFile", 500, err, "failed to write image file to tmp")
+// streamArgs argumen script: prefix lalu pasangan field / value (terurut) untuk XADD
+func streamArgs(values map[string]any, prefix ...any) []any {
+	fields := make([]string, 0, len(values))
+	for k := range values {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+	args := make([]any, 0, len(prefix)+2*len(values))
+	args = append(args, prefix...)
+	for _, k := range fields {
+		args = append(args, k, values[k])
 	}
+	return args
+}
 
-	// Marshal payload tanpa FileData (sudah tersimpan di tmp)
-	jsonPayload, err := json.Marshal(payload.Payload)
+// deleteStreamScript hapus stream KEYS[1], mengembalikan jumlah pesan yang terhapus
+var deleteStreamScript = redis.NewScript(`
+local n = redis.call('XLEN', KEYS[1])
+redis.call('DEL', KEYS[1])
+return n
+`)
+
+// DeleteStream hapus stream beserta semua pesannya secara atomik,
+// mengembalikan jumlah pesan yang terhapus
+func (c *Client) DeleteStream(ctx context.Context, stream string) (int64, error) {
+	n, err := deleteStreamScript.Run(ctx, c.rdb, []string{stream}).Int64()
 	if err != nil {
-		return apperror.New("redisx", "EnqueueJobFile", 500, err, "failed to marshal job payload")
+		return 0, apperror.New("redisx", "DeleteStream", 500, err, "failed to delete stream")
 	}
+	return n, nil
+}
 
-	// Enqueue job dengan path file
-	_, err = c.rdb.XAdd(ctx, &redis.XAddArgs{
-		Stream: "upload_jobs",
-		ID:     "*", // biarkan Redis generate ID
-		Values: map[string]interface{}{
-			"id":      payload.ID,
-			"payload": string(jsonPayload),
-		},
-	}).Result()
-	if err != nil {
-		return apperror.New("redisx", "EnqueueJobFile", 500, err, "failed to enqueue job with file")
+// StreamLen jumlah pesan di stream
+func (c *Client) StreamLen(ctx context.Context, stream string) (int64, error) {
+	n, err := c.rdb.XLen(ctx, stream).Result()
+	if err != nil && err != redis.Nil {
+		return 0, apperror.New("redisx", "StreamLen", 500, err, "failed to get stream length")
 	}
-	return nil
+	return n, nil
+}
+
+// ScanKeys cari key by pattern dengan SCAN (tidak memblok Redis seperti KEYS)
+func (c *Client) ScanKeys(ctx context.Context, pattern string) ([]string, error) {
+	var keys []string
+	iter := c.rdb.Scan(ctx, 0, pattern, 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, apperror.New("redisx", "ScanKeys", 500, err, "failed to scan keys with pattern")
+	}
+	return keys, nil
+}
+
+func toJobs(msgs []redis.XMessage) []Job {
+	jobs := make([]Job, 0, len(msgs))
+	for _, msg := range msgs {
+		payload := make(map[string]interface{})
+		for k, v := range msg.Values {
+			payload[k] = v
+		}
+		jobs = append(jobs, Job{ID: msg.ID, Payload: payload})
+	}
+	return jobs
 }
 
 // InitConsumerGroup buat consumer group
@@ -232,11 +343,139 @@ func (c *Client) ConsumeJob(ctx context.Context, stream, group, consumer string,
 		for k, v := range msg.Values {
 			payload[k] = v
 		}
-		jobs = append(jobs, Job{ID: msg.ID, Payload: payload})
+		jobs = append(jobs, Job{ID: msg.ID, Payload: payload, Deliveries: 1})
 	}
 	return jobs, nil
 }
 
+// ReclaimJobs ambil alih (XAUTOCLAIM) pesan pending yang idle >= minIdle,
+// misal karena consumer crash atau job gagal dan tidak di-ACK
+func (c *Client) ReclaimJobs(ctx context.Context, stream, group, consumer string, minIdle time.Duration, count int) ([]Job, error) {
+	var jobs []Job
+	start := "0-0"
+	for len(jobs) < count {
+		msgs, next, err := c.rdb.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   stream,
+			Group:    group,
+			Consumer: consumer,
+			MinIdle:  minIdle,
+			Start:    start,
+			Count:    int64(count - len(jobs)),
+		}).Result()
+		if err == redis.Nil {
+			break
+		}
+		if err != nil {
+			return nil, apperror.New("redisx", "ReclaimJobs", 500, err, "failed to autoclaim jobs")
+		}
+
+		for _, msg := range msgs {
+			payload := make(map[string]interface{})
+			for k, v := range msg.Values {
+				payload[k] = v
+			}
+			jobs = append(jobs, Job{ID: msg.ID, Payload: payload})
+		}
+
+		if next == "0-0" || next == "" {
+			break
+		}
+		start = next
+	}
+
+	// XAUTOCLAIM tidak mengembalikan delivery count, ambil dari XPENDING
+	for i := range jobs {
+		deliveries, err := c.DeliveryCount(ctx, stream, group, jobs[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		jobs[i].Deliveries = deliveries
+	}
+	return jobs, nil
+}
+
+// DeliveryCount jumlah pengiriman pesan pending (0 kalau sudah di-ACK)
+func (c *Client) DeliveryCount(ctx context.Context, stream, group, msgID string) (int64, error) {
+	res, err := c.rdb.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: stream,
+		Group:  group,
+		Start:  msgID,
+		End:    msgID,
+		Count:  1,
+	}).Result()
+	if err != nil && err != redis.Nil {
+		return 0, apperror.New("redisx", "DeliveryCount", 500, err, "failed to read pending entry")
+	}
+	if len(res) == 0 {
+		return 0, nil
+	}
+	return res[0].RetryCount, nil
+}
+
+// TouchJob reset idle time pesan pending milik consumer (XCLAIM JUSTID, delivery count
+// tidak bertambah) supaya pesan yang masih diproses tidak diambil alih ReclaimJobs
+func (c *Client) TouchJob(ctx context.Context, stream, group, consumer, msgID string) error {
+	err := c.rdb.XClaimJustID(ctx, &redis.XClaimArgs{
+		Stream:   stream,
+		Group:    group,
+		Consumer: consumer,
+		Messages: []string{msgID},
+	}).Err()
+	if err != nil && err != redis.Nil {
+		return apperror.New("redisx", "TouchJob", 500, err, "failed to claim job")
+	}
+	return nil
+}
+
+// ReclaimLoop setiap interval ambil alih pesan pending yang idle >= minIdle
+// lalu serahkan ke fn, berhenti saat ctx selesai
+func (c *Client) ReclaimLoop(ctx context.Context, stream, group, consumer string, minIdle, interval time.Duration, count int, fn func(Job)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		jobs, err := c.ReclaimJobs(ctx, stream, group, consumer, minIdle, count)
+		if err != nil {
+			logger.L().Errorf("redisx: failed to reclaim jobs from %s: %v", stream, err)
+		}
+		for _, job := range jobs {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.L().Infof("redisx: reclaimed job %s from %s (delivery %d)", job.ID, stream, job.Deliveries)
+			fn(job)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RemoveConsumer hapus consumer dari group kalau sudah tidak punya pesan pending,
+// mengembalikan true kalau consumer dihapus
+func (c *Client) RemoveConsumer(ctx context.Context, stream, group, consumer string) (bool, error) {
+	consumers, err := c.rdb.XInfoConsumers(ctx, stream, group).Result()
+	if err != nil && err != redis.Nil {
+		return false, apperror.New("redisx", "RemoveConsumer", 500, err, "failed to read consumers")
+	}
+	for _, info := range consumers {
+		if info.Name != consumer {
+			continue
+		}
+		if info.Pending > 0 {
+			return false, nil
+		}
+		if err := c.rdb.XGroupDelConsumer(ctx, stream, group, consumer).Err(); err != nil {
+			return false, apperror.New("redisx", "RemoveConsumer", 500, err, "failed to delete consumer")
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
 // AckJob tandai job selesai
 func (c *Client) AckJob(ctx context.Context, stream, group string, msgID string) error {
 	if err := c.rdb.XAck(ctx, stream, group, msgID).Err(); err != nil {
@@ -245,6 +484,161 @@ func (c *Client) AckJob(ctx context.Context, stream, group string, msgID string)
 	return nil
 }
 
+// ================== HASH ==================
+
+// HSet set field hash key lalu perpanjang TTL (ttl 0 = tanpa expire)
+func (c *Client) HSet(ctx context.Context, key string, values map[string]any, ttl time.Duration) error {
+	pipe := c.rdb.TxPipeline()
+	pipe.HSet(ctx, key, values)
+	if ttl > 0 {
+		pipe.Expire(ctx, key, ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return apperror.New("redisx", "HSet", 500, err, "failed to set hash")
+	}
+	return nil
+}
+
+// HGetAll semua field hash key, map kosong kalau key tidak ada
+func (c *Client) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	res, err := c.rdb.HGetAll(ctx, key).Result()
+	if err != nil && err != redis.Nil {
+		return nil, apperror.New("redisx", "HGetAll", 500, err, "failed to get hash")
+	}
+	return res, nil
+}
+
+// ================== DELAYED JOB ==================
+
+// promoteDueScript pindahkan member zset yang score <= now ke stream secara atomik,
+// member berisi objek JSON field pesan stream
+var promoteDueScript = redis.NewScript(`
+local items = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, tonumber(ARGV[2]))
+for _, item in ipairs(items) do
+	local fields = cjson.decode(item)
+	local args = {}
+	for k, v in pairs(fields) do
+		table.insert(args, k)
+		table.insert(args, tostring(v))
+	end
+	redis.call('XADD', KEYS[2], '*', unpack(args))
+	redis.call('ZREM', KEYS[1], item)
+end
+return #items
+`)
+
+// ScheduleToStream simpan pesan di sorted set key dengan score runAt,
+// dipindah ke stream oleh PromoteDue saat sudah waktunya
+func (c *Client) ScheduleToStream(ctx context.Context, key string, runAt time.Time, values map[string]any) error {
+	member, err := json.Marshal(values)
+	if err != nil {
+		return apperror.New("redisx", "ScheduleToStream", 500, err, "failed to marshal delayed message")
+	}
+	if err := c.rdb.ZAdd(ctx, key, redis.Z{Score: float64(runAt.UnixMilli()), Member: string(member)}).Err(); err != nil {
+		return apperror.New("redisx", "ScheduleToStream", 500, err, "failed to schedule message")
+	}
+	return nil
+}
+
+// PromoteDue pindahkan maksimal limit pesan dari sorted set key yang sudah jatuh tempo ke stream,
+// mengembalikan jumlah pesan yang dipindah
+func (c *Client) PromoteDue(ctx context.Context, key, stream string, now time.Time, limit int) (int64, error) {
+	n, err := promoteDueScript.Run(ctx, c.rdb, []string{key, stream}, now.UnixMilli(), limit).Int64()
+	if err != nil && err != redis.Nil {
+		return 0, apperror.New("redisx", "PromoteDue", 500, err, "failed to promote delayed messages")
+	}
+	return n, nil
+}
+
+// ZAdd tambah / update score member sorted set
+func (c *Client) ZAdd(ctx context.Context, key string, score float64, member string) error {
+	if err := c.rdb.ZAdd(ctx, key, redis.Z{Score: score, Member: member}).Err(); err != nil {
+		return apperror.New("redisx", "ZAdd", 500, err, "failed to add sorted set member")
+	}
+	return nil
+}
+
+// ZRem hapus member sorted set
+func (c *Client) ZRem(ctx context.Context, key string, members ...string) error {
+	args := make([]any, len(members))
+	for i, m := range members {
+		args[i] = m
+	}
+	if err := c.rdb.ZRem(ctx, key, args...).Err(); err != nil {
+		return apperror.New("redisx", "ZRem", 500, err, "failed to remove sorted set member")
+	}
+	return nil
+}
+
+// ZRangeByScore member dengan score di antara min dan max ("-inf" / "+inf" boleh)
+func (c *Client) ZRangeByScore(ctx context.Context, key, min, max string) ([]string, error) {
+	res, err := c.rdb.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: min, Max: max}).Result()
+	if err != nil && err != redis.Nil {
+		return nil, apperror.New("redisx", "ZRangeByScore", 500, err, "failed to read sorted set")
+	}
+	return res, nil
+}
+
+// ZRemRangeByScore hapus member dengan score di antara min dan max
+func (c *Client) ZRemRangeByScore(ctx context.Context, key, min, max string) (int64, error) {
+	n, err := c.rdb.ZRemRangeByScore(ctx, key, min, max).Result()
+	if err != nil && err != redis.Nil {
+		return 0, apperror.New("redisx", "ZRemRangeByScore", 500, err, "failed to trim sorted set")
+	}
+	return n, nil
+}
+
+// ZCard jumlah member sorted set
+func (c *Client) ZCard(ctx context.Context, key string) (int64, error) {
+	n, err := c.rdb.ZCard(ctx, key).Result()
+	if err != nil && err != redis.Nil {
+		return 0, apperror.New("redisx", "ZCard", 500, err, "failed to count sorted set")
+	}
+	return n, nil
+}
+
+// ================== LOCK ==================
+
+var refreshLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+var releaseLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// AcquireLock ambil lock key milik token selama ttl (SET NX), false kalau sudah dipegang pihak lain
+func (c *Client) AcquireLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	ok, err := c.rdb.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return false, apperror.New("redisx", "AcquireLock", 500, err, "failed to acquire lock")
+	}
+	return ok, nil
+}
+
+// RefreshLock perpanjang ttl lock kalau masih dipegang token
+func (c *Client) RefreshLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	n, err := refreshLockScript.Run(ctx, c.rdb, []string{key}, token, ttl.Milliseconds()).Int64()
+	if err != nil && err != redis.Nil {
+		return false, apperror.New("redisx", "RefreshLock", 500, err, "failed to refresh lock")
+	}
+	return n == 1, nil
+}
+
+// ReleaseLock lepas lock kalau masih dipegang token
+func (c *Client) ReleaseLock(ctx context.Context, key, token string) error {
+	if err := releaseLockScript.Run(ctx, c.rdb, []string{key}, token).Err(); err != nil && err != redis.Nil {
+		return apperror.New("redisx", "ReleaseLock", 500, err, "failed to release lock")
+	}
+	return nil
+}
+
 // ================== PUB / SUB ==================
 
 // Publish kirim pesan ke channel
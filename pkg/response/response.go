@@ -1,6 +1,10 @@
 package response
 
 import (
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/i18n"
+	"template-golang/pkg/locale"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -11,12 +15,11 @@ type BaseResponse struct {
 }
 
 type Response[T any] struct {
-	Status  bool        `json:"status"`
-	Message string      `json:"message"`
-	Data    T           `json:"data"`
+	Status  bool   `json:"status"`
+	Message string `json:"message"`
+	Data    T      `json:"data"`
 }
 
-
 type ErrorResponse struct {
 	Status  bool        `json:"status"`
 	Message string      `json:"message"`
@@ -38,11 +41,19 @@ func Success(c *fiber.Ctx, data interface{}) error {
 	})
 }
 
-// Error returns an error response with message
+// Error returns an error response with message. Seperti ErrorHandler, message (kode pesan,
+// katalog i18n "message.<kode>") dan AppError di err diterjemahkan ke locale request.
 func Error(c *fiber.Ctx, message string, err interface{}) error {
+	lang, _ := c.Locals(locale.ContextKey).(string)
+	if lang == "" {
+		lang = locale.Default
+	}
+	if appErr, ok := err.(*apperror.AppError); ok {
+		err = appErr.Localize(lang)
+	}
 	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 		"status":  false,
-		"message": message,
+		"message": i18n.Message(lang, message),
 		"data":    err,
 	})
 }
@@ -0,0 +1,115 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"template-golang/pkg/apperror"
+)
+
+// clamdChunkSize ukuran chunk INSTREAM, harus di bawah StreamMaxLength clamd
+const clamdChunkSize = 64 * 1024
+
+// Clamd scanner lewat daemon ClamAV (clamd) dengan perintah INSTREAM,
+// file dikirim per chunk sehingga clamd tidak perlu akses ke filesystem worker
+type Clamd struct {
+	network string
+	addr    string
+	timeout time.Duration
+}
+
+// NewClamd addr "tcp://host:3310", "unix:///run/clamav/clamd.sock" atau "host:port"
+func NewClamd(addr string, timeout time.Duration) (*Clamd, error) {
+	network, address := "tcp", addr
+	switch {
+	case strings.HasPrefix(addr, "unix://"):
+		network, address = "unix", strings.TrimPrefix(addr, "unix://")
+	case strings.HasPrefix(addr, "tcp://"):
+		address = strings.TrimPrefix(addr, "tcp://")
+	}
+	if address == "" {
+		return nil, apperror.New("scanner", "NewClamd", 500, nil, "clamd address cannot be empty")
+	}
+	if timeout <= 0 {
+		timeout = time.Minute
+	}
+	return &Clamd{network: network, addr: address, timeout: timeout}, nil
+}
+
+// Scan kirim r ke clamd: "zINSTREAM\0", chunk <panjang uint32 big endian><data>,
+// diakhiri chunk panjang 0. Balasan "stream: OK" atau "stream: <signature> FOUND".
+func (c *Clamd) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, c.network, c.addr)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+	// ctx dibatalkan di tengah scan: putus koneksi supaya Read / Write berhenti
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return Result{}, c.error(ctx, err)
+	}
+
+	buf := make([]byte, 4+clamdChunkSize)
+	for {
+		n, rerr := io.ReadFull(r, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, err := conn.Write(buf[:4+n]); err != nil {
+				// clamd menutup koneksi saat StreamMaxLength terlampaui, balasannya tetap dibaca
+				break
+			}
+		}
+		if errors.Is(rerr, io.EOF) || errors.Is(rerr, io.ErrUnexpectedEOF) {
+			break
+		}
+		if rerr != nil {
+			return Result{}, fmt.Errorf("failed to read file: %w", rerr)
+		}
+	}
+	conn.Write([]byte{0, 0, 0, 0})
+
+	reply, err := io.ReadAll(conn)
+	if err != nil && len(reply) == 0 {
+		return Result{}, c.error(ctx, err)
+	}
+	return parseClamdReply(reply)
+}
+
+func (c *Clamd) error(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return fmt.Errorf("clamd scan failed: %w", err)
+}
+
+// parseClamdReply "stream: OK", "stream: Eicar-Signature FOUND" atau "<pesan> ERROR"
+func parseClamdReply(reply []byte) (Result, error) {
+	line := strings.TrimSpace(string(bytes.TrimRight(reply, "\x00\n")))
+	line = strings.TrimPrefix(line, "stream: ")
+	switch {
+	case line == "OK":
+		return Result{Clean: true}, nil
+	case strings.HasSuffix(line, " FOUND"):
+		return Result{Signature: strings.TrimSuffix(line, " FOUND")}, nil
+	case line == "":
+		return Result{}, errors.New("clamd scan failed: empty reply")
+	}
+	return Result{}, fmt.Errorf("clamd scan failed: %s", line)
+}
@@ -0,0 +1,211 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// eicar string uji standar antivirus, dideteksi fakeClamd seperti clamd asli
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// fakeClamd server clamd palsu yang bicara protokol INSTREAM. Data yang diterima
+// dikirim ke received, balasan ditentukan reply dari isi stream.
+func fakeClamd(t *testing.T, reply func(data []byte) string) (addr string, received <-chan []byte) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	ch := make(chan []byte, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		cmd := make([]byte, len("zINSTREAM\x00"))
+		if _, err := io.ReadFull(conn, cmd); err != nil || string(cmd) != "zINSTREAM\x00" {
+			conn.Write([]byte("UNKNOWN COMMAND\x00"))
+			return
+		}
+
+		var data bytes.Buffer
+		for {
+			var size uint32
+			if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+				return
+			}
+			if size == 0 {
+				break
+			}
+			if _, err := io.CopyN(&data, conn, int64(size)); err != nil {
+				return
+			}
+		}
+		ch <- data.Bytes()
+		conn.Write([]byte(reply(data.Bytes()) + "\x00"))
+	}()
+	return ln.Addr().String(), ch
+}
+
+func eicarReply(data []byte) string {
+	if bytes.Contains(data, []byte(eicar)) {
+		return "stream: Eicar-Test-Signature FOUND"
+	}
+	return "stream: OK"
+}
+
+func TestClamdScan(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		want     Result
+		infected bool
+	}{
+		{name: "clean", data: []byte("hello world"), want: Result{Clean: true}},
+		{name: "infected", data: []byte(eicar), want: Result{Signature: "Eicar-Test-Signature"}, infected: true},
+		// lebih dari satu chunk, signature terpotong di batas chunk
+		{
+			name:     "infected across chunks",
+			data:     append(bytes.Repeat([]byte("a"), clamdChunkSize-10), eicar...),
+			want:     Result{Signature: "Eicar-Test-Signature"},
+			infected: true,
+		},
+		{name: "empty", data: nil, want: Result{Clean: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, received := fakeClamd(t, eicarReply)
+			c, err := NewClamd("tcp://"+addr, 5*time.Second)
+			if err != nil {
+				t.Fatalf("NewClamd: %v", err)
+			}
+
+			got, err := c.Scan(context.Background(), bytes.NewReader(tt.data))
+			if err != nil {
+				t.Fatalf("Scan: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Scan = %+v, want %+v", got, tt.want)
+			}
+			if errors.Is(got.Err(), ErrInfected) != tt.infected {
+				t.Errorf("Err() = %v, infected want %v", got.Err(), tt.infected)
+			}
+			if data := <-received; !bytes.Equal(data, tt.data) {
+				t.Errorf("clamd received %d bytes, want %d", len(data), len(tt.data))
+			}
+		})
+	}
+}
+
+func TestClamdScanError(t *testing.T) {
+	addr, _ := fakeClamd(t, func([]byte) string { return "INSTREAM size limit exceeded. ERROR" })
+	c, err := NewClamd(addr, 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewClamd: %v", err)
+	}
+
+	_, err = c.Scan(context.Background(), strings.NewReader("data"))
+	if err == nil || !strings.Contains(err.Error(), "size limit exceeded") {
+		t.Fatalf("Scan error = %v, want size limit error", err)
+	}
+}
+
+func TestClamdScanUnreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	c, err := NewClamd(addr, time.Second)
+	if err != nil {
+		t.Fatalf("NewClamd: %v", err)
+	}
+	if _, err := c.Scan(context.Background(), strings.NewReader("data")); err == nil {
+		t.Fatal("Scan succeeded without clamd")
+	}
+}
+
+func TestNewClamd(t *testing.T) {
+	tests := []struct {
+		addr    string
+		network string
+		address string
+		wantErr bool
+	}{
+		{addr: "tcp://clamav:3310", network: "tcp", address: "clamav:3310"},
+		{addr: "clamav:3310", network: "tcp", address: "clamav:3310"},
+		{addr: "unix:///run/clamav/clamd.sock", network: "unix", address: "/run/clamav/clamd.sock"},
+		{addr: "tcp://", wantErr: true},
+		{addr: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			c, err := NewClamd(tt.addr, 0)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NewClamd(%q) succeeded, want error", tt.addr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewClamd(%q): %v", tt.addr, err)
+			}
+			if c.network != tt.network || c.addr != tt.address {
+				t.Errorf("NewClamd(%q) = %s %s, want %s %s", tt.addr, c.network, c.addr, tt.network, tt.address)
+			}
+			if c.timeout != time.Minute {
+				t.Errorf("default timeout = %s, want 1m", c.timeout)
+			}
+		})
+	}
+}
+
+func TestParseClamdReply(t *testing.T) {
+	tests := []struct {
+		reply   string
+		want    Result
+		wantErr string
+	}{
+		{reply: "stream: OK\x00", want: Result{Clean: true}},
+		{reply: "stream: OK\n", want: Result{Clean: true}},
+		{reply: "OK", want: Result{Clean: true}},
+		{reply: "stream: Eicar-Test-Signature FOUND\x00", want: Result{Signature: "Eicar-Test-Signature"}},
+		{reply: "stream: Win.Trojan.Agent-123 FOUND", want: Result{Signature: "Win.Trojan.Agent-123"}},
+		{reply: "INSTREAM size limit exceeded. ERROR\x00", wantErr: "INSTREAM size limit exceeded. ERROR"},
+		{reply: "", wantErr: "empty reply"},
+		{reply: "\x00", wantErr: "empty reply"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.TrimRight(tt.reply, "\x00\n"), func(t *testing.T) {
+			got, err := parseClamdReply([]byte(tt.reply))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseClamdReply(%q) error = %v, want %q", tt.reply, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseClamdReply(%q): %v", tt.reply, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseClamdReply(%q) = %+v, want %+v", tt.reply, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Package scanner pemindaian malware file upload sebelum disimpan ke storage.
+// Driver dipilih lewat SCANNER_DRIVER: clamd (ClamAV) atau noop untuk development.
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/config"
+)
+
+const (
+	DriverNoop  = "noop"
+	DriverClamd = "clamd"
+)
+
+// ErrInfected file mengandung malware, error dari Scan membawa nama signature-nya
+var ErrInfected = errors.New("file is infected")
+
+// Result hasil scan satu file
+type Result struct {
+	Clean bool
+	// Signature nama malware yang terdeteksi, kosong kalau bersih
+	Signature string
+}
+
+// Err ErrInfected dengan nama signature, nil kalau file bersih
+func (r Result) Err() error {
+	if r.Clean {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrInfected, r.Signature)
+}
+
+// Scanner pemindai isi file. Error berarti scan tidak selesai (scanner mati, timeout),
+// bukan file terinfeksi: file seperti ini tidak boleh diupload dan perlu dicoba lagi.
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// New scanner sesuai SCANNER_DRIVER. Kalau kosong, Noop hanya dipakai di development
+// (SERVER_ENV=dev); environment lain harus memilih driver secara eksplisit.
+func New() (Scanner, error) {
+	cfg := config.GetConfig()
+	switch cfg.ScannerDriver {
+	case "":
+		if cfg.Env != "dev" {
+			return nil, apperror.New("scanner", "New", 500, nil, fmt.Sprintf("SCANNER_DRIVER is not set (env %q), set it to %q or %q", cfg.Env, DriverClamd, DriverNoop))
+		}
+		return Noop{}, nil
+	case DriverNoop:
+		return Noop{}, nil
+	case DriverClamd:
+		return NewClamd(cfg.ClamdAddr, cfg.ScannerTimeout)
+	}
+	return nil, apperror.New("scanner", "New", 500, nil, fmt.Sprintf("unknown scanner driver %q", cfg.ScannerDriver))
+}
+
+// Noop scanner yang selalu menganggap file bersih, hanya untuk development
+type Noop struct{}
+
+func (Noop) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	return Result{Clean: true}, nil
+}
@@ -0,0 +1,36 @@
+package scanner
+
+import (
+	"testing"
+
+	"template-golang/pkg/config"
+)
+
+func TestNewRequiresDriverOutsideDev(t *testing.T) {
+	tests := []struct {
+		env, driver string
+		wantErr     bool
+	}{
+		{env: "dev", driver: "", wantErr: false},
+		{env: "production", driver: "", wantErr: true},
+		{env: "production", driver: DriverNoop, wantErr: false},
+		{env: "dev", driver: "unknown", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.env+"/"+tt.driver, func(t *testing.T) {
+			t.Setenv("SERVER_ENV", tt.env)
+			t.Setenv("SCANNER_DRIVER", tt.driver)
+			config.LoadConfig()
+
+			s, err := New()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if _, ok := s.(Noop); !ok {
+					t.Errorf("New() = %T, want Noop", s)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"template-golang/pkg/apperror"
+)
+
+// Local driver filesystem lokal untuk development, file dilayani lewat
+// route static Fiber di path dari baseURL (lihat StaticRoute), kecuali file private
+type Local struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocal(dir, baseURL string) (*Local, error) {
+	if dir == "" {
+		dir = "storage"
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, apperror.New("storage", "NewLocal", 500, err, "failed to create storage dir")
+	}
+	return &Local{dir: dir, baseURL: strings.TrimRight(baseURL, "/")}, nil
+}
+
+// StaticRoute prefix route dan folder untuk app.Static
+func (l *Local) StaticRoute() (string, string) {
+	prefix := "/storage"
+	if u, err := url.Parse(l.baseURL); err == nil && u.Path != "" {
+		prefix = u.Path
+	}
+	return prefix, l.dir
+}
+
+// IsPrivatePath path request route static mengarah ke file private
+func (l *Local) IsPrivatePath(requestPath string) bool {
+	prefix, _ := l.StaticRoute()
+	rel := path.Clean("/" + strings.TrimPrefix(requestPath, prefix))
+	return strings.HasPrefix(strings.ToLower(rel+"/"), "/"+PrivatePrefix)
+}
+
+// path path file untuk key, key yang keluar dari dir ditolak
+func (l *Local) path(key string) (string, error) {
+	key = cleanKey(key)
+	if key == "" || !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
+
+func (l *Local) Put(ctx context.Context, key string, body io.Reader, opts PutOptions) (Object, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return Object{}, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return Object{}, fmt.Errorf("failed to create dir for %s: %w", key, err)
+	}
+
+	// tulis ke file sementara lalu rename supaya reader tidak melihat file setengah jadi
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return Object{}, fmt.Errorf("failed to create %s: %w", key, err)
+	}
+	_, err = io.Copy(tmp, body)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return Object{}, fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	return l.Stat(ctx, key)
+}
+
+func (l *Local) Get(ctx context.Context, key string) (io.ReadCloser, Object, error) {
+	obj, err := l.Stat(ctx, key)
+	if err != nil {
+		return nil, Object{}, err
+	}
+	path, _ := l.path(key)
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, Object{}, localError(key, err)
+	}
+	return f, obj, nil
+}
+
+func (l *Local) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, localError(key, err)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.NewSectionReader(f, offset, length), f}, nil
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (l *Local) Stat(ctx context.Context, key string) (Object, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return Object{}, err
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return Object{}, localError(key, err)
+	}
+	if fi.IsDir() {
+		return Object{}, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return localObject(cleanKey(key), fi), nil
+}
+
+func (l *Local) List(ctx context.Context, prefix string) ([]Object, error) {
+	prefix = cleanKey(prefix)
+	var objects []Object
+	err := filepath.WalkDir(l.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+		rel, err := filepath.Rel(l.dir, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, localObject(key, fi))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", prefix, err)
+	}
+	return objects, nil
+}
+
+func (l *Local) URL(key string) string {
+	return l.baseURL + "/" + cleanKey(key)
+}
+
+func (l *Local) Key(url string) (string, bool) {
+	return keyFromBaseURL(l.baseURL, url)
+}
+
+func localObject(key string, fi fs.FileInfo) Object {
+	contentType := mime.TypeByExtension(filepath.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return Object{Key: key, Size: fi.Size(), ContentType: contentType, ModifiedAt: fi.ModTime()}
+}
+
+func localError(key string, err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return fmt.Errorf("failed to read %s: %w", key, err)
+}
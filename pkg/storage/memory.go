@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Memory driver in-memory untuk test, isi hilang saat proses berhenti
+type Memory struct {
+	baseURL string
+
+	mu      sync.RWMutex
+	objects map[string]memoryObject
+}
+
+type memoryObject struct {
+	data []byte
+	Object
+}
+
+func NewMemory(baseURL string) *Memory {
+	return &Memory{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		objects: map[string]memoryObject{},
+	}
+}
+
+func (m *Memory) Put(ctx context.Context, key string, body io.Reader, opts PutOptions) (Object, error) {
+	key = cleanKey(key)
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return Object{}, fmt.Errorf("failed to read body for %s: %w", key, err)
+	}
+	obj := Object{Key: key, Size: int64(len(data)), ContentType: opts.ContentType, ModifiedAt: time.Now()}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[key] = memoryObject{data: data, Object: obj}
+	return obj, nil
+}
+
+func (m *Memory) Get(ctx context.Context, key string) (io.ReadCloser, Object, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	o, ok := m.objects[cleanKey(key)]
+	if !ok {
+		return nil, Object{}, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return io.NopCloser(bytes.NewReader(o.data)), o.Object, nil
+}
+
+func (m *Memory) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	o, ok := m.objects[cleanKey(key)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	end := min(offset+length, int64(len(o.data)))
+	if offset > end {
+		offset = end
+	}
+	return io.NopCloser(bytes.NewReader(o.data[offset:end])), nil
+}
+
+func (m *Memory) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, cleanKey(key))
+	return nil
+}
+
+func (m *Memory) Stat(ctx context.Context, key string) (Object, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	o, ok := m.objects[cleanKey(key)]
+	if !ok {
+		return Object{}, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return o.Object, nil
+}
+
+func (m *Memory) List(ctx context.Context, prefix string) ([]Object, error) {
+	prefix = cleanKey(prefix)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var objects []Object
+	for key, o := range m.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, o.Object)
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+func (m *Memory) URL(key string) string {
+	return m.baseURL + "/" + cleanKey(key)
+}
+
+func (m *Memory) Key(url string) (string, bool) {
+	return keyFromBaseURL(m.baseURL, url)
+}
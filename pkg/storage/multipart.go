@@ -0,0 +1,266 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const (
+	// MinPartSize batas minimal ukuran part S3 (kecuali part terakhir)
+	MinPartSize = 5 * 1024 * 1024
+	// DefaultPartSize ukuran part kalau tidak diatur
+	DefaultPartSize = 8 * 1024 * 1024
+	// DefaultConcurrency jumlah part yang diupload bersamaan
+	DefaultConcurrency = 4
+
+	// maxParts batas jumlah part per multipart upload S3
+	maxParts = 10000
+)
+
+// MultipartOptions opsi upload file besar
+type MultipartOptions struct {
+	ContentType string
+	// PartSize ukuran tiap part, 0 = default driver
+	PartSize int64
+	// Concurrency jumlah part yang diupload bersamaan, 0 = default driver
+	Concurrency int
+	// UploadID lanjutkan multipart upload yang sudah ada, part yang sudah selesai dilewati
+	UploadID string
+	// OnStart dipanggil dengan upload id sebelum part pertama diupload, simpan untuk resume
+	OnStart func(uploadID string)
+	// OnProgress dipanggil setiap satu part selesai
+	OnProgress func(uploaded, total int64)
+	// Resumable part yang sudah terupload tidak di-abort saat gagal supaya bisa
+	// dilanjutkan dengan UploadID yang sama. Default-nya multipart upload di-abort.
+	Resumable bool
+}
+
+// MultipartUploader driver yang bisa upload file besar per part tanpa membaca
+// seluruh isi ke memori. Tiap part dibaca langsung dari src (io.SectionReader).
+type MultipartUploader interface {
+	PutMultipart(ctx context.Context, key string, src io.ReaderAt, size int64, opts MultipartOptions) (Object, error)
+	AbortMultipart(ctx context.Context, key, uploadID string) error
+}
+
+// PutStream upload src lewat multipart kalau driver mendukung, selain itu lewat Put biasa
+func PutStream(ctx context.Context, store Storage, key string, src io.ReaderAt, size int64, opts MultipartOptions) (Object, error) {
+	if mu, ok := store.(MultipartUploader); ok {
+		return mu.PutMultipart(ctx, key, src, size, opts)
+	}
+	obj, err := store.Put(ctx, key, io.NewSectionReader(src, 0, size), PutOptions{ContentType: opts.ContentType})
+	if err == nil && opts.OnProgress != nil {
+		opts.OnProgress(size, size)
+	}
+	return obj, err
+}
+
+// PutMultipart upload src per part secara paralel. File yang muat dalam satu part
+// diupload dengan PutObject biasa.
+func (s *S3) PutMultipart(ctx context.Context, key string, src io.ReaderAt, size int64, opts MultipartOptions) (Object, error) {
+	key = cleanKey(key)
+	partSize := s.partSizeFor(size, opts.PartSize)
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = s.concurrency
+	}
+
+	if size <= partSize {
+		_, err := s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+			Bucket:        aws.String(s.bucket),
+			Key:           aws.String(key),
+			Body:          io.NewSectionReader(src, 0, size),
+			ContentLength: aws.Int64(size),
+			ContentType:   aws.String(opts.ContentType),
+			ACL:           aws.String(acl(key)),
+		})
+		if err != nil {
+			return Object{}, fmt.Errorf("failed to upload %s: %w", key, err)
+		}
+		if opts.OnProgress != nil {
+			opts.OnProgress(size, size)
+		}
+		return Object{Key: key, Size: size, ContentType: opts.ContentType}, nil
+	}
+
+	numParts := (size + partSize - 1) / partSize
+	parts := make([]*s3.CompletedPart, numParts)
+	var uploaded atomic.Int64
+
+	uploadID := opts.UploadID
+	if uploadID != "" {
+		done, err := s.listParts(ctx, key, uploadID)
+		switch {
+		case isNoSuchUpload(err):
+			// sudah di-abort / kedaluwarsa, mulai dari awal
+			uploadID = ""
+		case err != nil:
+			return Object{}, err
+		}
+		for _, p := range done {
+			n := aws.Int64Value(p.PartNumber)
+			if n < 1 || n > numParts || aws.Int64Value(p.Size) != partLength(n, partSize, size) {
+				continue
+			}
+			parts[n-1] = &s3.CompletedPart{ETag: p.ETag, PartNumber: p.PartNumber}
+			uploaded.Add(aws.Int64Value(p.Size))
+		}
+	}
+	if uploadID == "" {
+		out, err := s.client.CreateMultipartUploadWithContext(ctx, &s3.CreateMultipartUploadInput{
+			Bucket:      aws.String(s.bucket),
+			Key:         aws.String(key),
+			ContentType: aws.String(opts.ContentType),
+			ACL:         aws.String(acl(key)),
+		})
+		if err != nil {
+			return Object{}, fmt.Errorf("failed to start multipart upload %s: %w", key, err)
+		}
+		uploadID = aws.StringValue(out.UploadId)
+	}
+	if opts.OnStart != nil {
+		opts.OnStart(uploadID)
+	}
+
+	err := s.uploadParts(ctx, key, uploadID, src, size, partSize, concurrency, parts, &uploaded, opts.OnProgress)
+	if err == nil {
+		_, err = s.client.CompleteMultipartUploadWithContext(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:          aws.String(s.bucket),
+			Key:             aws.String(key),
+			UploadId:        aws.String(uploadID),
+			MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+		})
+	}
+	if err != nil {
+		if !opts.Resumable {
+			// ctx bisa sudah dibatalkan, abort tetap harus jalan
+			if aerr := s.AbortMultipart(context.WithoutCancel(ctx), key, uploadID); aerr != nil {
+				err = errors.Join(err, aerr)
+			}
+		}
+		return Object{}, fmt.Errorf("failed to upload %s (upload id %s): %w", key, uploadID, err)
+	}
+	return Object{Key: key, Size: size, ContentType: opts.ContentType}, nil
+}
+
+// uploadParts upload part yang belum ada di parts, berhenti di error pertama
+func (s *S3) uploadParts(ctx context.Context, key, uploadID string, src io.ReaderAt, size, partSize int64, concurrency int, parts []*s3.CompletedPart, uploaded *atomic.Int64, onProgress func(uploaded, total int64)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	numbers := make(chan int64)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range numbers {
+				length := partLength(n, partSize, size)
+				out, err := s.client.UploadPartWithContext(ctx, &s3.UploadPartInput{
+					Bucket:        aws.String(s.bucket),
+					Key:           aws.String(key),
+					UploadId:      aws.String(uploadID),
+					PartNumber:    aws.Int64(n),
+					Body:          io.NewSectionReader(src, (n-1)*partSize, length),
+					ContentLength: aws.Int64(length),
+				})
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = fmt.Errorf("failed to upload part %d: %w", n, err)
+						cancel()
+					})
+					continue
+				}
+				parts[n-1] = &s3.CompletedPart{ETag: out.ETag, PartNumber: aws.Int64(n)}
+				done := uploaded.Add(length)
+				if onProgress != nil {
+					onProgress(done, size)
+				}
+			}
+		}()
+	}
+
+send:
+	for i, p := range parts {
+		if p != nil {
+			continue
+		}
+		select {
+		case numbers <- int64(i + 1):
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(numbers)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// AbortMultipart batalkan multipart upload dan hapus semua part yang sudah terupload
+func (s *S3) AbortMultipart(ctx context.Context, key, uploadID string) error {
+	_, err := s.client.AbortMultipartUploadWithContext(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(cleanKey(key)),
+		UploadId: aws.String(uploadID),
+	})
+	if err != nil && !isNoSuchUpload(err) {
+		return fmt.Errorf("failed to abort multipart upload %s: %w", uploadID, err)
+	}
+	return nil
+}
+
+// listParts part yang sudah terupload untuk uploadID
+func (s *S3) listParts(ctx context.Context, key, uploadID string) ([]*s3.Part, error) {
+	var parts []*s3.Part
+	err := s.client.ListPartsPagesWithContext(ctx, &s3.ListPartsInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	}, func(page *s3.ListPartsOutput, _ bool) bool {
+		parts = append(parts, page.Parts...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list parts of %s: %w", uploadID, err)
+	}
+	return parts, nil
+}
+
+// partSizeFor ukuran part minimal MinPartSize, diperbesar kalau jumlah part melebihi batas S3
+func (s *S3) partSizeFor(size, partSize int64) int64 {
+	if partSize <= 0 {
+		partSize = s.partSize
+	}
+	if partSize < MinPartSize {
+		partSize = MinPartSize
+	}
+	if minSize := (size + maxParts - 1) / maxParts; partSize < minSize {
+		partSize = minSize
+	}
+	return partSize
+}
+
+// partLength ukuran part ke-n (mulai dari 1), part terakhir bisa lebih kecil
+func partLength(n, partSize, size int64) int64 {
+	return min(partSize, size-(n-1)*partSize)
+}
+
+func isNoSuchUpload(err error) bool {
+	var aerr awserr.Error
+	return errors.As(err, &aerr) && aerr.Code() == s3.ErrCodeNoSuchUpload
+}
@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/goccy/go-json"
+)
+
+// ErrPresignUnsupported driver tidak bisa membuat presigned upload (local, memory)
+var ErrPresignUnsupported = errors.New("storage: driver does not support presigned uploads")
+
+// ErrSignedURLUnsupported driver tidak bisa membuat URL download bertanda tangan (local, memory)
+var ErrSignedURLUnsupported = errors.New("storage: driver does not support signed download urls")
+
+// PresignOptions batasan upload langsung dari client
+type PresignOptions struct {
+	ContentType string
+	// Size ukuran pasti file (PUT), ikut ditandatangani sebagai Content-Length
+	Size int64
+	// MaxSize ukuran maksimal file (POST policy content-length-range)
+	MaxSize int64
+	Expires time.Duration
+}
+
+// PresignedRequest request yang harus dikirim client untuk upload langsung ke storage
+type PresignedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// Headers wajib dikirim apa adanya (PUT)
+	Headers map[string]string `json:"headers,omitempty"`
+	// Fields form field sebelum field "file" (POST multipart)
+	Fields    map[string]string `json:"fields,omitempty"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// Presigner driver yang mendukung upload langsung dari client
+type Presigner interface {
+	PresignPut(ctx context.Context, key string, opts PresignOptions) (PresignedRequest, error)
+	PresignPost(ctx context.Context, key string, opts PresignOptions) (PresignedRequest, error)
+}
+
+// SignedURLOptions opsi URL download bertanda tangan
+type SignedURLOptions struct {
+	Expires time.Duration
+	// ContentDisposition header Content-Disposition yang dikirim storage, contoh `attachment; filename="ktp.pdf"`
+	ContentDisposition string
+}
+
+// URLSigner driver yang bisa membuat URL download sementara untuk object private
+type URLSigner interface {
+	SignedURL(ctx context.Context, key string, opts SignedURLOptions) (string, error)
+}
+
+// SignedURL URL GET bertanda tangan, berlaku selama opts.Expires
+func (s *S3) SignedURL(ctx context.Context, key string, opts SignedURLOptions) (string, error) {
+	key = cleanKey(key)
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}
+	if opts.ContentDisposition != "" {
+		input.ResponseContentDisposition = aws.String(opts.ContentDisposition)
+	}
+	req, _ := s.client.GetObjectRequest(input)
+	req.SetContext(ctx)
+
+	url, err := req.Presign(opts.Expires)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign %s: %w", key, err)
+	}
+	return url, nil
+}
+
+// PresignPut URL PUT bertanda tangan, Content-Type dan Content-Length harus sama persis
+func (s *S3) PresignPut(ctx context.Context, key string, opts PresignOptions) (PresignedRequest, error) {
+	key = cleanKey(key)
+	req, _ := s.client.PutObjectRequest(&s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		ContentType:   aws.String(opts.ContentType),
+		ContentLength: aws.Int64(opts.Size),
+		ACL:           aws.String(acl(key)),
+	})
+	req.SetContext(ctx)
+
+	url, signed, err := req.PresignRequest(opts.Expires)
+	if err != nil {
+		return PresignedRequest{}, fmt.Errorf("failed to presign %s: %w", key, err)
+	}
+
+	headers := map[string]string{}
+	for name, values := range signed {
+		name = http.CanonicalHeaderKey(name)
+		// Host diisi otomatis oleh client HTTP
+		if name != "Host" {
+			headers[name] = strings.Join(values, ",")
+		}
+	}
+	return PresignedRequest{
+		Method:    "PUT",
+		URL:       url,
+		Headers:   headers,
+		ExpiresAt: time.Now().Add(opts.Expires).UTC(),
+	}, nil
+}
+
+// PresignPost form POST dengan policy SigV4: key, Content-Type dan rentang ukuran
+// (1 - MaxSize) dicek oleh S3 sendiri
+func (s *S3) PresignPost(ctx context.Context, key string, opts PresignOptions) (PresignedRequest, error) {
+	key = cleanKey(key)
+	now := time.Now().UTC()
+	expiresAt := now.Add(opts.Expires)
+	date := now.Format("20060102")
+	amzDate := now.Format("20060102T150405Z")
+	credential := fmt.Sprintf("%s/%s/%s/s3/aws4_request", s.accessKey, date, s.region)
+
+	fields := map[string]string{
+		"key":              key,
+		"acl":              acl(key),
+		"Content-Type":     opts.ContentType,
+		"x-amz-algorithm":  "AWS4-HMAC-SHA256",
+		"x-amz-credential": credential,
+		"x-amz-date":       amzDate,
+	}
+
+	conditions := []any{
+		map[string]string{"bucket": s.bucket},
+		[]any{"content-length-range", 1, opts.MaxSize},
+	}
+	for name, value := range fields {
+		conditions = append(conditions, map[string]string{name: value})
+	}
+	policy, err := json.Marshal(map[string]any{
+		"expiration": expiresAt.Format("2006-01-02T15:04:05.000Z"),
+		"conditions": conditions,
+	})
+	if err != nil {
+		return PresignedRequest{}, fmt.Errorf("failed to build policy for %s: %w", key, err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(policy)
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+
+	fields["policy"] = encoded
+	fields["x-amz-signature"] = hex.EncodeToString(hmacSHA256(signingKey, encoded))
+
+	return PresignedRequest{
+		Method:    "POST",
+		URL:       fmt.Sprintf("%s/%s", s.endpoint, s.bucket),
+		Fields:    fields,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
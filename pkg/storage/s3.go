@@ -0,0 +1,244 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"template-golang/pkg/apperror"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// S3Config koneksi bucket S3 (atau S3 compatible)
+type S3Config struct {
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+	// Endpoint host S3, tanpa / dengan skema
+	Endpoint string
+	// PartSize ukuran part multipart upload (byte), default DefaultPartSize
+	PartSize int64
+	// Concurrency jumlah part yang diupload bersamaan, default DefaultConcurrency
+	Concurrency int
+}
+
+// S3 driver S3, object diupload dengan ACL public-read, kecuali key private (lihat acl)
+type S3 struct {
+	client   *s3.S3
+	uploader *s3manager.Uploader
+	bucket   string
+	// endpoint URL endpoint dengan skema, tanpa "/" di akhir
+	endpoint string
+
+	partSize    int64
+	concurrency int
+
+	// kredensial untuk tanda tangan POST policy
+	region    string
+	accessKey string
+	secretKey string
+}
+
+func NewS3(cfg S3Config) (*S3, error) {
+	region := cfg.Region
+	if region == "" {
+		region = "us-west-2"
+	}
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(region),
+		Endpoint:    aws.String(cfg.Endpoint),
+		Credentials: credentials.NewStaticCredentials(cfg.AccessKey, cfg.SecretKey, ""),
+	})
+	if err != nil {
+		return nil, apperror.New("storage", "NewS3", 500, err, "failed to create S3 session")
+	}
+
+	endpoint := strings.TrimRight(cfg.Endpoint, "/")
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		endpoint = "https://" + endpoint
+	}
+
+	partSize := cfg.PartSize
+	if partSize < MinPartSize {
+		partSize = DefaultPartSize
+	}
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	client := s3.New(sess)
+	return &S3{
+		client: client,
+		uploader: s3manager.NewUploaderWithClient(client, func(u *s3manager.Uploader) {
+			u.PartSize = partSize
+			u.Concurrency = concurrency
+		}),
+		bucket:   cfg.Bucket,
+		endpoint: endpoint,
+
+		partSize:    partSize,
+		concurrency: concurrency,
+
+		region:    region,
+		accessKey: cfg.AccessKey,
+		secretKey: cfg.SecretKey,
+	}, nil
+}
+
+func (s *S3) Put(ctx context.Context, key string, body io.Reader, opts PutOptions) (Object, error) {
+	key = cleanKey(key)
+	counter := &countingReader{r: body}
+	_, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        counter,
+		ContentType: aws.String(opts.ContentType),
+		ACL:         aws.String(acl(key)),
+	})
+	if err != nil {
+		return Object{}, fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	return Object{Key: key, Size: counter.n, ContentType: opts.ContentType}, nil
+}
+
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, Object, error) {
+	key = cleanKey(key)
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, Object{}, s3Error(key, err)
+	}
+	return out.Body, Object{
+		Key:         key,
+		Size:        aws.Int64Value(out.ContentLength),
+		ContentType: aws.StringValue(out.ContentType),
+		ModifiedAt:  aws.TimeValue(out.LastModified),
+	}, nil
+}
+
+func (s *S3) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	key = cleanKey(key)
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)),
+	})
+	if err != nil {
+		return nil, s3Error(key, err)
+	}
+	return out.Body, nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	key = cleanKey(key)
+	_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *S3) Stat(ctx context.Context, key string) (Object, error) {
+	key = cleanKey(key)
+	out, err := s.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return Object{}, s3Error(key, err)
+	}
+	return Object{
+		Key:         key,
+		Size:        aws.Int64Value(out.ContentLength),
+		ContentType: aws.StringValue(out.ContentType),
+		ModifiedAt:  aws.TimeValue(out.LastModified),
+	}, nil
+}
+
+func (s *S3) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	err := s.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(cleanKey(prefix)),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, o := range page.Contents {
+			objects = append(objects, Object{
+				Key:        aws.StringValue(o.Key),
+				Size:       aws.Int64Value(o.Size),
+				ModifiedAt: aws.TimeValue(o.LastModified),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", prefix, err)
+	}
+	return objects, nil
+}
+
+// URL "<endpoint>/<bucket>/<key>"
+func (s *S3) URL(key string) string {
+	return fmt.Sprintf("%s/%s/%s", s.endpoint, s.bucket, cleanKey(key))
+}
+
+// Key ambil key setelah segmen bucket di URL
+func (s *S3) Key(url string) (string, bool) {
+	lower := strings.ToLower(url)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		return "", false
+	}
+	parts := strings.Split(url, "/")
+	// parts[0..2] = "https:", "", host
+	for i := 3; i < len(parts)-1; i++ {
+		if parts[i] == s.bucket {
+			return strings.Join(parts[i+1:], "/"), true
+		}
+	}
+	return "", false
+}
+
+// acl ACL object: key private hanya bisa dibaca lewat kredensial / URL bertanda tangan
+func acl(key string) string {
+	if IsPrivate(key) {
+		return "private"
+	}
+	return "public-read"
+}
+
+func s3Error(key string, err error) error {
+	var aerr awserr.Error
+	if errors.As(err, &aerr) {
+		switch aerr.Code() {
+		case s3.ErrCodeNoSuchKey, "NotFound":
+			return fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+	}
+	return fmt.Errorf("failed to read %s: %w", key, err)
+}
+
+// countingReader hitung jumlah byte yang dibaca
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
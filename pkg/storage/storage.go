@@ -0,0 +1,128 @@
+// Package storage abstraksi penyimpanan file (S3, local filesystem, memory).
+// Driver dipilih lewat STORAGE_DRIVER dan di-inject lewat wire.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"template-golang/pkg/apperror"
+	"template-golang/pkg/config"
+)
+
+const (
+	DriverS3     = "s3"
+	DriverLocal  = "local"
+	DriverMemory = "memory"
+)
+
+// PrivatePrefix prefix key file private: tidak bisa diakses lewat URL publik,
+// diunduh lewat URL bertanda tangan (URLSigner) atau proxy API
+const PrivatePrefix = "private/"
+
+// StagingPrefix prefix key upload presigned sebelum discan dan disalin worker ke key akhir
+const StagingPrefix = PrivatePrefix + "staging/"
+
+// ErrNotFound object tidak ada di storage
+var ErrNotFound = errors.New("storage: object not found")
+
+// Object metadata satu file di storage
+type Object struct {
+	Key         string    `json:"key"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"content_type"`
+	ModifiedAt  time.Time `json:"modified_at"`
+}
+
+// PutOptions opsi saat Put
+type PutOptions struct {
+	ContentType string
+}
+
+// Storage penyimpanan file berbasis key, contoh "avatars/123.webp"
+type Storage interface {
+	// Put simpan isi body ke key (menimpa kalau sudah ada)
+	Put(ctx context.Context, key string, body io.Reader, opts PutOptions) (Object, error)
+	// Get buka isi key, reader wajib di-Close
+	Get(ctx context.Context, key string) (io.ReadCloser, Object, error)
+	// GetRange buka length byte isi key mulai offset, reader wajib di-Close
+	GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	// Delete hapus key, tidak error kalau key tidak ada
+	Delete(ctx context.Context, key string) error
+	// Stat metadata key, ErrNotFound kalau tidak ada
+	Stat(ctx context.Context, key string) (Object, error)
+	// List semua object dengan prefix key
+	List(ctx context.Context, prefix string) ([]Object, error)
+	// URL URL publik key
+	URL(key string) string
+	// Key kebalikan URL, false kalau URL bukan milik storage ini
+	Key(url string) (string, bool)
+}
+
+// New storage sesuai STORAGE_DRIVER (s3, local, memory)
+func New() (Storage, error) {
+	cfg := config.GetConfig()
+	switch cfg.StorageDriver {
+	case "", DriverS3:
+		return NewS3(S3Config{
+			Bucket:    cfg.S3Bucket,
+			Region:    cfg.S3Region,
+			AccessKey: cfg.S3Access,
+			SecretKey: cfg.S3Secret,
+			Endpoint:  cfg.S3End,
+
+			PartSize:    int64(cfg.S3PartSizeMB) * 1024 * 1024,
+			Concurrency: cfg.S3UploadConcurrency,
+		})
+	case DriverLocal:
+		return NewLocal(cfg.StorageLocalDir, publicURL(cfg))
+	case DriverMemory:
+		return NewMemory(publicURL(cfg)), nil
+	}
+	return nil, apperror.New("storage", "New", 500, nil, fmt.Sprintf("unknown storage driver %q", cfg.StorageDriver))
+}
+
+// Bucket lokasi penyimpanan driver aktif: nama bucket S3, folder driver local
+// atau "memory", disimpan di tabel files bersama storage key
+func Bucket() string {
+	cfg := config.GetConfig()
+	switch cfg.StorageDriver {
+	case "", DriverS3:
+		return cfg.S3Bucket
+	case DriverLocal:
+		return cfg.StorageLocalDir
+	}
+	return cfg.StorageDriver
+}
+
+// IsPrivate key file private (diawali PrivatePrefix)
+func IsPrivate(key string) bool {
+	return strings.HasPrefix(cleanKey(key), PrivatePrefix)
+}
+
+// publicURL base URL driver local / memory, default file dilayani API sendiri di /storage
+func publicURL(cfg *config.Config) string {
+	if cfg.StoragePublicURL != "" {
+		return cfg.StoragePublicURL
+	}
+	return fmt.Sprintf("http://localhost:%d/storage", cfg.Port)
+}
+
+// cleanKey buang "/" di awal key
+func cleanKey(key string) string {
+	return strings.TrimLeft(key, "/")
+}
+
+// keyFromBaseURL key dari URL "<baseURL>/<key>"
+func keyFromBaseURL(baseURL, url string) (string, bool) {
+	prefix := strings.TrimRight(baseURL, "/") + "/"
+	if !strings.HasPrefix(url, prefix) {
+		return "", false
+	}
+	key := strings.TrimPrefix(url, prefix)
+	return key, key != ""
+}
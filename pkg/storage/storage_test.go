@@ -0,0 +1,245 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// drivers driver yang bisa dites tanpa layanan luar
+func drivers(t *testing.T) map[string]Storage {
+	t.Helper()
+	local, err := NewLocal(t.TempDir(), "http://localhost:8080/storage/")
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+	return map[string]Storage{
+		DriverMemory: NewMemory("http://localhost:8080/storage/"),
+		DriverLocal:  local,
+	}
+}
+
+func readAll(t *testing.T, r io.ReadCloser) string {
+	t.Helper()
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	return string(data)
+}
+
+func TestDriverContract(t *testing.T) {
+	ctx := context.Background()
+	for name, store := range drivers(t) {
+		t.Run(name, func(t *testing.T) {
+			obj, err := store.Put(ctx, "/docs/a.txt", strings.NewReader("hello world"), PutOptions{ContentType: "text/plain"})
+			if err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+			if obj.Key != "docs/a.txt" || obj.Size != 11 {
+				t.Errorf("Put object = %+v", obj)
+			}
+
+			body, got, err := store.Get(ctx, "docs/a.txt")
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if s := readAll(t, body); s != "hello world" || got.Size != 11 {
+				t.Errorf("Get = %q %+v", s, got)
+			}
+
+			r, err := store.GetRange(ctx, "docs/a.txt", 6, 5)
+			if err != nil {
+				t.Fatalf("GetRange: %v", err)
+			}
+			if s := readAll(t, r); s != "world" {
+				t.Errorf("GetRange = %q, want world", s)
+			}
+			r, err = store.GetRange(ctx, "docs/a.txt", 6, 100)
+			if err != nil {
+				t.Fatalf("GetRange past end: %v", err)
+			}
+			if s := readAll(t, r); s != "world" {
+				t.Errorf("GetRange past end = %q, want world", s)
+			}
+
+			// Put menimpa isi lama
+			if _, err := store.Put(ctx, "docs/a.txt", strings.NewReader("hi"), PutOptions{}); err != nil {
+				t.Fatalf("Put overwrite: %v", err)
+			}
+			if stat, err := store.Stat(ctx, "docs/a.txt"); err != nil || stat.Size != 2 {
+				t.Errorf("Stat after overwrite = %+v, %v", stat, err)
+			}
+
+			if _, err := store.Put(ctx, "docs/b.txt", strings.NewReader("b"), PutOptions{}); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+			if _, err := store.Put(ctx, "other/c.txt", strings.NewReader("c"), PutOptions{}); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+			objects, err := store.List(ctx, "docs/")
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(objects) != 2 || objects[0].Key != "docs/a.txt" || objects[1].Key != "docs/b.txt" {
+				t.Errorf("List = %+v", objects)
+			}
+
+			if err := store.Delete(ctx, "docs/a.txt"); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+			if err := store.Delete(ctx, "docs/a.txt"); err != nil {
+				t.Errorf("Delete missing key: %v", err)
+			}
+			if _, err := store.Stat(ctx, "docs/a.txt"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Stat deleted = %v, want ErrNotFound", err)
+			}
+			if _, _, err := store.Get(ctx, "docs/a.txt"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Get deleted = %v, want ErrNotFound", err)
+			}
+			if _, err := store.GetRange(ctx, "docs/a.txt", 0, 1); !errors.Is(err, ErrNotFound) {
+				t.Errorf("GetRange deleted = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestDriverURLAndKey(t *testing.T) {
+	for name, store := range drivers(t) {
+		t.Run(name, func(t *testing.T) {
+			url := store.URL("/avatars/1.webp")
+			if url != "http://localhost:8080/storage/avatars/1.webp" {
+				t.Errorf("URL = %s", url)
+			}
+			if key, ok := store.Key(url); !ok || key != "avatars/1.webp" {
+				t.Errorf("Key(%s) = %s, %v", url, key, ok)
+			}
+			for _, foreign := range []string{"https://bucket.s3.amazonaws.com/avatars/1.webp", "http://localhost:8080/storage/", "http://localhost:8080/storagex/a"} {
+				if key, ok := store.Key(foreign); ok {
+					t.Errorf("Key(%s) = %s, want not owned", foreign, key)
+				}
+			}
+		})
+	}
+}
+
+func TestLocalRejectsKeysOutsideDir(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := NewLocal(filepath.Join(dir, "storage"), "http://localhost/storage")
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+
+	for _, key := range []string{"../escape.txt", "docs/../../escape.txt", ""} {
+		if _, err := store.Put(ctx, key, strings.NewReader("x"), PutOptions{}); err == nil {
+			t.Errorf("Put(%q) succeeded", key)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escape.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("file written outside storage dir: %v", err)
+	}
+}
+
+func TestLocalListSkipsPartialUploads(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := NewLocal(dir, "http://localhost/storage")
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+	if _, err := store.Put(ctx, "a/file.pdf", strings.NewReader("pdf"), PutOptions{}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a", ".upload-123"), []byte("partial"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	objects, err := store.List(ctx, "")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(objects) != 1 || objects[0].Key != "a/file.pdf" || objects[0].ContentType != "application/pdf" {
+		t.Errorf("List = %+v", objects)
+	}
+}
+
+func TestLocalPrivatePaths(t *testing.T) {
+	store, err := NewLocal(t.TempDir(), "http://localhost:8080/files")
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+	if prefix, _ := store.StaticRoute(); prefix != "/files" {
+		t.Errorf("StaticRoute prefix = %s", prefix)
+	}
+
+	tests := map[string]bool{
+		"/files/private/a.pdf":        true,
+		"/files/PRIVATE/a.pdf":        true,
+		"/files/public/../private/a":  true,
+		"/files//private/a.pdf":       true,
+		"/files/avatars/a.webp":       false,
+		"/files/privates/a.pdf":       false,
+		"/files/avatars/private/a.js": false,
+	}
+	for path, want := range tests {
+		if got := store.IsPrivatePath(path); got != want {
+			t.Errorf("IsPrivatePath(%s) = %v, want %v", path, got, want)
+		}
+	}
+
+	if !IsPrivate("/private/bukti/a.pdf") || IsPrivate("avatars/private.webp") {
+		t.Error("IsPrivate mismatch")
+	}
+}
+
+func TestPutStreamFallsBackToPut(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory("http://localhost/storage")
+	data := bytes.Repeat([]byte("x"), 1024)
+
+	var uploaded, total int64
+	obj, err := PutStream(ctx, store, "big/file.bin", bytes.NewReader(data), int64(len(data)), MultipartOptions{
+		OnProgress: func(u, t int64) { uploaded, total = u, t },
+	})
+	if err != nil {
+		t.Fatalf("PutStream: %v", err)
+	}
+	if obj.Size != 1024 || uploaded != 1024 || total != 1024 {
+		t.Errorf("PutStream object = %+v, progress %d/%d", obj, uploaded, total)
+	}
+}
+
+func TestPartSizeFor(t *testing.T) {
+	s := &S3{partSize: 8 * 1024 * 1024}
+	tests := []struct {
+		name           string
+		size, partSize int64
+		want           int64
+	}{
+		{name: "driver default", size: 100 << 20, want: 8 << 20},
+		{name: "explicit", size: 100 << 20, partSize: 16 << 20, want: 16 << 20},
+		{name: "below S3 minimum", size: 100 << 20, partSize: 1 << 20, want: MinPartSize},
+		{name: "too many parts", size: 200 << 30, want: (200<<30 + maxParts - 1) / maxParts},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.partSizeFor(tt.size, tt.partSize); got != tt.want {
+				t.Errorf("partSizeFor = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if got := partLength(3, 10, 25); got != 5 {
+		t.Errorf("last partLength = %d, want 5", got)
+	}
+	if got := partLength(1, 10, 25); got != 10 {
+		t.Errorf("first partLength = %d, want 10", got)
+	}
+}
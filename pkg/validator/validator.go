@@ -4,14 +4,16 @@ import (
 	"mime/multipart"
 	"net/http"
 	"reflect"
-	"runtime/debug"
 	"strconv"
 	"strings"
 
 	"template-golang/pkg/apperror"
+	"template-golang/pkg/i18n"
+	"template-golang/pkg/locale"
 	"template-golang/pkg/logger"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/goccy/go-json"
 )
 
 var validate = validator.New()
@@ -91,18 +93,22 @@ func init() {
 		}
 	})
 
-	// Validate image file types (jpg, jpeg, png)
+	// Validate image file name (jpg, jpeg, png) dari string atau multipart.FileHeader.
+	// Hanya ekstensi, isi file dicek fileUploader (filecheck) saat upload.
 	validate.RegisterValidation("image", func(fl validator.FieldLevel) bool {
-		v, ok := fl.Field().Interface().(multipart.FileHeader)
-		if !ok {
+		var v string
+		switch f := fl.Field().Interface().(type) {
+		case string:
+			v = f
+		case multipart.FileHeader:
+			v = f.Filename
+		default:
 			return false
 		}
-
-		contentType := v.Header.Get("Content-Type")
-		validTypes := []string{"image/jpg", "image/jpeg", "image/png"}
-
-		for _, mimeType := range validTypes {
-			if contentType == mimeType {
+		v = strings.ToLower(v)
+		validTypes := []string{".jpg", ".jpeg", ".png"}
+		for _, ext := range validTypes {
+			if strings.HasSuffix(v, ext) {
 				return true
 			}
 		}
@@ -121,72 +127,83 @@ func init() {
 		return v <= maxSizeBytes
 	})
 
-	// Validate image file types (jpg, jpeg, png)
-	validate.RegisterValidation("image", func(fl validator.FieldLevel) bool {
-		v := strings.ToLower(fl.Field().String())
-		validTypes := []string{".jpg", ".jpeg", ".png"}
-		for _, ext := range validTypes {
-			if strings.HasSuffix(v, ext) {
-				return true
+	// Validate pdf file name
+	validate.RegisterValidation("pdf", func(fl validator.FieldLevel) bool {
+		return strings.HasSuffix(strings.ToLower(fl.Field().String()), ".pdf")
+	})
+
+	// Validate teks per locale (map[string]string), semua key harus locale yang didukung
+	validate.RegisterValidation("locales", func(fl validator.FieldLevel) bool {
+		field := fl.Field()
+		if field.Kind() != reflect.Map {
+			return false
+		}
+		for _, key := range field.MapKeys() {
+			if !locale.IsSupported(key.String()) {
+				return false
 			}
 		}
-		return false
+		return true
 	})
 
-	// Validate image file size with custom max size in MB
-	validate.RegisterValidation("size", func(fl validator.FieldLevel) bool {
-		v := fl.Field().Int()
-		param := fl.Param()
-		maxSizeMB, err := strconv.ParseInt(param, 10, 64)
-		if err != nil {
+	// Validate teks per locale wajib terisi untuk locale default
+	validate.RegisterValidation("default_locale", func(fl validator.FieldLevel) bool {
+		field := fl.Field()
+		if field.Kind() != reflect.Map || field.Len() == 0 {
 			return false
 		}
-		maxSizeBytes := maxSizeMB * 1024 * 1024 // Convert MB to bytes
-		return v <= maxSizeBytes
+		v := field.MapIndex(reflect.ValueOf(locale.Default))
+		return v.IsValid() && strings.TrimSpace(v.String()) != ""
 	})
 }
 
+// FieldError satu error validasi, pesannya dirender sesuai locale
+type FieldError struct {
+	Field string
+	Tag   string
+	Param string
+}
+
+// ValidationErrors detail error validasi per field (key: nama field lowercase)
+type ValidationErrors map[string]FieldError
+
+// Localize render pesan validasi dalam locale l
+func (v ValidationErrors) Localize(l string) any {
+	errs := make(map[string]string, len(v))
+	for key, e := range v {
+		errs[key] = i18n.Validation(l, e.Tag, i18n.Params{
+			"field": e.Field,
+			"tag":   e.Tag,
+			"param": e.Param,
+		})
+	}
+	return errs
+}
+
+// MarshalJSON pakai locale default kalau detail tidak dilokalisasi lewat ErrorHandler
+func (v ValidationErrors) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Localize(locale.Default))
+}
+
 // ValidateStruct pakai validator global
 func ValidateStruct(s interface{}) error {
 	if err := validate.Struct(s); err != nil {
-		errs := make(map[string]string)
+		errs := make(ValidationErrors)
 		for _, e := range err.(validator.ValidationErrors) {
-			message := ""
+			param := e.Param()
 			switch e.Tag() {
-			case "required":
-				message = e.Field() + " is required"
-			case "email":
-				message = e.Field() + " must be a valid email address"
-			case "min":
-				message = e.Field() + " must be at least " + e.Param() + " characters long"
-			case "max":
-				message = e.Field() + " must not exceed " + e.Param() + " characters"
-			case "strong_password":
-				message = e.Field() + " must be at least 8 characters and contain both letters and numbers"
-			case "alphanum_space":
-				message = e.Field() + " must only contain letters, numbers and spaces"
-			case "latitude":
-				message = e.Field() + " must be a valid latitude between -90 and 90"
-			case "longitude":
-				message = e.Field() + " must be a valid longitude between -180 and 180"
-			case "positive":
-				message = e.Field() + " must be a positive number"
-			case "image":
-				message = e.Field() + " must be a valid image file (jpg, jpeg, png)"
-			case "size":
-				message = e.Field() + " must not exceed " + e.Param() + " MB"
-			default:
-				message = e.Field() + " failed " + e.Tag() + " validation"
+			case "locales":
+				param = strings.Join(locale.Supported, ", ")
+			case "default_locale":
+				param = locale.Default
+			}
+			errs[strings.ToLower(e.Field())] = FieldError{
+				Field: e.Field(),
+				Tag:   e.Tag(),
+				Param: param,
 			}
-			errs[strings.ToLower(e.Field())] = message
 		}
-		return apperror.New(
-			"VALIDATION_ERROR",
-			"Validation Failed",
-			http.StatusUnprocessableEntity,
-			errs,
-			string(debug.Stack()),
-		)
+		return apperror.Validation(errs)
 	}
 	return nil
 }
@@ -198,13 +215,7 @@ func StringToInt(str string) (int, error) {
 	}
 	num, err := strconv.Atoi(str)
 	if err != nil {
-		return 0, apperror.New(
-			"INVALID_INT",
-			"Invalid integer value",
-			http.StatusBadRequest,
-			nil,
-			string(debug.Stack()),
-		)
+		return 0, apperror.NewT("INVALID_INT", http.StatusBadRequest, nil, nil)
 	}
 	logger.L().Info(num)
 	return num, nil